@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/internal"
+	userRepository "github.com/victorsantoso/endeus/users/repository"
+	userUsecase "github.com/victorsantoso/endeus/users/usecase"
+)
+
+// CreateAdmin creates an admin account, used to bootstrap the first admin who then invites the other admins
+func CreateAdmin(email, name, password string) error {
+	createAdminDTO := &domain.CreateAdminDTO{
+		Email:    email,
+		Name:     name,
+		Password: password,
+	}
+	// same validation as the registration
+	if err := binding.Validator.ValidateStruct(createAdminDTO); err != nil {
+		return fmt.Errorf("invalid admin: %w", err)
+	}
+	dbConn := internal.NewPostgresConn(internal.ConfigureDatabase())
+	defer dbConn.Close()
+	// creating an admin does not send mails
+	userUsecase := userUsecase.NewUserUsecase(userRepository.NewUserRepository(dbConn), nil)
+	userId, err := userUsecase.CreateAdmin(context.Background(), createAdminDTO)
+	if err != nil {
+		return err
+	}
+	log.Infof("[CreateAdmin] created admin with user_id: %d, email: %s", userId, email)
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/apex/log"
 	"github.com/gin-gonic/gin"
 	"github.com/victorsantoso/endeus/internal"
+	"github.com/victorsantoso/endeus/mailer"
 
 	userHandler "github.com/victorsantoso/endeus/users/http/handler"
 	userRepository "github.com/victorsantoso/endeus/users/repository"
@@ -20,6 +21,9 @@ import (
 	recipeRepository "github.com/victorsantoso/endeus/recipes/repository"
 	recipeUsecase "github.com/victorsantoso/endeus/recipes/usecase"
 
+	discussionHandler "github.com/victorsantoso/endeus/discussions/http/handler"
+	discussionRepository "github.com/victorsantoso/endeus/discussions/repository"
+	discussionUsecase "github.com/victorsantoso/endeus/discussions/usecase"
 )
 
 func Bootstrap(configPath string) error {
@@ -42,14 +46,27 @@ func Bootstrap(configPath string) error {
 	// define domain of applications
 	// user domain
 	userRepository := userRepository.NewUserRepository(dbConn)
-	userUsecase := userUsecase.NewUserUsecase(userRepository)
-	userHandler.NewUserHandler(g, userUsecase)
+	// configure mailer, the outbox driver writes mails to files for local development
+	mailer, err := mailer.NewMailer(internal.ConfigureMailer())
+	if err != nil {
+		return err
+	}
+	userUsecase := userUsecase.NewUserUsecase(userRepository, mailer)
+	// set permission middleware factory, used on routes after the authentication middleware
+	requirePermission := authMiddleware.RequirePermission(userRepository)
+	// set verified email middleware, restricts unverified users when application.require_verified_email is enabled
+	requireVerifiedEmail := authMiddleware.RequireVerifiedEmail(application.RequireVerifiedEmail)
 	// set authentication middleware
 	authMiddleware := authMiddleware.AuthMiddleware(userRepository)
+	userHandler.NewUserHandler(g, authMiddleware, requirePermission, userUsecase)
 	// recipe domain
 	recipeRepository := recipeRepository.NewRecipeRepository(dbConn)
 	recipeUsecase := recipeUsecase.NewRecipeUsecase(recipeRepository)
-	recipeHandler.NewRecipeHandler(g, authMiddleware, recipeUsecase)
+	recipeHandler.NewRecipeHandler(g, authMiddleware, requirePermission, requireVerifiedEmail, recipeUsecase)
+	// discussion domain
+	discussionRepository := discussionRepository.NewDiscussionRepository(dbConn)
+	discussionUsecase := discussionUsecase.NewDiscussionUsecase(discussionRepository)
+	discussionHandler.NewDiscussionHandler(g, authMiddleware, requirePermission, requireVerifiedEmail, discussionUsecase)
 
 	// set gin router with defined application port
 	server := &http.Server{
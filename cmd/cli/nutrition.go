@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/internal"
+
+	recipeRepository "github.com/victorsantoso/endeus/recipes/repository"
+	recipeUsecase "github.com/victorsantoso/endeus/recipes/usecase"
+)
+
+// ingredientNutritionColumns is the header of the nutrition dataset, values are per 100 g and sodium is in mg
+var ingredientNutritionColumns = []string{"name", "aliases", "calories", "protein", "fat", "carbohydrate", "sodium", "grams_per_ml", "grams_per_unit"}
+
+// ImportNutrition loads the ingredient nutrition dataset eg: ./data/ingredient_nutritions.csv and refreshes the nutrition of every recipe
+func ImportNutrition(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	ingredientNutritions, err := parseIngredientNutritions(file)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", filePath, err)
+	}
+	dbConn := internal.NewPostgresConn(internal.ConfigureDatabase())
+	defer dbConn.Close()
+	recipeUsecase := recipeUsecase.NewRecipeUsecase(recipeRepository.NewRecipeRepository(dbConn))
+	if err := recipeUsecase.ImportIngredientNutritions(context.Background(), ingredientNutritions); err != nil {
+		return err
+	}
+	log.Infof("[ImportNutrition] imported %d ingredient nutritions from %s", len(ingredientNutritions), filePath)
+	return nil
+}
+
+func parseIngredientNutritions(r io.Reader) ([]entity.IngredientNutrition, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(ingredientNutritionColumns)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if strings.Join(header, ",") != strings.Join(ingredientNutritionColumns, ",") {
+		return nil, fmt.Errorf("header must be %s", strings.Join(ingredientNutritionColumns, ","))
+	}
+	var ingredientNutritions []entity.IngredientNutrition
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(record[0]) == "" {
+			return nil, fmt.Errorf("line %d: name is required", line)
+		}
+		// calories, protein, fat, carbohydrate, sodium, grams_per_ml and grams_per_unit, empty means 0
+		var values [7]float64
+		for i := range values {
+			field := strings.TrimSpace(record[i+2])
+			if field == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("line %d: %s must be a positive number", line, ingredientNutritionColumns[i+2])
+			}
+			values[i] = value
+		}
+		aliases := []string{}
+		for _, alias := range strings.Split(record[1], ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				aliases = append(aliases, alias)
+			}
+		}
+		ingredientNutritions = append(ingredientNutritions, entity.IngredientNutrition{
+			Name:         record[0],
+			Aliases:      aliases,
+			Calories:     values[0],
+			Protein:      values[1],
+			Fat:          values[2],
+			Carbohydrate: values[3],
+			Sodium:       values[4],
+			GramsPerMl:   values[5],
+			GramsPerUnit: values[6],
+		})
+	}
+	return ingredientNutritions, nil
+}
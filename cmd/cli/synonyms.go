@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/internal"
+
+	recipeRepository "github.com/victorsantoso/endeus/recipes/repository"
+	recipeUsecase "github.com/victorsantoso/endeus/recipes/usecase"
+)
+
+// searchSynonymColumns is the header of the search synonyms dictionary
+var searchSynonymColumns = []string{"term", "replacement"}
+
+// ImportSynonyms loads the search synonyms dictionary eg: ./data/search_synonyms.csv, existing terms are replaced
+func ImportSynonyms(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	searchSynonyms, err := parseSearchSynonyms(file)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", filePath, err)
+	}
+	dbConn := internal.NewPostgresConn(internal.ConfigureDatabase())
+	defer dbConn.Close()
+	recipeUsecase := recipeUsecase.NewRecipeUsecase(recipeRepository.NewRecipeRepository(dbConn))
+	if err := recipeUsecase.ImportSearchSynonyms(context.Background(), searchSynonyms); err != nil {
+		return err
+	}
+	log.Infof("[ImportSynonyms] imported %d search synonyms from %s", len(searchSynonyms), filePath)
+	return nil
+}
+
+func parseSearchSynonyms(r io.Reader) ([]entity.SearchSynonym, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(searchSynonymColumns)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if strings.Join(header, ",") != strings.Join(searchSynonymColumns, ",") {
+		return nil, fmt.Errorf("header must be %s", strings.Join(searchSynonymColumns, ","))
+	}
+	var searchSynonyms []entity.SearchSynonym
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(record[0]) == "" || strings.TrimSpace(record[1]) == "" {
+			return nil, fmt.Errorf("line %d: term and replacement are required", line)
+		}
+		searchSynonyms = append(searchSynonyms, entity.SearchSynonym{
+			Term:        record[0],
+			Replacement: record[1],
+		})
+	}
+	return searchSynonyms, nil
+}
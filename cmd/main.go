@@ -23,6 +23,61 @@ var commands = []*cli.Command{
 			return bootstrap.Bootstrap(config)
 		},
 	},
+	{
+		Name: "import-nutrition",
+		Usage: "import the ingredient nutrition dataset and refresh the nutrition of every recipe",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "file",
+				Aliases: []string{"f"},
+				Value: "./data/ingredient_nutritions.csv",
+				Usage: "-f path of the nutrition dataset eg: -f ./data/ingredient_nutritions.csv",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return bootstrap.ImportNutrition(ctx.String("file"))
+		},
+	},
+	{
+		Name: "import-synonyms",
+		Usage: "import the search synonyms dictionary used by the fuzzy recipe search",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "file",
+				Aliases: []string{"f"},
+				Value: "./data/search_synonyms.csv",
+				Usage: "-f path of the synonyms dictionary eg: -f ./data/search_synonyms.csv",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return bootstrap.ImportSynonyms(ctx.String("file"))
+		},
+	},
+	{
+		Name: "create-admin",
+		Usage: "create an admin account, the other admins are onboarded by invitation",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "email",
+				Required: true,
+				Usage: "--email of the admin eg: --email admin@endeus.id",
+			},
+			&cli.StringFlag{
+				Name: "name",
+				Required: true,
+				Usage: "--name of the admin eg: --name \"Endeus Admin\"",
+			},
+			&cli.StringFlag{
+				Name: "password",
+				EnvVars: []string{"ENDEUS_ADMIN_PASSWORD"},
+				Required: true,
+				Usage: "--password of the admin, prefer the ENDEUS_ADMIN_PASSWORD environment variable to keep it out of the shell history",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return bootstrap.CreateAdmin(ctx.String("email"), ctx.String("name"), ctx.String("password"))
+		},
+	},
 }
 
 func main() {
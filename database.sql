@@ -1,11 +1,34 @@
+-- Extensions, pg_trgm backs the prefix and similarity matching of the suggest endpoint
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- Role Enum
-CREATE TYPE role AS ENUM('ADMIN', 'READER');
+CREATE TYPE role AS ENUM('ADMIN', 'READER', 'EDITOR', 'CONTRIBUTOR');
 
--- Recipe Categories Table
+-- Recipe Categories Table, root categories have no parent eg: Masakan Nusantara -> Jawa -> Jawa Timur
 CREATE TABLE public.recipe_categories (
     category_id SERIAL PRIMARY KEY NOT NULL,
-    category_tag VARCHAR(60) NOT NULL
+    category_tag VARCHAR(60) NOT NULL,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    parent_category_id INTEGER DEFAULT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    cover_image TEXT DEFAULT '' NOT NULL,
+    display_order INTEGER DEFAULT 0 NOT NULL,
+    is_featured BOOLEAN DEFAULT FALSE NOT NULL,
+    CONSTRAINT fk_recipe_categories_parent_category_id FOREIGN KEY(parent_category_id) REFERENCES recipe_categories(category_id),
+    CONSTRAINT ck_recipe_categories_parent_category_id CHECK(parent_category_id <> category_id)
+);
+CREATE INDEX idx_recipe_categories_parent_category_id ON public.recipe_categories(parent_category_id);
+-- Category tags are unique regardless of their case
+CREATE UNIQUE INDEX idx_recipe_categories_lower_category_tag ON public.recipe_categories(lower(category_tag));
+
+-- Recipe Category Slug Redirects Table, former slugs of a renamed category redirect to its current slug
+CREATE TABLE public.recipe_category_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY NOT NULL,
+    category_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_category_slug_redirects_category_id FOREIGN KEY(category_id) REFERENCES recipe_categories(category_id) ON DELETE CASCADE
 );
+CREATE INDEX idx_recipe_category_slug_redirects_category_id ON public.recipe_category_slug_redirects(category_id);
 
 -- Users Table
 CREATE TABLE public.users (
@@ -16,22 +39,212 @@ CREATE TABLE public.users (
     name VARCHAR(60) NOT NULL,
     profile_image TEXT DEFAULT NULL,
     created_at TIMESTAMPTZ NOT NULL,
-    updated_at TIMESTAMPTZ NOT NULL
+    updated_at TIMESTAMPTZ NOT NULL,
+    email_verified_at TIMESTAMPTZ DEFAULT NULL,
+    tokens_valid_after TIMESTAMPTZ DEFAULT NULL
+);
+
+-- Refresh Tokens Table, only the sha256 of a token is stored. A refresh is revoking the token and issuing a new one
+-- of the same family, presenting a revoked token again revokes the whole family.
+CREATE TABLE public.refresh_tokens (
+    refresh_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_refresh_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON public.refresh_tokens(family_id);
+
+-- Revoked Access Tokens Table, jti of the logged out access tokens kept until they expire
+CREATE TABLE public.revoked_access_tokens (
+    jti VARCHAR(64) PRIMARY KEY NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_revoked_access_tokens_expires_at ON public.revoked_access_tokens(expires_at);
+
+-- Password Reset Tokens Table, only the sha256 of a token is stored. A token is single use and expires after an hour,
+-- a new token replaces the unused ones of the user.
+CREATE TABLE public.password_reset_tokens (
+    password_reset_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_password_reset_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
 );
+CREATE INDEX idx_password_reset_tokens_user_id ON public.password_reset_tokens(user_id);
 
--- Recipes Table
+-- Email Verification Tokens Table, only the sha256 of a token is stored. A token verifies the email it was sent to
+-- while the user still has that email, a new token replaces the unused ones of the user.
+CREATE TABLE public.email_verification_tokens (
+    email_verification_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    email VARCHAR(60) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_email_verification_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_email_verification_tokens_user_id ON public.email_verification_tokens(user_id, created_at);
+
+-- Invitations Table, an admin invites an email to register with a role, only the sha256 of the token is stored
+CREATE TABLE public.invitations (
+    invitation_id SERIAL PRIMARY KEY NOT NULL,
+    email VARCHAR(60) NOT NULL,
+    role role NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    invited_by INTEGER DEFAULT NULL,
+    accepted_user_id INTEGER DEFAULT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_invitations_invited_by FOREIGN KEY(invited_by) REFERENCES users(user_id) ON DELETE SET NULL,
+    CONSTRAINT fk_invitations_accepted_user_id FOREIGN KEY(accepted_user_id) REFERENCES users(user_id) ON DELETE SET NULL
+);
+CREATE INDEX idx_invitations_email ON public.invitations(email);
+
+-- Permissions Table, a route requires permissions and a role is granted them by role_permissions eg: "recipe:create"
+CREATE TABLE public.permissions (
+    permission VARCHAR(60) PRIMARY KEY NOT NULL,
+    description VARCHAR(255) NOT NULL
+);
+
+-- Role Permissions Table, a role without rows is granted nothing eg: READER
+CREATE TABLE public.role_permissions (
+    role role NOT NULL,
+    permission VARCHAR(60) NOT NULL,
+    PRIMARY KEY(role, permission),
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY(permission) REFERENCES permissions(permission) ON DELETE CASCADE
+);
+
+INSERT INTO public.permissions (permission, description) VALUES
+    ('recipe:create', 'Create recipes'),
+    ('recipe:update', 'Update recipes and their steps'),
+    ('recipe:delete', 'Delete recipes'),
+    ('recipe:publish', 'Publish and unpublish recipes'),
+    ('category:manage', 'Create, update, delete and reorder recipe categories'),
+    ('tag:manage', 'Create, update and delete tags'),
+    ('search:manage', 'Manage search synonyms'),
+    ('discussion:moderate', 'Remove discussions of any author'),
+    ('user:manage', 'Change roles of users and invite users');
+
+INSERT INTO public.role_permissions (role, permission) VALUES
+    ('ADMIN', 'recipe:create'),
+    ('ADMIN', 'recipe:update'),
+    ('ADMIN', 'recipe:delete'),
+    ('ADMIN', 'recipe:publish'),
+    ('ADMIN', 'category:manage'),
+    ('ADMIN', 'tag:manage'),
+    ('ADMIN', 'search:manage'),
+    ('ADMIN', 'discussion:moderate'),
+    ('ADMIN', 'user:manage'),
+    ('EDITOR', 'recipe:create'),
+    ('EDITOR', 'recipe:update'),
+    ('EDITOR', 'recipe:delete'),
+    ('EDITOR', 'recipe:publish'),
+    ('EDITOR', 'category:manage'),
+    ('EDITOR', 'tag:manage'),
+    ('EDITOR', 'discussion:moderate'),
+    ('CONTRIBUTOR', 'recipe:create'),
+    ('CONTRIBUTOR', 'recipe:update');
+
+-- Recipes Table, a recipe without published_at is a draft that is only shown to users with the recipe:update permission
 CREATE TABLE public.recipes (
     recipe_id SERIAL PRIMARY KEY NOT NULL, 
     category_id INTEGER NOT NULL,
     title VARCHAR(60) NOT NULL,
+    slug VARCHAR(100) UNIQUE NOT NULL,
     header TEXT NOT NULL,
     image_preview TEXT NOT NULL,
     description TEXT DEFAULT NULL,
     estimated_time_minutes INTEGER NOT NULL,
-    recipe_ingredients JSON NOT NULL,
+    servings INTEGER DEFAULT 1 NOT NULL,
+    search_vector TSVECTOR DEFAULT NULL,
+    published_at TIMESTAMPTZ DEFAULT NULL,
     created_at TIMESTAMPTZ NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL,
-    CONSTRAINT fk_recipes_category_id FOREIGN KEY(category_id) REFERENCES recipe_categories(category_id)
+    CONSTRAINT fk_recipes_category_id FOREIGN KEY(category_id) REFERENCES recipe_categories(category_id),
+    CONSTRAINT ck_recipes_servings CHECK(servings BETWEEN 1 AND 100)
+);
+-- Sort indexes, ties are broken by recipe_id for keyset pagination
+CREATE INDEX idx_recipes_created_at ON public.recipes(created_at, recipe_id);
+CREATE INDEX idx_recipes_lower_title ON public.recipes(lower(title), recipe_id);
+CREATE INDEX idx_recipes_estimated_time_minutes ON public.recipes(estimated_time_minutes, recipe_id);
+
+-- Recipe Slug Redirects Table, former slugs of a renamed recipe redirect to its current slug
+CREATE TABLE public.recipe_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_slug_redirects_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_slug_redirects_recipe_id ON public.recipe_slug_redirects(recipe_id);
+
+-- Ingredient Nutritions Table, nutrition of 100 g of an ingredient loaded with the import-nutrition command
+CREATE TABLE public.ingredient_nutritions (
+    nutrition_id SERIAL PRIMARY KEY NOT NULL,
+    name VARCHAR(60) UNIQUE NOT NULL,
+    aliases TEXT[] DEFAULT '{}' NOT NULL,
+    calories NUMERIC(10, 2) NOT NULL,
+    protein NUMERIC(10, 2) NOT NULL,
+    fat NUMERIC(10, 2) NOT NULL,
+    carbohydrate NUMERIC(10, 2) NOT NULL,
+    sodium NUMERIC(10, 2) NOT NULL,
+    grams_per_ml NUMERIC(6, 3) DEFAULT 1 NOT NULL,
+    grams_per_unit NUMERIC(10, 2) DEFAULT NULL,
+    CONSTRAINT ck_ingredient_nutritions_values CHECK(calories >= 0 AND protein >= 0 AND fat >= 0 AND carbohydrate >= 0 AND sodium >= 0),
+    CONSTRAINT ck_ingredient_nutritions_grams_per_ml CHECK(grams_per_ml > 0)
+);
+CREATE INDEX idx_ingredient_nutritions_aliases ON public.ingredient_nutritions USING GIN(aliases);
+
+-- Recipe Ingredients Table, ordered by position and optionally grouped eg: "Bumbu halus"
+CREATE TABLE public.recipe_ingredients (
+    recipe_ingredient_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    name VARCHAR(60) NOT NULL,
+    quantity NUMERIC(10, 3) DEFAULT NULL,
+    unit VARCHAR(20) DEFAULT NULL,
+    note VARCHAR(255) DEFAULT NULL,
+    ingredient_group VARCHAR(60) DEFAULT NULL,
+    nutrition_id INTEGER DEFAULT NULL,
+    CONSTRAINT fk_recipe_ingredients_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_ingredients_nutrition_id FOREIGN KEY(nutrition_id) REFERENCES ingredient_nutritions(nutrition_id) ON DELETE SET NULL,
+    CONSTRAINT ck_recipe_ingredients_quantity CHECK(quantity IS NULL OR quantity >= 0)
+);
+CREATE INDEX idx_recipe_ingredients_recipe_id ON public.recipe_ingredients(recipe_id, position);
+
+-- Recipe Nutritions Table, cached nutrition of one serving computed whenever the recipe is created or updated
+CREATE TABLE public.recipe_nutritions (
+    recipe_id INTEGER PRIMARY KEY NOT NULL,
+    calories NUMERIC(10, 2) NOT NULL,
+    protein NUMERIC(10, 2) NOT NULL,
+    fat NUMERIC(10, 2) NOT NULL,
+    carbohydrate NUMERIC(10, 2) NOT NULL,
+    sodium NUMERIC(10, 2) NOT NULL,
+    is_complete BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_nutritions_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_nutritions_calories ON public.recipe_nutritions(calories);
+
+-- Recipe Steps Table, step numbers are checked at commit so steps can be shifted and reordered within a transaction
+CREATE TABLE public.recipe_steps (
+    recipe_step_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    step_number INTEGER NOT NULL,
+    instruction TEXT NOT NULL,
+    duration_seconds INTEGER DEFAULT NULL,
+    step_image TEXT DEFAULT NULL,
+    CONSTRAINT fk_recipe_steps_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT uq_recipe_steps_recipe_id_step_number UNIQUE(recipe_id, step_number) DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT ck_recipe_steps_step_number CHECK(step_number > 0),
+    CONSTRAINT ck_recipe_steps_duration_seconds CHECK(duration_seconds IS NULL OR duration_seconds > 0)
 );
 
 -- Recipe Ratings Table
@@ -41,8 +254,88 @@ CREATE TABLE public.recipe_ratings (
     recipe_rating INTEGER NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL,
-    CONSTRAINT fk_recipe_ratings_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id),
-    CONSTRAINT fk_recipe_ratings_user_id FOREIGN KEY(user_id) REFERENCES users(user_id)
+    CONSTRAINT fk_recipe_ratings_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_ratings_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT uq_recipe_ratings_recipe_id_user_id UNIQUE(recipe_id, user_id),
+    CONSTRAINT ck_recipe_ratings_recipe_rating CHECK(recipe_rating BETWEEN 1 AND 5)
+);
+
+-- Discussion Testimonials Table, a discussion with parent_discussion_testimonial_id is a reply
+CREATE TABLE public.discussion_testimonials (
+    discussion_testimonial_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    parent_discussion_testimonial_id INTEGER DEFAULT NULL,
+    discussion TEXT NOT NULL,
+    discussion_image TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_discussion_testimonials_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_discussion_testimonials_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_discussion_testimonials_parent_id FOREIGN KEY(parent_discussion_testimonial_id) REFERENCES discussion_testimonials(discussion_testimonial_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_discussion_testimonials_recipe_id ON public.discussion_testimonials(recipe_id, created_at);
+CREATE INDEX idx_discussion_testimonials_parent_id ON public.discussion_testimonials(parent_discussion_testimonial_id, created_at);
+
+-- Recipe Search, search_vector weights title (A), ingredient names (B), header (C) and description (D).
+-- The 'simple' configuration is used since Postgres has no Indonesian dictionary, it lowercases without stemming.
+CREATE FUNCTION recipe_search_vector(p_recipe_id INTEGER, p_title TEXT, p_header TEXT, p_description TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE((SELECT string_agg(name, ' ') FROM recipe_ingredients WHERE recipe_id = p_recipe_id), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE(p_header, '')), 'C')
+        || setweight(to_tsvector('simple', COALESCE(p_description, '')), 'D');
+$$ LANGUAGE SQL STABLE;
+
+CREATE FUNCTION recipes_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := recipe_search_vector(NEW.recipe_id, NEW.title, NEW.header, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER tg_recipes_search_vector BEFORE INSERT OR UPDATE OF title, header, description ON public.recipes
+    FOR EACH ROW EXECUTE FUNCTION recipes_search_vector_trigger();
+
+CREATE FUNCTION recipe_ingredients_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE recipes
+    SET search_vector = recipe_search_vector(recipe_id, title, header, description)
+    WHERE recipe_id = CASE TG_OP WHEN 'DELETE' THEN OLD.recipe_id ELSE NEW.recipe_id END;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER tg_recipe_ingredients_search_vector AFTER INSERT OR UPDATE OR DELETE ON public.recipe_ingredients
+    FOR EACH ROW EXECUTE FUNCTION recipe_ingredients_search_vector_trigger();
+
+CREATE INDEX idx_recipes_search_vector ON public.recipes USING GIN(search_vector);
+CREATE INDEX idx_recipes_category_id ON public.recipes(category_id);
+
+-- Suggest, trigram indexes for type-ahead on recipe titles, category tags and ingredient names
+CREATE INDEX idx_recipes_title_trgm ON public.recipes USING GIN(lower(title) gin_trgm_ops);
+CREATE INDEX idx_recipe_categories_category_tag_trgm ON public.recipe_categories USING GIN(lower(category_tag) gin_trgm_ops);
+CREATE INDEX idx_recipe_ingredients_name_trgm ON public.recipe_ingredients USING GIN(lower(name) gin_trgm_ops);
+
+-- Search Synonyms Table, normalized terms replaced before a fuzzy search eg: "nasgor" -> "nasi goreng"
+CREATE TABLE public.search_synonyms (
+    search_synonym_id SERIAL PRIMARY KEY NOT NULL,
+    term VARCHAR(60) UNIQUE NOT NULL,
+    replacement VARCHAR(120) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
 );
 
--- Not indexed yet for searching etc
\ No newline at end of file
+-- Tags Table, a recipe has one category but any number of tags eg: "Lebaran", "Masakan Padang"
+CREATE TABLE public.tags (
+    tag_id SERIAL PRIMARY KEY NOT NULL,
+    tag_name VARCHAR(60) NOT NULL
+);
+CREATE UNIQUE INDEX idx_tags_lower_tag_name ON public.tags(lower(tag_name));
+
+-- Recipe Tags Table, removed along with their recipe or tag
+CREATE TABLE public.recipe_tags (
+    recipe_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY(recipe_id, tag_id),
+    CONSTRAINT fk_recipe_tags_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_tags_tag_id FOREIGN KEY(tag_id) REFERENCES tags(tag_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_tags_tag_id ON public.recipe_tags(tag_id, recipe_id);
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+const (
+	defaultDiscussionLimit = 10
+	maxDiscussionLimit     = 50
+)
+
+type discussionHandler struct {
+	discussionUsecase domain.DiscussionUsecase
+}
+
+func NewDiscussionHandler(g *gin.Engine, authMiddleware gin.HandlerFunc, requirePermission func(permissions ...string) gin.HandlerFunc, requireVerifiedEmail gin.HandlerFunc, discussionUsecase domain.DiscussionUsecase) {
+	discussionHandler := &discussionHandler{
+		discussionUsecase: discussionUsecase,
+	}
+
+	// No Auth needed to read discussions
+	noAuthGroup := g.Group("/api/v1")
+	noAuthGroup.GET("/recipe/:recipeId/discussions", discussionHandler.GetDiscussionTestimonials)
+	noAuthGroup.GET("/discussion/:discussionTestimonialId/replies", discussionHandler.GetDiscussionTestimonialReplies)
+
+	// Auth group, only the author can update or delete the discussion, posting may require a verified email
+	authGroup := g.Group("/api/v1", authMiddleware)
+	authGroup.POST("/recipe/:recipeId/discussion", requireVerifiedEmail, discussionHandler.CreateDiscussionTestimonial)
+	authGroup.PUT("/discussion/:discussionTestimonialId", requireVerifiedEmail, discussionHandler.UpdateDiscussionTestimonial)
+	authGroup.DELETE("/discussion/:discussionTestimonialId", discussionHandler.DeleteDiscussionTestimonial)
+	// Moderation, removes the discussion of any author
+	authGroup.DELETE("/moderation/discussion/:discussionTestimonialId", requirePermission(domain.PermissionDiscussionModerate), discussionHandler.ModerateDiscussionTestimonial)
+}
+
+func (dh *discussionHandler) CreateDiscussionTestimonial(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.CreateDiscussionTestimonialResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	recipeId, err := strconv.Atoi(c.Param("recipeId"))
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.CreateDiscussionTestimonialResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	createDiscussionTestimonialDTO := &domain.CreateDiscussionTestimonialDTO{}
+	if err := c.ShouldBindJSON(createDiscussionTestimonialDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateDiscussionTestimonialResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := dh.discussionUsecase.CreateDiscussionTestimonial(context.Background(), int64(recipeId), user.UserId, createDiscussionTestimonialDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.CreateDiscussionTestimonialResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		case domain.ErrBadRequest:
+			c.JSON(http.StatusBadRequest, &domain.CreateDiscussionTestimonialResponse{
+				Message: domain.ErrBadRequest.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.CreateDiscussionTestimonialResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.CreateDiscussionTestimonialResponse{
+		Message: "successfully created a new discussion",
+		Code:    http.StatusOK,
+	})
+}
+
+func (dh *discussionHandler) GetDiscussionTestimonials(c *gin.Context) {
+	recipeId, err := strconv.Atoi(c.Param("recipeId"))
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetDiscussionTestimonialsResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	queryFilter.RecipeId = int64(recipeId)
+	dh.getDiscussionTestimonials(c, queryFilter)
+}
+
+func (dh *discussionHandler) GetDiscussionTestimonialReplies(c *gin.Context) {
+	discussionTestimonialId, err := strconv.Atoi(c.Param("discussionTestimonialId"))
+	if err != nil || discussionTestimonialId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetDiscussionTestimonialsResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	queryFilter.ParentDiscussionTestimonialId = int64(discussionTestimonialId)
+	dh.getDiscussionTestimonials(c, queryFilter)
+}
+
+func (dh *discussionHandler) getDiscussionTestimonials(c *gin.Context, queryFilter *domain.GetDiscussionTestimonialsQueryFilter) {
+	discussionTestimonials, err := dh.discussionUsecase.GetDiscussionTestimonials(context.Background(), queryFilter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetDiscussionTestimonialsResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetDiscussionTestimonialsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetDiscussionTestimonialsResponse{
+		DiscussionTestimonials: discussionTestimonials,
+		Message:                "successfully retrieved discussions",
+		Code:                   http.StatusOK,
+	})
+}
+
+func (dh *discussionHandler) UpdateDiscussionTestimonial(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdateDiscussionTestimonialResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	discussionTestimonialId, err := strconv.Atoi(c.Param("discussionTestimonialId"))
+	if err != nil || discussionTestimonialId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateDiscussionTestimonialResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	updateDiscussionTestimonialDTO := &domain.UpdateDiscussionTestimonialDTO{}
+	if err := c.ShouldBindJSON(updateDiscussionTestimonialDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateDiscussionTestimonialResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := dh.discussionUsecase.UpdateDiscussionTestimonial(context.Background(), int64(discussionTestimonialId), user.UserId, updateDiscussionTestimonialDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateDiscussionTestimonialResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		case domain.ErrForbidenAccess:
+			c.JSON(http.StatusForbidden, &domain.UpdateDiscussionTestimonialResponse{
+				Message: domain.ErrForbidenAccess.Error(),
+				Code:    http.StatusForbidden,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.UpdateDiscussionTestimonialResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateDiscussionTestimonialResponse{
+		Message: "successfully updated discussion",
+		Code:    http.StatusOK,
+	})
+}
+
+func (dh *discussionHandler) DeleteDiscussionTestimonial(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.DeleteDiscussionTestimonialResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	discussionTestimonialId, err := strconv.Atoi(c.Param("discussionTestimonialId"))
+	if err != nil || discussionTestimonialId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteDiscussionTestimonialResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := dh.discussionUsecase.DeleteDiscussionTestimonial(context.Background(), int64(discussionTestimonialId), user.UserId); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.DeleteDiscussionTestimonialResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		case domain.ErrForbidenAccess:
+			c.JSON(http.StatusForbidden, &domain.DeleteDiscussionTestimonialResponse{
+				Message: domain.ErrForbidenAccess.Error(),
+				Code:    http.StatusForbidden,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.DeleteDiscussionTestimonialResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteDiscussionTestimonialResponse{
+		Message: "successfully deleted discussion",
+		Code:    http.StatusOK,
+	})
+}
+
+func (dh *discussionHandler) ModerateDiscussionTestimonial(c *gin.Context) {
+	discussionTestimonialId, err := strconv.Atoi(c.Param("discussionTestimonialId"))
+	if err != nil || discussionTestimonialId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteDiscussionTestimonialResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := dh.discussionUsecase.ModerateDiscussionTestimonial(context.Background(), int64(discussionTestimonialId)); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteDiscussionTestimonialResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteDiscussionTestimonialResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteDiscussionTestimonialResponse{
+		Message: "successfully moderated discussion",
+		Code:    http.StatusOK,
+	})
+}
+
+// bindPagination reads limit and offset query, responds with bad request on malformed values
+func bindPagination(c *gin.Context) (*domain.GetDiscussionTestimonialsQueryFilter, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultDiscussionLimit)))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetDiscussionTestimonialsResponse{
+			Message: "invalid limit",
+			Code:    http.StatusBadRequest,
+		})
+		return nil, false
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetDiscussionTestimonialsResponse{
+			Message: "invalid offset",
+			Code:    http.StatusBadRequest,
+		})
+		return nil, false
+	}
+	if limit > maxDiscussionLimit {
+		limit = maxDiscussionLimit
+	}
+	return &domain.GetDiscussionTestimonialsQueryFilter{
+		Limit:  limit,
+		Offset: offset,
+	}, true
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+type discussionRepository struct {
+	dbConn *sql.DB
+}
+
+func NewDiscussionRepository(dbConn *sql.DB) domain.DiscussionRepository {
+	return &discussionRepository{
+		dbConn: dbConn,
+	}
+}
+
+const (
+	// query with variables handling to escape sql injections, drafts cannot be discussed
+	CreateDiscussionTestimonialQuery = `
+		INSERT INTO discussion_testimonials(recipe_id, user_id, parent_discussion_testimonial_id, discussion, discussion_image, created_at, updated_at)
+		SELECT recipe_id, $2::integer, $3::integer, $4::text, NULLIF($5::text, ''), now()::timestamptz, now()::timestamptz
+		FROM recipes
+		WHERE recipe_id = $1 AND published_at IS NOT NULL
+		RETURNING discussion_testimonial_id, created_at, updated_at;
+	`
+	GetDiscussionTestimonialByIdQuery = `
+		SELECT d.discussion_testimonial_id, d.recipe_id, d.user_id, d.parent_discussion_testimonial_id, d.discussion, d.discussion_image, d.created_at, d.updated_at,
+			(SELECT COUNT(*) FROM discussion_testimonials r WHERE r.parent_discussion_testimonial_id = d.discussion_testimonial_id) AS reply_count
+		FROM discussion_testimonials d
+		WHERE d.discussion_testimonial_id = $1
+		LIMIT 1
+	`
+	GetDiscussionTestimonialsQuery = `
+		SELECT d.discussion_testimonial_id, d.recipe_id, d.user_id, d.parent_discussion_testimonial_id, d.discussion, d.discussion_image, d.created_at, d.updated_at,
+			(SELECT COUNT(*) FROM discussion_testimonials r WHERE r.parent_discussion_testimonial_id = d.discussion_testimonial_id) AS reply_count
+		FROM discussion_testimonials d
+	`
+	UpdateDiscussionTestimonialByIdQuery = `
+		UPDATE discussion_testimonials
+		SET discussion = $2, discussion_image = NULLIF($3, ''), updated_at = now()::timestamptz
+		WHERE discussion_testimonial_id = $1;
+	`
+	// replies are deleted along with their parent by ON DELETE CASCADE
+	DeleteDiscussionTestimonialByIdQuery = `
+		DELETE FROM discussion_testimonials
+		WHERE discussion_testimonial_id = $1;
+	`
+)
+
+func (dr *discussionRepository) CreateDiscussionTestimonial(ctx context.Context, discussionTestimonial *entity.DiscussionTestimonial) error {
+	parentDiscussionTestimonialId := sql.NullInt64{
+		Int64: discussionTestimonial.ParentDiscussionTestimonialId,
+		Valid: discussionTestimonial.ParentDiscussionTestimonialId != 0,
+	}
+	row := dr.dbConn.QueryRowContext(ctx, CreateDiscussionTestimonialQuery,
+		discussionTestimonial.RecipeId,
+		discussionTestimonial.UserId,
+		parentDiscussionTestimonialId,
+		discussionTestimonial.Discussion,
+		discussionTestimonial.DiscussionImage,
+	)
+	if err := row.Scan(&discussionTestimonial.DiscussionTestimonialId, &discussionTestimonial.CreatedAt, &discussionTestimonial.UpdatedAt); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23503" { // foreign key violation, parent discussion does not exist
+				return sql.ErrNoRows
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (dr *discussionRepository) GetDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) (*entity.DiscussionTestimonial, error) {
+	row := dr.dbConn.QueryRowContext(ctx, GetDiscussionTestimonialByIdQuery, discussionTestimonialId)
+	discussionTestimonial, err := scanDiscussionTestimonial(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return discussionTestimonial, nil
+}
+
+func (dr *discussionRepository) GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error) {
+	var discussionTestimonials []entity.DiscussionTestimonial
+	query := GetDiscussionTestimonialsQuery
+	var args []interface{}
+	if getDiscussionTestimonialsQueryFilter.ParentDiscussionTestimonialId != 0 {
+		// replies are read as a conversation, oldest first
+		query += " WHERE d.parent_discussion_testimonial_id = $1 ORDER BY d.created_at ASC, d.discussion_testimonial_id ASC"
+		args = append(args, getDiscussionTestimonialsQueryFilter.ParentDiscussionTestimonialId)
+	} else {
+		// top level discussions, newest first
+		query += " WHERE d.recipe_id = $1 AND d.parent_discussion_testimonial_id IS NULL ORDER BY d.created_at DESC, d.discussion_testimonial_id DESC"
+		args = append(args, getDiscussionTestimonialsQueryFilter.RecipeId)
+	}
+	query += " LIMIT $2 OFFSET $3"
+	args = append(args, getDiscussionTestimonialsQueryFilter.Limit, getDiscussionTestimonialsQueryFilter.Offset)
+	rows, err := dr.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		discussionTestimonial, err := scanDiscussionTestimonial(rows)
+		if err != nil {
+			return nil, err
+		}
+		discussionTestimonials = append(discussionTestimonials, *discussionTestimonial)
+	}
+	return discussionTestimonials, nil
+}
+
+func (dr *discussionRepository) UpdateDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64, updateDiscussionTestimonialByIdQueryFilter *domain.UpdateDiscussionTestimonialByIdQueryFilter) error {
+	result, err := dr.dbConn.ExecContext(ctx, UpdateDiscussionTestimonialByIdQuery,
+		discussionTestimonialId,
+		updateDiscussionTestimonialByIdQueryFilter.Discussion,
+		updateDiscussionTestimonialByIdQueryFilter.DiscussionImage,
+	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (dr *discussionRepository) DeleteDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) error {
+	result, err := dr.dbConn.ExecContext(ctx, DeleteDiscussionTestimonialByIdQuery, discussionTestimonialId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDiscussionTestimonial(s scanner) (*entity.DiscussionTestimonial, error) {
+	var discussionTestimonial entity.DiscussionTestimonial
+	var parentDiscussionTestimonialId sql.NullInt64
+	var discussionImage sql.NullString
+	if err := s.Scan(
+		&discussionTestimonial.DiscussionTestimonialId,
+		&discussionTestimonial.RecipeId,
+		&discussionTestimonial.UserId,
+		&parentDiscussionTestimonialId,
+		&discussionTestimonial.Discussion,
+		&discussionImage,
+		&discussionTestimonial.CreatedAt,
+		&discussionTestimonial.UpdatedAt,
+		&discussionTestimonial.ReplyCount,
+	); err != nil {
+		return nil, err
+	}
+	discussionTestimonial.ParentDiscussionTestimonialId = parentDiscussionTestimonialId.Int64
+	discussionTestimonial.DiscussionImage = discussionImage.String
+	return &discussionTestimonial, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/apex/log"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+type discussionUsecase struct {
+	discussionRepository domain.DiscussionRepository
+}
+
+func NewDiscussionUsecase(discussionRepository domain.DiscussionRepository) domain.DiscussionUsecase {
+	return &discussionUsecase{
+		discussionRepository: discussionRepository,
+	}
+}
+
+func (du *discussionUsecase) CreateDiscussionTestimonial(ctx context.Context, recipeId, userId int64, createDiscussionTestimonialDTO *domain.CreateDiscussionTestimonialDTO) error {
+	// a reply must belong to the same recipe as the discussion it replies to
+	if createDiscussionTestimonialDTO.ParentDiscussionTestimonialId != 0 {
+		parentDiscussionTestimonial, err := du.discussionRepository.GetDiscussionTestimonialById(ctx, createDiscussionTestimonialDTO.ParentDiscussionTestimonialId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				log.Debugf("[discussion_usecase.CreateDiscussionTestimonial] no parent discussion found for discussion_testimonial_id: %d", createDiscussionTestimonialDTO.ParentDiscussionTestimonialId)
+				return sql.ErrNoRows
+			}
+			log.Errorf("[discussion_usecase.CreateDiscussionTestimonial] error getting parent discussion, err: %v", err)
+			return err
+		}
+		if parentDiscussionTestimonial.RecipeId != recipeId {
+			log.Debugf("[discussion_usecase.CreateDiscussionTestimonial] parent discussion_testimonial_id: %d does not belong to recipe_id: %d", parentDiscussionTestimonial.DiscussionTestimonialId, recipeId)
+			return domain.ErrBadRequest
+		}
+	}
+	discussionTestimonial := &entity.DiscussionTestimonial{
+		RecipeId:                      recipeId,
+		UserId:                        userId,
+		ParentDiscussionTestimonialId: createDiscussionTestimonialDTO.ParentDiscussionTestimonialId,
+		Discussion:                    createDiscussionTestimonialDTO.Discussion,
+		DiscussionImage:               createDiscussionTestimonialDTO.DiscussionImage,
+	}
+	if err := du.discussionRepository.CreateDiscussionTestimonial(ctx, discussionTestimonial); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[discussion_usecase.CreateDiscussionTestimonial] no recipe found for recipe_id: %d", recipeId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[discussion_usecase.CreateDiscussionTestimonial] error creating a new discussion, err: %v", err)
+		return err
+	}
+	log.Debugf("[discussion_usecase.CreateDiscussionTestimonial] discussion_testimonial_id: %d created", discussionTestimonial.DiscussionTestimonialId)
+	return nil
+}
+
+func (du *discussionUsecase) GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error) {
+	discussionTestimonials, err := du.discussionRepository.GetDiscussionTestimonials(ctx, getDiscussionTestimonialsQueryFilter)
+	if err != nil {
+		log.Errorf("[discussion_usecase.GetDiscussionTestimonials] error getting discussions, err: %v", err)
+		return nil, err
+	}
+	if len(discussionTestimonials) == 0 {
+		log.Debugf("[discussion_usecase.GetDiscussionTestimonials] no rows found for filter: %+v", getDiscussionTestimonialsQueryFilter)
+		return nil, sql.ErrNoRows
+	}
+	return discussionTestimonials, nil
+}
+
+func (du *discussionUsecase) UpdateDiscussionTestimonial(ctx context.Context, discussionTestimonialId, userId int64, updateDiscussionTestimonialDTO *domain.UpdateDiscussionTestimonialDTO) error {
+	if err := du.validateAuthor(ctx, discussionTestimonialId, userId); err != nil {
+		return err
+	}
+	if err := du.discussionRepository.UpdateDiscussionTestimonialById(ctx, discussionTestimonialId, &domain.UpdateDiscussionTestimonialByIdQueryFilter{
+		Discussion:      updateDiscussionTestimonialDTO.Discussion,
+		DiscussionImage: updateDiscussionTestimonialDTO.DiscussionImage,
+	}); err != nil {
+		log.Errorf("[discussion_usecase.UpdateDiscussionTestimonial] error updating discussion_testimonial_id: %d, err: %v", discussionTestimonialId, err)
+		return err
+	}
+	return nil
+}
+
+func (du *discussionUsecase) DeleteDiscussionTestimonial(ctx context.Context, discussionTestimonialId, userId int64) error {
+	if err := du.validateAuthor(ctx, discussionTestimonialId, userId); err != nil {
+		return err
+	}
+	if err := du.discussionRepository.DeleteDiscussionTestimonialById(ctx, discussionTestimonialId); err != nil {
+		log.Errorf("[discussion_usecase.DeleteDiscussionTestimonial] error deleting discussion_testimonial_id: %d, err: %v", discussionTestimonialId, err)
+		return err
+	}
+	return nil
+}
+
+// ModerateDiscussionTestimonial removes a discussion of any author along with its replies
+func (du *discussionUsecase) ModerateDiscussionTestimonial(ctx context.Context, discussionTestimonialId int64) error {
+	if err := du.discussionRepository.DeleteDiscussionTestimonialById(ctx, discussionTestimonialId); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[discussion_usecase.ModerateDiscussionTestimonial] no row found for discussion_testimonial_id: %d", discussionTestimonialId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[discussion_usecase.ModerateDiscussionTestimonial] error deleting discussion_testimonial_id: %d, err: %v", discussionTestimonialId, err)
+		return err
+	}
+	return nil
+}
+
+// validateAuthor only allows the author of the discussion to change it
+func (du *discussionUsecase) validateAuthor(ctx context.Context, discussionTestimonialId, userId int64) error {
+	discussionTestimonial, err := du.discussionRepository.GetDiscussionTestimonialById(ctx, discussionTestimonialId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[discussion_usecase.validateAuthor] no row found for discussion_testimonial_id: %d", discussionTestimonialId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[discussion_usecase.validateAuthor] error getting discussion_testimonial_id: %d, err: %v", discussionTestimonialId, err)
+		return err
+	}
+	if discussionTestimonial.UserId != userId {
+		log.Debugf("[discussion_usecase.validateAuthor] user_id: %d is not the author of discussion_testimonial_id: %d", userId, discussionTestimonialId)
+		return domain.ErrForbidenAccess
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+	mocks "github.com/victorsantoso/endeus/mocks/domain"
+)
+
+func TestDiscussionUsecase_CreateDiscussionTestimonial(t *testing.T) {
+	t.Run("test create top level discussion", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("CreateDiscussionTestimonial", mock.Anything, mock.Anything).Return(nil)
+		err := discussionUsecase.CreateDiscussionTestimonial(context.Background(), 1, 2, &domain.CreateDiscussionTestimonialDTO{
+			Discussion: "Enak banget, bumbunya meresap",
+		})
+		assert.NoError(t, err)
+		mockDiscussionRepository.AssertNotCalled(t, "GetDiscussionTestimonialById", mock.Anything, mock.Anything) // no parent to validate
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+
+	t.Run("test reply to discussion of another recipe", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("GetDiscussionTestimonialById", mock.Anything, int64(10)).Return(&entity.DiscussionTestimonial{
+			DiscussionTestimonialId: 10,
+			RecipeId:                3, // parent belongs to another recipe
+			UserId:                  4,
+		}, nil)
+		err := discussionUsecase.CreateDiscussionTestimonial(context.Background(), 1, 2, &domain.CreateDiscussionTestimonialDTO{
+			Discussion:                    "Setuju!",
+			ParentDiscussionTestimonialId: 10,
+		})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		mockDiscussionRepository.AssertNotCalled(t, "CreateDiscussionTestimonial", mock.Anything, mock.Anything)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+}
+
+func TestDiscussionUsecase_UpdateDiscussionTestimonial(t *testing.T) {
+	updateDiscussionTestimonialDTO := &domain.UpdateDiscussionTestimonialDTO{
+		Discussion: "Ternyata lebih enak pakai santan kental",
+	}
+
+	t.Run("test update by the author", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("GetDiscussionTestimonialById", mock.Anything, int64(10)).Return(&entity.DiscussionTestimonial{
+			DiscussionTestimonialId: 10,
+			RecipeId:                1,
+			UserId:                  2,
+		}, nil)
+		mockDiscussionRepository.On("UpdateDiscussionTestimonialById", mock.Anything, int64(10), mock.Anything).Return(nil)
+		err := discussionUsecase.UpdateDiscussionTestimonial(context.Background(), 10, 2, updateDiscussionTestimonialDTO)
+		assert.NoError(t, err)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+
+	t.Run("test update by another user", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("GetDiscussionTestimonialById", mock.Anything, int64(10)).Return(&entity.DiscussionTestimonial{
+			DiscussionTestimonialId: 10,
+			RecipeId:                1,
+			UserId:                  2,
+		}, nil)
+		err := discussionUsecase.UpdateDiscussionTestimonial(context.Background(), 10, 5, updateDiscussionTestimonialDTO)
+		assert.ErrorIs(t, err, domain.ErrForbidenAccess)
+		mockDiscussionRepository.AssertNotCalled(t, "UpdateDiscussionTestimonialById", mock.Anything, mock.Anything, mock.Anything)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+
+	t.Run("test update unknown discussion", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("GetDiscussionTestimonialById", mock.Anything, int64(99)).Return(nil, sql.ErrNoRows)
+		err := discussionUsecase.UpdateDiscussionTestimonial(context.Background(), 99, 2, updateDiscussionTestimonialDTO)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+}
+
+func TestDiscussionUsecase_DeleteDiscussionTestimonial(t *testing.T) {
+	t.Run("test delete by another user", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("GetDiscussionTestimonialById", mock.Anything, int64(10)).Return(&entity.DiscussionTestimonial{
+			DiscussionTestimonialId: 10,
+			RecipeId:                1,
+			UserId:                  2,
+		}, nil)
+		err := discussionUsecase.DeleteDiscussionTestimonial(context.Background(), 10, 5)
+		assert.ErrorIs(t, err, domain.ErrForbidenAccess)
+		mockDiscussionRepository.AssertNotCalled(t, "DeleteDiscussionTestimonialById", mock.Anything, mock.Anything)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+}
+
+func TestDiscussionUsecase_ModerateDiscussionTestimonial(t *testing.T) {
+	t.Run("test moderate discussion of any author", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("DeleteDiscussionTestimonialById", mock.Anything, int64(10)).Return(nil)
+		err := discussionUsecase.ModerateDiscussionTestimonial(context.Background(), 10)
+		assert.NoError(t, err)
+		mockDiscussionRepository.AssertNotCalled(t, "GetDiscussionTestimonialById", mock.Anything, mock.Anything)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+	t.Run("test moderate unknown discussion", func(t *testing.T) {
+		mockDiscussionRepository := new(mocks.DiscussionRepository)
+		discussionUsecase := NewDiscussionUsecase(mockDiscussionRepository)
+		mockDiscussionRepository.On("DeleteDiscussionTestimonialById", mock.Anything, int64(10)).Return(sql.ErrNoRows)
+		err := discussionUsecase.ModerateDiscussionTestimonial(context.Background(), 10)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		defer mockDiscussionRepository.AssertExpectations(t)
+	})
+}
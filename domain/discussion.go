@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/victorsantoso/endeus/entity"
+)
+
+type DiscussionRepository interface {
+	CreateDiscussionTestimonial(ctx context.Context, discussionTestimonial *entity.DiscussionTestimonial) error
+	GetDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) (*entity.DiscussionTestimonial, error)
+	GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error)
+	UpdateDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64, updateDiscussionTestimonialByIdQueryFilter *UpdateDiscussionTestimonialByIdQueryFilter) error
+	DeleteDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) error
+}
+
+type DiscussionUsecase interface {
+	CreateDiscussionTestimonial(ctx context.Context, recipeId, userId int64, createDiscussionTestimonialDTO *CreateDiscussionTestimonialDTO) error
+	GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error)
+	UpdateDiscussionTestimonial(ctx context.Context, discussionTestimonialId, userId int64, updateDiscussionTestimonialDTO *UpdateDiscussionTestimonialDTO) error
+	DeleteDiscussionTestimonial(ctx context.Context, discussionTestimonialId, userId int64) error
+	ModerateDiscussionTestimonial(ctx context.Context, discussionTestimonialId int64) error
+}
+
+type CreateDiscussionTestimonialDTO struct {
+	Discussion                    string `json:"discussion" binding:"required,min=1,max=2000"`
+	DiscussionImage               string `json:"discussion_image" binding:"omitempty,url"`
+	ParentDiscussionTestimonialId int64  `json:"parent_discussion_testimonial_id,omitempty" binding:"omitempty,min=1"`
+}
+type CreateDiscussionTestimonialResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// GetDiscussionTestimonialsQueryFilter lists top level discussions of RecipeId,
+// or replies of ParentDiscussionTestimonialId when it's set
+type GetDiscussionTestimonialsQueryFilter struct {
+	RecipeId                      int64
+	ParentDiscussionTestimonialId int64
+	Limit                         int
+	Offset                        int
+}
+type GetDiscussionTestimonialsResponse struct {
+	DiscussionTestimonials []entity.DiscussionTestimonial `json:"discussion_testimonials,omitempty"`
+	Message                string                         `json:"message"`
+	Code                   int                            `json:"code"`
+}
+
+type UpdateDiscussionTestimonialDTO struct {
+	Discussion      string `json:"discussion" binding:"required,min=1,max=2000"`
+	DiscussionImage string `json:"discussion_image" binding:"omitempty,url"`
+}
+type UpdateDiscussionTestimonialByIdQueryFilter struct {
+	Discussion      string
+	DiscussionImage string
+}
+type UpdateDiscussionTestimonialResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteDiscussionTestimonialResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
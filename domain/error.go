@@ -12,4 +12,30 @@ var (
 	ErrInvalidRole       = errors.New("invalid role")
 	ErrInvalidId         = errors.New("invalid id")
 	ErrDuplicateUser     = errors.New("duplicate entry")
+
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, every session of this login has been revoked")
+
+	ErrInvalidInvitation   = errors.New("invitation is invalid, expired or already accepted")
+	ErrCannotChangeOwnRole = errors.New("admins cannot change their own role")
+
+	ErrInvalidPasswordResetToken = errors.New("password reset token is invalid, expired or already used")
+
+	ErrInvalidEmailVerificationToken = errors.New("email verification token is invalid, expired or already used")
+	ErrEmailAlreadyVerified          = errors.New("email is already verified")
+	ErrEmailVerificationThrottled    = errors.New("a verification email was sent recently, please try again later")
+	ErrEmailNotVerified              = errors.New("please verify your email first")
+
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrUnknownRecipeSort = errors.New("sort must be one of: newest, oldest, title, quickest, top_rated, or relevance when searching with q")
+
+	ErrDuplicateSearchSynonym = errors.New("search synonym term already exists")
+
+	ErrDuplicateTag = errors.New("tag already exists")
+	ErrUnknownTag   = errors.New("unknown tag_id")
+
+	ErrUnknownParentCategory   = errors.New("unknown parent_category_id")
+	ErrInvalidReassignCategory = errors.New("reassign_to must be another existing category")
+	ErrDuplicateCategory       = errors.New("category_tag already exists")
+	ErrCategoryCycle           = errors.New("parent_category_id must not be the category itself or one of its sub categories")
 )
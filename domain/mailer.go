@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// Mailer sends the transactional emails of the application eg: password reset links
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+// Mail is a plain text email to a single recipient
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
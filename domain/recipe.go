@@ -2,23 +2,59 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/search"
+	"github.com/victorsantoso/endeus/units"
 )
 
 type RecipeRepository interface {
 	// Recipe Categories
-	CreateRecipeCategory(ctx context.Context, categoryTag string) error
+	CreateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error
 	GetRecipeCategoryById(ctx context.Context, categoryId int64) (*entity.RecipeCategory, error)
 	GetRecipeCategories(ctx context.Context) ([]entity.RecipeCategory, error)
+	UpdateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error
+	DeleteRecipeCategory(ctx context.Context, categoryId, reassignToCategoryId int64) error
+	ReorderRecipeCategories(ctx context.Context, categoryIds []int64) error
+	ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error)
+	// Tags
+	GetTags(ctx context.Context) ([]entity.Tag, error)
+	CreateTag(ctx context.Context, tag *entity.Tag) error
+	UpdateTag(ctx context.Context, tag *entity.Tag) error
+	DeleteTag(ctx context.Context, tagId int64) error
 	// Recipes
 	CreateRecipe(ctx context.Context, recipe *entity.Recipe) error
 	GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error)
-	GetRecipes(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) ([]entity.Recipe, error)
+	ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error)
+	GetRecipes(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) (*entity.RecipePage, error)
+	GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) (*entity.RecipeFacets, error)
+	GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error)
+	GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error)
 	UpdateRecipeById(ctx context.Context, recipeId int64, updateRecipeByIdQueryFilter *UpdateRecipeByIdQueryFilter) error
 	DeleteRecipeById(ctx context.Context, recipeId int64) error
+	UpdateRecipePublished(ctx context.Context, recipeId int64, published bool) error
+	// Recipe Steps
+	GetRecipeStepsByRecipeId(ctx context.Context, recipeId int64) ([]entity.RecipeStep, error)
+	CreateRecipeStep(ctx context.Context, recipeId int64, recipeStep *entity.RecipeStep) error
+	ReorderRecipeSteps(ctx context.Context, recipeId int64, recipeStepIds []int64) error
+	DeleteRecipeStep(ctx context.Context, recipeId, recipeStepId int64) error
+	// Recipe Nutritions
+	GetRecipeIds(ctx context.Context) ([]int64, error)
+	UpsertIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error
+	GetIngredientNutritionsByRecipeId(ctx context.Context, recipeId int64) (map[int64]entity.IngredientNutrition, error)
+	UpsertRecipeNutrition(ctx context.Context, recipeId int64, recipeNutrition *entity.RecipeNutrition) error
+	// Search Synonyms
+	GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error)
+	CreateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error
+	UpdateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error
+	DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error
+	UpsertSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error
 	// Recipe Ratings
 	CreateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error
+	UpdateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error
+	DeleteRecipeRating(ctx context.Context, recipeId, userId int64) error
+	GetRecipeRatingByUserId(ctx context.Context, recipeId, userId int64) (*entity.RecipeRating, error)
 	GetRecipeRatingSummary(ctx context.Context, recipeId int64) (float64, int, error)
 }
 
@@ -27,18 +63,55 @@ type RecipeUsecase interface {
 	CreateRecipeCategory(ctx context.Context, createRecipeCategoryDTO *CreateRecipeCategoryDTO) error
 	GetRecipeCategoryById(ctx context.Context, categoryId int64) (*entity.RecipeCategory, error)
 	GetRecipeCategories(ctx context.Context) ([]entity.RecipeCategory, error)
+	UpdateRecipeCategory(ctx context.Context, categoryId int64, updateRecipeCategoryDTO *UpdateRecipeCategoryDTO) error
+	DeleteRecipeCategory(ctx context.Context, categoryId, reassignToCategoryId int64) error
+	ReorderRecipeCategories(ctx context.Context, reorderRecipeCategoriesDTO *ReorderRecipeCategoriesDTO) error
+	ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error)
+	// Tags
+	GetTags(ctx context.Context) ([]entity.Tag, error)
+	CreateTag(ctx context.Context, tagDTO *TagDTO) error
+	UpdateTag(ctx context.Context, tagId int64, tagDTO *TagDTO) error
+	DeleteTag(ctx context.Context, tagId int64) error
 	// Recipes
 	CreateRecipe(ctx context.Context, createRecipeDTO *CreateRecipeDTO) error
-	GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error)
-	GetRecipes(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) ([]entity.Recipe, error)
+	GetRecipeById(ctx context.Context, recipeId int64, getRecipeByIdQueryFilter *GetRecipeByIdQueryFilter) (*entity.Recipe, error)
+	ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error)
+	GetRecipes(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) (*entity.RecipePage, error)
+	GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *GetRecipesQueryFilter) (*entity.RecipeFacets, error)
+	GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error)
+	GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error)
 	UpdateRecipe(ctx context.Context, recipeId int64, updateRecipeDTO *UpdateRecipeDTO) error
 	DeleteRecipeById(ctx context.Context, recipeId int64) error
+	PublishRecipe(ctx context.Context, recipeId int64, published bool) error
+	// Recipe Steps
+	GetRecipeSteps(ctx context.Context, recipeId int64, includeDrafts bool) ([]entity.RecipeStep, error)
+	CreateRecipeStep(ctx context.Context, recipeId int64, createRecipeStepDTO *CreateRecipeStepDTO) error
+	ReorderRecipeSteps(ctx context.Context, recipeId int64, reorderRecipeStepsDTO *ReorderRecipeStepsDTO) error
+	DeleteRecipeStep(ctx context.Context, recipeId, recipeStepId int64) error
+	// Recipe Nutritions
+	ImportIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error
+	RefreshRecipeNutrition(ctx context.Context, recipeId int64) error
+	// Search Synonyms
+	GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error)
+	CreateSearchSynonym(ctx context.Context, searchSynonymDTO *SearchSynonymDTO) error
+	UpdateSearchSynonym(ctx context.Context, searchSynonymId int64, searchSynonymDTO *SearchSynonymDTO) error
+	DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error
+	ImportSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error
 	// Recipe Ratings
+	CreateRecipeRating(ctx context.Context, recipeId, userId int64, createRecipeRatingDTO *CreateRecipeRatingDTO) error
+	UpdateRecipeRating(ctx context.Context, recipeId, userId int64, updateRecipeRatingDTO *UpdateRecipeRatingDTO) error
+	DeleteRecipeRating(ctx context.Context, recipeId, userId int64) error
+	GetRecipeRatingSummary(ctx context.Context, recipeId, userId int64) (*entity.RecipeRatingSummary, error)
 }
 
 // Recipe Categories
 type CreateRecipeCategoryDTO struct {
-	CategoryTag string `json:"category_tag" binding:"required,min=3,max=60"`
+	CategoryTag      string `json:"category_tag" binding:"required,min=3,max=60"`
+	Description      string `json:"description,omitempty" binding:"max=1000"`
+	CoverImage       string `json:"cover_image,omitempty" binding:"omitempty,url"`
+	ParentCategoryId int64  `json:"parent_category_id,omitempty" binding:"omitempty,min=1"` // empty creates a root category
+	DisplayOrder     int    `json:"display_order,omitempty" binding:"min=0"`
+	IsFeatured       bool   `json:"is_featured,omitempty"`
 }
 
 type CreateRecipeCategoryResponse struct {
@@ -58,54 +131,277 @@ type GetRecipeCategoriesResponse struct {
 	Code             int                     `json:"code"`
 }
 
+type UpdateRecipeCategoryDTO struct {
+	CategoryTag      string `json:"category_tag" binding:"required,min=3,max=60"`
+	Description      string `json:"description,omitempty" binding:"max=1000"`
+	CoverImage       string `json:"cover_image,omitempty" binding:"omitempty,url"`
+	ParentCategoryId int64  `json:"parent_category_id,omitempty" binding:"omitempty,min=1"` // empty moves the category to the root
+	DisplayOrder     int    `json:"display_order,omitempty" binding:"min=0"`
+	IsFeatured       bool   `json:"is_featured,omitempty"`
+}
+
+type UpdateRecipeCategoryResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteRecipeCategoryResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// ReorderRecipeCategoriesDTO lists categories in their new display order, categories left out keep their display order
+type ReorderRecipeCategoriesDTO struct {
+	CategoryIds []int64 `json:"category_ids" binding:"required,min=1,max=500,dive,min=1"`
+}
+type ReorderRecipeCategoriesResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// Tags
+// MaxRecipeTags limits the tags of a recipe and the tags of a recipe listing filter
+const MaxRecipeTags int = 20
+
+// TagMode is how the tags of a recipe listing filter are matched
+type TagMode string
+
+const (
+	TagModeAnd TagMode = "and" // recipes with every tag
+	TagModeOr  TagMode = "or"  // recipes with any of the tags
+)
+
+type TagDTO struct {
+	TagName string `json:"tag_name" binding:"required,min=2,max=60"`
+}
+type GetTagsResponse struct {
+	Tags    []entity.Tag `json:"tags"`
+	Message string       `json:"message"`
+	Code    int          `json:"code"`
+}
+
+type CreateTagResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type UpdateTagResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteTagResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
 // Recipes
+
+// MaxServings limits both the stored servings of a recipe and the servings it can be rescaled to
+const MaxServings int = 100
+
+// MaxSearchQueryLength limits the q query of GetRecipes
+const MaxSearchQueryLength int = 100
+
+// MaxRecipesLimit caps the limit of recipe listings
+const MaxRecipesLimit int = 50
+
+// MinSuggestQueryLength and MaxSuggestQueryLength bound the q query of GetSuggestions
+const (
+	MinSuggestQueryLength int = 2
+	MaxSuggestQueryLength int = 60
+)
+
+// MaxSuggestionsPerType caps the limit of each suggestion type
+const MaxSuggestionsPerType int = 10
+
+// MaxIngredientNames limits the have and exclude ingredient names of GetRecipesByIngredients
+const MaxIngredientNames int = 20
+
+type RecipeIngredientDTO struct {
+	Name     string  `json:"name" binding:"required,min=1,max=60"`
+	Quantity float64 `json:"quantity" binding:"gte=0"`
+	Unit     string  `json:"unit" binding:"max=20"`
+	Note     string  `json:"note" binding:"max=255"`
+	Group    string  `json:"group" binding:"max=60"`
+}
+
+type RecipeStepDTO struct {
+	Instruction     string `json:"instruction" binding:"required,min=1,max=2000"`
+	DurationSeconds int    `json:"duration_seconds" binding:"gte=0,max=86400"`
+	StepImage       string `json:"step_image" binding:"omitempty,url"`
+}
+
 type CreateRecipeDTO struct {
-	Title                string      `json:"title" binding:"required,min=6,max=60"`
-	Header               string      `json:"header" binding:"required"`
-	ImagePreview         string      `json:"image_preview" binding:"required"`
-	Description          string      `json:"description,omitempty"`
-	RecipeIngredients    interface{} `json:"recipe_ingredients" binding:"required"`
-	CategoryId           int64       `json:"category_id" binding:"required,min=1"`
-	EstimatedTimeMinutes int         `json:"estimated_time_minutes" binding:"required,min=3"`
+	Title                string                `json:"title" binding:"required,min=6,max=60"`
+	Header               string                `json:"header" binding:"required"`
+	ImagePreview         string                `json:"image_preview" binding:"required"`
+	Description          string                `json:"description,omitempty"`
+	RecipeIngredients    []RecipeIngredientDTO `json:"recipe_ingredients" binding:"required,min=1,max=100,dive"`
+	RecipeSteps          []RecipeStepDTO       `json:"recipe_steps,omitempty" binding:"omitempty,max=100,dive"`
+	TagIds               []int64               `json:"tag_ids,omitempty" binding:"omitempty,max=20,dive,min=1"`
+	CategoryId           int64                 `json:"category_id" binding:"required,min=1"`
+	EstimatedTimeMinutes int                   `json:"estimated_time_minutes" binding:"required,min=3"`
+	Servings             int                   `json:"servings,omitempty" binding:"omitempty,min=1,max=100"` // defaults to 1 serving
 }
 type CreateRecipeResponse struct {
 	Message string `json:"message"`
 	Code    int    `json:"code"`
 }
+
+// GetRecipeByIdQueryFilter rescales ingredient quantities to Servings portions, 0 keeps the recipe servings
+type GetRecipeByIdQueryFilter struct {
+	Units         units.System
+	Servings      int
+	IncludeDrafts bool // a draft is not found unless the user can update recipes
+}
 type GetRecipeByIdResponse struct {
 	Recipe  *entity.Recipe `json:"recipe,omitempty"`
 	Message string         `json:"message"`
 	Code    int            `json:"code"`
 }
 
-type GetRecipesQueryFilter struct {
+// RecipeTimeBucket is an estimated time range of the time filter, MaxMinutes 0 means unbounded
+type RecipeTimeBucket struct {
 	Name       string
-	CategoryId int64
-	Limit      int
-	Offset     int
+	MinMinutes int
+	MaxMinutes int
+}
+
+// Contains reports whether estimatedTimeMinutes falls in the bucket, MinMinutes inclusive and MaxMinutes exclusive
+func (tb RecipeTimeBucket) Contains(estimatedTimeMinutes int) bool {
+	return estimatedTimeMinutes >= tb.MinMinutes && (tb.MaxMinutes == 0 || estimatedTimeMinutes < tb.MaxMinutes)
+}
+
+// RecipeTimeBuckets are ordered from the quickest
+var RecipeTimeBuckets = []RecipeTimeBucket{
+	{Name: "under_15", MinMinutes: 0, MaxMinutes: 15},
+	{Name: "15_30", MinMinutes: 15, MaxMinutes: 30},
+	{Name: "30_60", MinMinutes: 30, MaxMinutes: 60},
+	{Name: "60_plus", MinMinutes: 60},
+}
+
+func GetRecipeTimeBucket(name string) (RecipeTimeBucket, bool) {
+	for _, timeBucket := range RecipeTimeBuckets {
+		if timeBucket.Name == name {
+			return timeBucket, true
+		}
+	}
+	return RecipeTimeBucket{}, false
+}
+
+// RecipeSort is the order of a recipe listing, ties are always broken by recipe_id
+type RecipeSort string
+
+const (
+	SortNewest    RecipeSort = "newest"
+	SortOldest    RecipeSort = "oldest"
+	SortTitle     RecipeSort = "title"
+	SortQuickest  RecipeSort = "quickest"
+	SortTopRated  RecipeSort = "top_rated" // unrated recipes come last
+	SortRelevance RecipeSort = "relevance" // only with a search query
+)
+
+// ParseRecipeSort parses the sort query, empty means SortRelevance when searching and SortNewest otherwise
+func ParseRecipeSort(sort string, searching bool) (RecipeSort, error) {
+	switch RecipeSort(sort) {
+	case "":
+		if searching {
+			return SortRelevance, nil
+		}
+		return SortNewest, nil
+	case SortNewest, SortOldest, SortTitle, SortQuickest, SortTopRated:
+		return RecipeSort(sort), nil
+	case SortRelevance:
+		if searching {
+			return SortRelevance, nil
+		}
+	}
+	return "", ErrUnknownRecipeSort
+}
+
+// GetRecipesQueryFilter pages with Cursor (the next_cursor of the previous page) when given, otherwise with Offset.
+// With Fuzzy the usecase fills FuzzySearch and FuzzyName so SearchQuery and Name tolerate synonyms, affixes and typos
+type GetRecipesQueryFilter struct {
+	FuzzySearch        search.Expansion
+	FuzzyName          search.Expansion
+	CreatedAfter       time.Time // inclusive, zero means no lower bound
+	CreatedBefore      time.Time // exclusive, zero means no upper bound
+	SearchQuery        string    // full-text search over title, ingredients, header and description
+	Name               string
+	Tags               []string // lowercased tag names matched according to TagMode
+	TagMode            TagMode
+	TimeBucket         string // one of RecipeTimeBuckets names
+	Sort               RecipeSort
+	Cursor             string
+	Units              units.System
+	HasImage           *bool // nil lists recipes with and without an image preview
+	CategoryId         int64
+	MaxCalories        float64 // calories per serving, recipes without computed nutrition are excluded
+	MinRating          int     // minimum average rating, unrated recipes are excluded
+	MinTime            int     // minimum estimated_time_minutes, inclusive
+	MaxTime            int     // maximum estimated_time_minutes, inclusive, 0 means no upper bound
+	Limit              int
+	Offset             int
+	Fuzzy              bool
+	IncludeDescendants bool // also lists the recipes of every category below CategoryId
+	Drafts             bool // lists the drafts instead of the published recipes
 }
 type GetRecipesResponse struct {
-	Recipes []entity.Recipe `json:"recipes,omitempty"`
-	Message string          `json:"message"`
-	Code    int             `json:"code"`
+	Recipes    []entity.Recipe      `json:"recipes,omitempty"`
+	Facets     *entity.RecipeFacets `json:"facets,omitempty"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	Message    string               `json:"message"`
+	Total      int                  `json:"total"`
+	Code       int                  `json:"code"`
+	HasMore    bool                 `json:"has_more"`
 }
 
+// GetRecipesByIngredientsQueryFilter matches ingredient names by word prefix, at least one Have name is required
+type GetRecipesByIngredientsQueryFilter struct {
+	Have    []string
+	Exclude []string
+	Units   units.System
+	Limit   int
+	Offset  int
+}
+type GetRecipesByIngredientsResponse struct {
+	RecipeMatches []entity.RecipeMatch `json:"recipe_matches,omitempty"`
+	Message       string               `json:"message"`
+	Code          int                  `json:"code"`
+}
+
+type GetSuggestionsResponse struct {
+	Suggestions []entity.Suggestion `json:"suggestions"`
+	Message     string              `json:"message"`
+	Code        int                 `json:"code"`
+}
+
+// UpdateRecipeDTO only updates the given fields, RecipeIngredients and RecipeSteps replace all ingredients/steps of the recipe when given
 type UpdateRecipeDTO struct {
-	Title                string      `json:"title,omitempty"`
-	Header               string      `json:"header,omitempty"`
-	ImagePreview         string      `json:"image_preview,omitempty"`
-	Description          string      `json:"description,omitempty"`
-	RecipeIngredients    interface{} `json:"recipe_ingredients,omitempty"`
-	CategoryId           int64       `json:"category_id,omitempty" binding:"min=1"`
-	EstimatedTimeMinutes int         `json:"estimated_time_minutes,omitempty" binding:"min=3"`
+	Title                string                `json:"title,omitempty" binding:"omitempty,min=6,max=60"`
+	Header               string                `json:"header,omitempty"`
+	ImagePreview         string                `json:"image_preview,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	RecipeIngredients    []RecipeIngredientDTO `json:"recipe_ingredients,omitempty" binding:"omitempty,min=1,max=100,dive"`
+	RecipeSteps          []RecipeStepDTO       `json:"recipe_steps,omitempty" binding:"omitempty,max=100,dive"`
+	TagIds               []int64               `json:"tag_ids,omitempty" binding:"omitempty,max=20,dive,min=1"` // replaces every tag, [] removes them
+	CategoryId           int64                 `json:"category_id,omitempty" binding:"omitempty,min=1"`
+	EstimatedTimeMinutes int                   `json:"estimated_time_minutes,omitempty" binding:"omitempty,min=3"`
+	Servings             int                   `json:"servings,omitempty" binding:"omitempty,min=1,max=100"`
 }
 type UpdateRecipeByIdQueryFilter struct {
 	Title                string
+	Slug                 string // slug of the new Title, made unique by the repository
 	Header               string
 	ImagePreview         string
 	Description          string
-	RecipeIngredients    interface{}
+	RecipeIngredients    []entity.RecipeIngredient
+	RecipeSteps          []entity.RecipeStep
+	Tags                 []entity.Tag // nil leaves the tags untouched
+	CategoryId           int64
 	EstimatedTimeMinutes int
+	Servings             int
 }
 type UpdateRecipeResponse struct {
 	Message string `json:"message"`
@@ -117,7 +413,92 @@ type DeleteRecipeResponse struct {
 	Code    int    `json:"code"`
 }
 
-// // Recipe Ratings
-// type CreateRecipeRatingDTO struct {
-// 	Rating int `json:"rating" binding:"required,min=1,max=5"`
-// }
+type PublishRecipeResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// Recipe Steps
+type GetRecipeStepsResponse struct {
+	RecipeSteps []entity.RecipeStep `json:"recipe_steps,omitempty"`
+	Message     string              `json:"message"`
+	Code        int                 `json:"code"`
+}
+
+// CreateRecipeStepDTO inserts a step at StepNumber and shifts the following steps, the step is appended when StepNumber is empty
+type CreateRecipeStepDTO struct {
+	RecipeStepDTO
+	StepNumber int `json:"step_number,omitempty" binding:"omitempty,min=1"`
+}
+type CreateRecipeStepResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// ReorderRecipeStepsDTO contains every step id of the recipe in the new order
+type ReorderRecipeStepsDTO struct {
+	RecipeStepIds []int64 `json:"recipe_step_ids" binding:"required,min=1,dive,min=1"`
+}
+type ReorderRecipeStepsResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteRecipeStepResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// Search Synonyms
+type SearchSynonymDTO struct {
+	Term        string `json:"term" binding:"required,max=60"`
+	Replacement string `json:"replacement" binding:"required,max=120"`
+}
+type GetSearchSynonymsResponse struct {
+	SearchSynonyms []entity.SearchSynonym `json:"search_synonyms"`
+	Message        string                 `json:"message"`
+	Code           int                    `json:"code"`
+}
+
+type CreateSearchSynonymResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type UpdateSearchSynonymResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteSearchSynonymResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// Recipe Ratings
+type CreateRecipeRatingDTO struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"`
+}
+type CreateRecipeRatingResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type UpdateRecipeRatingDTO struct {
+	Rating int `json:"rating" binding:"required,min=1,max=5"`
+}
+type UpdateRecipeRatingResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteRecipeRatingResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type GetRecipeRatingSummaryResponse struct {
+	RecipeRatingSummary *entity.RecipeRatingSummary `json:"recipe_rating_summary,omitempty"`
+	Message             string                      `json:"message"`
+	Code                int                         `json:"code"`
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/victorsantoso/endeus/entity"
 )
@@ -10,39 +11,270 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) (string, int64, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindById(ctx context.Context, userId int64) (*entity.User, error)
+	CreateRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error
+	RotateRefreshToken(ctx context.Context, tokenHash string, nextRefreshToken *entity.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, userId int64, tokenHash string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	UpdateUserRole(ctx context.Context, userId int64, role string) error
+	CreateInvitation(ctx context.Context, invitation *entity.Invitation) error
+	GetInvitations(ctx context.Context) ([]entity.Invitation, error)
+	DeleteInvitation(ctx context.Context, invitationId int64) error
+	AcceptInvitation(ctx context.Context, tokenHash string, user *entity.User) error
+	GetRolePermissions(ctx context.Context, role string) ([]string, error)
+	UpdateUserProfile(ctx context.Context, userId int64, name string, profileImage string) error
+	UpdateUserPassword(ctx context.Context, userId int64, password string) error
+	UpdateUserEmail(ctx context.Context, userId int64, email string) error
+	DeleteUser(ctx context.Context, userId int64) error
+	CreatePasswordResetToken(ctx context.Context, passwordResetToken *entity.PasswordResetToken) error
+	GetLatestPasswordResetToken(ctx context.Context, userId int64) (*entity.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenHash string, password string) (int64, error)
+	CreateEmailVerificationToken(ctx context.Context, emailVerificationToken *entity.EmailVerificationToken) error
+	GetLatestEmailVerificationToken(ctx context.Context, userId int64) (*entity.EmailVerificationToken, error)
+	VerifyEmail(ctx context.Context, tokenHash string) (int64, error)
 }
 
 type UserUsecase interface {
-	Register(ctx context.Context, registerDTO *RegisterDTO) (string, error)
-	Login(ctx context.Context, loginDTO *LoginDTO) (string, error)
+	Register(ctx context.Context, registerDTO *RegisterDTO) (*AuthTokens, error)
+	Login(ctx context.Context, loginDTO *LoginDTO) (*AuthTokens, error)
+	RefreshToken(ctx context.Context, refreshTokenDTO *RefreshTokenDTO) (*AuthTokens, error)
+	Logout(ctx context.Context, userId int64, jti string, accessTokenExpiresAt time.Time, logoutDTO *LogoutDTO) error
+	CreateAdmin(ctx context.Context, createAdminDTO *CreateAdminDTO) (int64, error)
+	UpdateUserRole(ctx context.Context, adminId int64, userId int64, updateUserRoleDTO *UpdateUserRoleDTO) error
+	CreateInvitation(ctx context.Context, adminId int64, createInvitationDTO *CreateInvitationDTO) (*entity.Invitation, string, error)
+	GetInvitations(ctx context.Context) ([]entity.Invitation, error)
+	DeleteInvitation(ctx context.Context, invitationId int64) error
+	AcceptInvitation(ctx context.Context, acceptInvitationDTO *AcceptInvitationDTO) (*AuthTokens, error)
+	GetMe(ctx context.Context, userId int64) (*entity.User, error)
+	UpdateMe(ctx context.Context, userId int64, updateMeDTO *UpdateMeDTO) (*entity.User, error)
+	UpdatePassword(ctx context.Context, userId int64, updatePasswordDTO *UpdatePasswordDTO) (*AuthTokens, error)
+	UpdateEmail(ctx context.Context, userId int64, updateEmailDTO *UpdateEmailDTO) error
+	DeleteMe(ctx context.Context, userId int64, deleteMeDTO *DeleteMeDTO) error
+	ForgotPassword(ctx context.Context, forgotPasswordDTO *ForgotPasswordDTO) error
+	ResetPassword(ctx context.Context, resetPasswordDTO *ResetPasswordDTO) error
+	VerifyEmail(ctx context.Context, verifyEmailDTO *VerifyEmailDTO) error
+	ResendEmailVerification(ctx context.Context, userId int64) error
 }
 
 const (
-	ADMIN  string = "ADMIN"
-	READER string = "READER"
+	ADMIN       string = "ADMIN"
+	EDITOR      string = "EDITOR"
+	CONTRIBUTOR string = "CONTRIBUTOR"
+	READER      string = "READER"
 )
 
+// Permissions granted to a role by the role_permissions table
+const (
+	PermissionRecipeCreate       string = "recipe:create"
+	PermissionRecipeUpdate       string = "recipe:update"
+	PermissionRecipeDelete       string = "recipe:delete"
+	PermissionRecipePublish      string = "recipe:publish"
+	PermissionCategoryManage     string = "category:manage"
+	PermissionTagManage          string = "tag:manage"
+	PermissionSearchManage       string = "search:manage"
+	PermissionDiscussionModerate string = "discussion:moderate"
+	PermissionUserManage         string = "user:manage"
+)
+
+// RegisterDTO of a self registration, a registered user is always a READER
 type RegisterDTO struct {
-	Role         string `json:"role" binding:"required,oneof=ADMIN READER"`
 	Email        string `json:"email" binding:"required,email,min=9,max=60"`
 	Password     string `json:"password" binding:"required,min=6,max=20"`
 	Name         string `json:"name" binding:"required,min=3,max=60"`
 	ProfileImage string `json:"profile_image"`
 }
 
+// CreateAdminDTO of the create-admin command bootstrapping the first admin
+type CreateAdminDTO struct {
+	Email    string `json:"email" binding:"required,email,min=9,max=60"`
+	Password string `json:"password" binding:"required,min=6,max=20"`
+	Name     string `json:"name" binding:"required,min=3,max=60"`
+}
+
+type UpdateUserRoleDTO struct {
+	Role string `json:"role" binding:"required,oneof=ADMIN EDITOR CONTRIBUTOR READER"`
+}
+
+// CreateInvitationDTO invites an email to register with Role, defaults to ADMIN
+type CreateInvitationDTO struct {
+	Email string `json:"email" binding:"required,email,min=9,max=60"`
+	Role  string `json:"role" binding:"omitempty,oneof=ADMIN EDITOR CONTRIBUTOR"`
+}
+
+// AcceptInvitationDTO registers the invited email with the role of the invitation
+type AcceptInvitationDTO struct {
+	Token        string `json:"token" binding:"required,max=128"`
+	Password     string `json:"password" binding:"required,min=6,max=20"`
+	Name         string `json:"name" binding:"required,min=3,max=60"`
+	ProfileImage string `json:"profile_image"`
+}
+
 type LoginDTO struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token" binding:"required,max=128"`
+}
+
+// LogoutDTO revokes the family of the refresh token besides the access token of the request
+type LogoutDTO struct {
+	RefreshToken string `json:"refresh_token" binding:"required,max=128"`
+}
+
+// UpdateMeDTO replaces the profile of the current user, an empty ProfileImage removes it
+type UpdateMeDTO struct {
+	Name         string `json:"name" binding:"required,min=3,max=60"`
+	ProfileImage string `json:"profile_image"`
+}
+
+// UpdatePasswordDTO changes the password of the current user, every other session is logged out
+type UpdatePasswordDTO struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6,max=20"`
+}
+
+// UpdateEmailDTO changes the email of the current user, the current password re-verifies the user
+type UpdateEmailDTO struct {
+	Email           string `json:"email" binding:"required,email,min=9,max=60"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+// DeleteMeDTO deletes the account of the current user, the current password re-verifies the user
+type DeleteMeDTO struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+type ForgotPasswordDTO struct {
+	Email string `json:"email" binding:"required,email,max=60"`
+}
+
+// ResetPasswordDTO sets a new password with the token mailed by ForgotPassword
+type ResetPasswordDTO struct {
+	Token       string `json:"token" binding:"required,max=128"`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=20"`
+}
+
+type VerifyEmailDTO struct {
+	Token string `json:"token" binding:"required,max=128"`
+}
+
+// AuthTokens of a session, ExpiresIn is the lifetime of the access token in seconds
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
+}
+
 type RegisterResponse struct {
-	AccessToken string `json:"access_token,omitempty"`
-	Message     string `json:"message"`
-	Code        int    `json:"code"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message"`
+	Code         int    `json:"code"`
 }
 
 type LoginResponse struct {
-	AccessToken string `json:"access_token,omitempty"`
-	Message     string `json:"message"`
-	Code        int    `json:"code"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message"`
+	Code         int    `json:"code"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message"`
+	Code         int    `json:"code"`
+}
+
+type LogoutResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type UpdateUserRoleResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// CreateInvitationResponse returns the invitation token only once, it is stored hashed
+type CreateInvitationResponse struct {
+	Invitation *entity.Invitation `json:"invitation,omitempty"`
+	Token      string             `json:"token,omitempty"`
+	Message    string             `json:"message"`
+	Code       int                `json:"code"`
+}
+
+type GetInvitationsResponse struct {
+	Invitations []entity.Invitation `json:"invitations"`
+	Message     string              `json:"message"`
+	Code        int                 `json:"code"`
+}
+
+type DeleteInvitationResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type AcceptInvitationResponse struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message"`
+	Code         int    `json:"code"`
+}
+
+type GetMeResponse struct {
+	User    *entity.User `json:"user,omitempty"`
+	Message string       `json:"message"`
+	Code    int          `json:"code"`
+}
+
+type UpdateMeResponse struct {
+	User    *entity.User `json:"user,omitempty"`
+	Message string       `json:"message"`
+	Code    int          `json:"code"`
+}
+
+// UpdatePasswordResponse returns a new session since every refresh token of the user is revoked
+type UpdatePasswordResponse struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message"`
+	Code         int    `json:"code"`
+}
+
+type UpdateEmailResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type DeleteMeResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ForgotPasswordResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ResetPasswordResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type VerifyEmailResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+type ResendEmailVerificationResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
 }
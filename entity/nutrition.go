@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// IngredientNutrition is the nutrition of 100 g of an ingredient, ingredients are linked to it by Name or one of its Aliases
+type IngredientNutrition struct {
+	Name         string   `json:"name"`
+	Aliases      []string `json:"aliases"`
+	Calories     float64  `json:"calories"`       // kcal
+	Protein      float64  `json:"protein"`        // g
+	Fat          float64  `json:"fat"`            // g
+	Carbohydrate float64  `json:"carbohydrate"`   // g
+	Sodium       float64  `json:"sodium"`         // mg
+	GramsPerMl   float64  `json:"grams_per_ml"`   // density used for volume units eg: sdm, gelas
+	GramsPerUnit float64  `json:"grams_per_unit"` // weight of one piece for count units eg: butir, 0 when unknown
+	NutritionId  int64    `json:"nutrition_id"`
+}
+
+// RecipeNutrition is the computed nutrition of one serving of a recipe
+type RecipeNutrition struct {
+	UpdatedAt    time.Time `json:"updated_at"`
+	Calories     float64   `json:"calories"`
+	Protein      float64   `json:"protein"`
+	Fat          float64   `json:"fat"`
+	Carbohydrate float64   `json:"carbohydrate"`
+	Sodium       float64   `json:"sodium"`
+	// IsComplete is false when some measured ingredients have no nutrition data or cannot be weighed
+	IsComplete bool `json:"is_complete"`
+}
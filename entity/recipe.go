@@ -2,23 +2,121 @@ package entity
 
 import "time"
 
+// RecipeCategory is a node of the category tree, a root category has no ParentCategoryId.
+// Siblings are listed by DisplayOrder then by CategoryTag
 type RecipeCategory struct {
+	CategoryTag      string           `json:"category_tag"`
+	Slug             string           `json:"slug"`
+	Description      string           `json:"description"`
+	CoverImage       string           `json:"cover_image"`
+	Breadcrumbs      []RecipeCategory `json:"breadcrumbs,omitempty"` // path from the root down to the category itself
+	Children         []RecipeCategory `json:"children,omitempty"`
+	CategoryId       int64            `json:"category_id"`
+	ParentCategoryId int64            `json:"parent_category_id,omitempty"`
+	DisplayOrder     int              `json:"display_order"`
+	RecipeCount      int              `json:"recipe_count"` // published recipes directly in the category, not in its sub categories
+	IsFeatured       bool             `json:"is_featured"`
+}
+
+// Tag labels recipes across categories, a recipe can have many tags eg: "Lebaran", "Masakan Padang"
+type Tag struct {
+	TagName string `json:"tag_name"`
+	TagId   int64  `json:"tag_id"`
+}
+
+// Recipe will have adjusted memory padding to optimize memory, ingredient quantities are for Servings portions
+type Recipe struct {
+	Title                string             `json:"title"`
+	Slug                 string             `json:"slug"`
+	Header               string             `json:"header"`
+	ImagePreview         string             `json:"image_preview"`
+	Description          string             `json:"description"`
+	SearchHighlight      string             `json:"search_highlight,omitempty"` // header and description fragments with matches wrapped in <mark>
+	CreatedAt            time.Time          `json:"created_at"`
+	UpdatedAt            time.Time          `json:"updated_at"`
+	PublishedAt          *time.Time         `json:"published_at"` // nil while the recipe is a draft
+	RecipeIngredients    []RecipeIngredient `json:"recipe_ingredients"`
+	RecipeSteps          []RecipeStep       `json:"recipe_steps,omitempty"`
+	Tags                 []Tag              `json:"tags"`
+	Nutrition            *RecipeNutrition   `json:"nutrition,omitempty"`
+	RecipeId             int64              `json:"recipe_id"`
+	CategoryId           int64              `json:"category_id"`
+	SearchRank           float64            `json:"search_rank,omitempty"` // only set when searching with q
+	EstimatedTimeMinutes int                `json:"estimated_time_minutes"`
+	Servings             int                `json:"servings"`
+}
+
+// RecipePage is a page of a recipe listing, NextCursor continues after the last recipe and is only set when HasMore
+type RecipePage struct {
+	Recipes    []Recipe
+	NextCursor string
+	Total      int // recipes matching the filter on every page
+	HasMore    bool
+}
+
+// RecipeFacets counts the recipes of a filter per category, estimated time bucket and minimum average rating.
+// Each facet ignores its own filter so the counts of the other values stay visible
+type RecipeFacets struct {
+	Categories  []CategoryFacet   `json:"categories"`
+	TimeBuckets []TimeBucketFacet `json:"time_buckets"`
+	MinRatings  []RatingFacet     `json:"min_ratings"`
+}
+
+type CategoryFacet struct {
 	CategoryTag string `json:"category_tag"`
 	CategoryId  int64  `json:"category_id"`
+	Count       int    `json:"count"`
 }
 
-// Recipe will have adjusted memory padding to optimize memory
-type Recipe struct {
-	Title                string      `json:"title"`
-	Header               string      `json:"header"`
-	ImagePreview         string      `json:"image_preview"`
-	Description          string      `json:"description"`
-	CreatedAt            time.Time   `json:"created_at"`
-	UpdatedAt            time.Time   `json:"updated_at"`
-	RecipeIngredients    interface{} `json:"recipe_ingredients"`
-	RecipeId             int64       `json:"recipe_id"`
-	CategoryId           int64       `json:"category_id"`
-	EstimatedTimeMinutes int         `json:"estimated_time_minutes"`
+type TimeBucketFacet struct {
+	TimeBucket string `json:"time_bucket"`
+	Count      int    `json:"count"`
+}
+
+// RatingFacet counts recipes rated MinRating and up on average
+type RatingFacet struct {
+	MinRating int `json:"min_rating"`
+	Count     int `json:"count"`
+}
+
+// Suggestion is a type-ahead match of a recipe title, category tag or ingredient name.
+// Id is the recipe_id or category_id, ingredients have no id
+type Suggestion struct {
+	Type        string  `json:"type"` // recipe, category or ingredient
+	Text        string  `json:"text"`
+	Id          int64   `json:"id,omitempty"`
+	RecipeCount int     `json:"recipe_count,omitempty"` // recipes of the category or using the ingredient
+	Score       float64 `json:"score"`
+}
+
+// RecipeMatch is a recipe found by the ingredients a user has, MissingIngredients still need to be bought
+type RecipeMatch struct {
+	Recipe             Recipe   `json:"recipe"`
+	MatchedIngredients []string `json:"matched_ingredients"`
+	MissingIngredients []string `json:"missing_ingredients"`
+	MatchedCount       int      `json:"matched_count"`
+	MissingCount       int      `json:"missing_count"`
+}
+
+// RecipeIngredient is a single ingredient line of a recipe, Quantity 0 means unmeasured eg: "garam secukupnya"
+type RecipeIngredient struct {
+	Name               string  `json:"name"`
+	Unit               string  `json:"unit"`
+	Note               string  `json:"note"`
+	Group              string  `json:"group"`
+	QuantityDisplay    string  `json:"quantity_display"` // rounded quantity with unicode fractions eg: "1½", empty when unmeasured
+	Quantity           float64 `json:"quantity"`
+	RecipeIngredientId int64   `json:"recipe_ingredient_id"`
+	Position           int     `json:"position"`
+}
+
+// RecipeStep is a single cooking step ordered by StepNumber, DurationSeconds 0 means the step has no timer
+type RecipeStep struct {
+	Instruction     string `json:"instruction"`
+	StepImage       string `json:"step_image"`
+	RecipeStepId    int64  `json:"recipe_step_id"`
+	StepNumber      int    `json:"step_number"`
+	DurationSeconds int    `json:"duration_seconds"`
 }
 
 type RecipeRating struct {
@@ -29,12 +127,22 @@ type RecipeRating struct {
 	RecipeRating int       `json:"recipe_rating"`
 }
 
+// RecipeRatingSummary aggregates ratings of a recipe, UserRating is the caller's own rating (0 if not rated yet)
+type RecipeRatingSummary struct {
+	AverageRating float64 `json:"average_rating"`
+	RatingCount   int     `json:"rating_count"`
+	UserRating    int     `json:"user_rating"`
+}
+
+// DiscussionTestimonial is a top level discussion of a recipe when ParentDiscussionTestimonialId is 0, otherwise it's a reply
 type DiscussionTestimonial struct {
-	CreatedAt               time.Time `json:"created_at"`
-	UpdatedAt               time.Time `json:"updated_at"`
-	Discussion              string    `json:"discussion"`
-	DiscussionImage         string    `json:"discussion_image"`
-	DiscussionTestimonialId int64     `json:"discussion_testimonial_id"`
-	RecipeId                int64     `json:"recipe_id"`
-	UserId                  int64     `json:"user_id"`
+	CreatedAt                     time.Time `json:"created_at"`
+	UpdatedAt                     time.Time `json:"updated_at"`
+	Discussion                    string    `json:"discussion"`
+	DiscussionImage               string    `json:"discussion_image"`
+	DiscussionTestimonialId       int64     `json:"discussion_testimonial_id"`
+	RecipeId                      int64     `json:"recipe_id"`
+	UserId                        int64     `json:"user_id"`
+	ParentDiscussionTestimonialId int64     `json:"parent_discussion_testimonial_id,omitempty"`
+	ReplyCount                    int       `json:"reply_count"`
 }
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// SearchSynonym replaces Term with Replacement in fuzzy recipe searches, both are normalized eg: "fried rice" -> "nasi goreng"
+type SearchSynonym struct {
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Term            string    `json:"term"`
+	Replacement     string    `json:"replacement"`
+	SearchSynonymId int64     `json:"search_synonym_id"`
+}
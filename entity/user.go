@@ -5,10 +5,53 @@ import "time"
 type User struct {
 	Role         string    `json:"role"`
 	Email        string    `json:"email"`
-	Password     string    `json:"password"`
+	Password     string    `json:"-"`
 	Name         string    `json:"name"`
 	ProfileImage string    `json:"profile_image"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
-	UserId       int64     `json:"user_id"`
+	// EmailVerifiedAt is nil until the user opens the verification link mailed to its email
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// TokensValidAfter rejects the access tokens issued before it eg: after a password reset
+	TokensValidAfter time.Time `json:"-"`
+	UserId           int64     `json:"user_id"`
+}
+
+// RefreshToken is stored by its hash, every rotation issues a new token in the same family
+type RefreshToken struct {
+	TokenHash      string    `json:"-"`
+	FamilyId       string    `json:"-"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserId         int64     `json:"user_id"`
+	RefreshTokenId int64     `json:"refresh_token_id"`
+}
+
+// Invitation lets an email register with Role, only the hash of its token is stored
+type Invitation struct {
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	TokenHash    string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	InvitationId int64     `json:"invitation_id"`
+	InvitedBy    int64     `json:"invited_by,omitempty"`
+}
+
+// PasswordResetToken is single use and stored by its hash, a new token replaces the unused ones of the user
+type PasswordResetToken struct {
+	TokenHash            string    `json:"-"`
+	ExpiresAt            time.Time `json:"expires_at"`
+	CreatedAt            time.Time `json:"created_at"`
+	UserId               int64     `json:"user_id"`
+	PasswordResetTokenId int64     `json:"password_reset_token_id"`
+}
+
+// EmailVerificationToken verifies Email, it cannot verify the user once the user changed its email
+type EmailVerificationToken struct {
+	Email                    string    `json:"email"`
+	TokenHash                string    `json:"-"`
+	ExpiresAt                time.Time `json:"expires_at"`
+	CreatedAt                time.Time `json:"created_at"`
+	UserId                   int64     `json:"user_id"`
+	EmailVerificationTokenId int64     `json:"email_verification_token_id"`
 }
@@ -10,20 +10,62 @@ import (
 	"github.com/victorsantoso/endeus/internal"
 )
 
+const (
+	// default expirations when the jwt config does not set them
+	defaultAccessTokenExpiration  = 15 * time.Minute
+	defaultRefreshTokenExpiration = 14 * 24 * time.Hour
+)
+
 var (
 	jwtConfig              = internal.ConfigureJWT()
 	ErrInvalidJwtAlgorithm = errors.New("invalid jwt signing method")
+	ErrInvalidJwt          = errors.New("invalid jwt")
 )
 
+// Claims of an access token, the subject is the user id and the id is the jti checked against the revoked access tokens
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// UserId returns the user id of the subject
+func (c *Claims) UserId() (int64, error) {
+	return strconv.ParseInt(c.Subject, 10, 64)
+}
+
+// AccessTokenExpiration is the lifetime of an access token from jwt.jwt_expiration_time in seconds
+func AccessTokenExpiration() time.Duration {
+	if jwtConfig.JwtExpirationTime <= 0 {
+		return defaultAccessTokenExpiration
+	}
+	return time.Duration(jwtConfig.JwtExpirationTime) * time.Second
+}
+
+// RefreshTokenExpiration is the lifetime of a refresh token from jwt.refresh_token_expiration_time in seconds
+func RefreshTokenExpiration() time.Duration {
+	if jwtConfig.RefreshTokenExpirationTime <= 0 {
+		return defaultRefreshTokenExpiration
+	}
+	return time.Duration(jwtConfig.RefreshTokenExpirationTime) * time.Second
+}
+
 func GenerateJWT(role string, userId int64) (string, error) {
-	id := strconv.Itoa(int(userId))
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{ // can use custom claim if you need to inject more data
-		ID:        id,
-		Audience:  jwt.ClaimStrings{jwtConfig.Audience},
-		Issuer:    jwtConfig.Issuer,
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(3 * time.Hour)),
-		Subject:   role,
+	jti, err := GenerateToken()
+	if err != nil {
+		log.Debugf("[jwt.GenerateJWT] err generating jti: %v\n", err)
+		return "", err
+	}
+	now := time.Now()
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{jwtConfig.Audience},
+			Issuer:    jwtConfig.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenExpiration())),
+			Subject:   strconv.FormatInt(userId, 10),
+		},
 	})
 	token, err := jwtToken.SignedString([]byte(jwtConfig.Secret)) // sign token with private key
 	if err != nil {
@@ -34,15 +76,24 @@ func GenerateJWT(role string, userId int64) (string, error) {
 	return token, nil
 }
 
-func VerifyJwt(jwtString string) (*jwt.Token, error) {
-    jwtToken, err := jwt.Parse(jwtString, func(t *jwt.Token) (interface{}, error) {
-        if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok || t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
-            return nil, ErrInvalidJwtAlgorithm
-        }
-        return []byte(jwtConfig.Secret), nil
-    })
-    if err != nil {
-        return nil, err
-    }
-    return jwtToken, nil
-}
\ No newline at end of file
+// VerifyJwt returns the claims of a valid, unexpired access token signed with HS256 by this issuer
+func VerifyJwt(jwtString string) (*Claims, error) {
+	claims := &Claims{}
+	jwtToken, err := jwt.ParseWithClaims(jwtString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok || t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, ErrInvalidJwtAlgorithm
+		}
+		return []byte(jwtConfig.Secret), nil
+	},
+		jwt.WithIssuer(jwtConfig.Issuer),
+		jwt.WithAudience(jwtConfig.Audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !jwtToken.Valid || claims.ID == "" {
+		return nil, ErrInvalidJwt
+	}
+	return claims, nil
+}
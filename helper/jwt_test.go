@@ -0,0 +1,39 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateJWT(t *testing.T) {
+	token, err := GenerateJWT("ADMIN", 7)
+	assert.NoError(t, err)
+	claims, err := VerifyJwt(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "ADMIN", claims.Role)
+	userId, err := claims.UserId()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), userId)
+	assert.NotEmpty(t, claims.ID)
+	assert.WithinDuration(t, claims.IssuedAt.Add(AccessTokenExpiration()), claims.ExpiresAt.Time, 0)
+
+	other, err := GenerateJWT("ADMIN", 7)
+	assert.NoError(t, err)
+	otherClaims, err := VerifyJwt(other)
+	assert.NoError(t, err)
+	assert.NotEqual(t, claims.ID, otherClaims.ID) // every access token has its own jti
+}
+
+func TestVerifyJwt_RejectsTamperedToken(t *testing.T) {
+	token, err := GenerateJWT("READER", 7)
+	assert.NoError(t, err)
+	adminToken, err := GenerateJWT("ADMIN", 7)
+	assert.NoError(t, err)
+	// claims of the reader token with the signature of the admin token
+	parts := strings.Split(token, ".")
+	adminParts := strings.Split(adminToken, ".")
+	_, err = VerifyJwt(parts[0] + "." + parts[1] + "." + adminParts[2])
+	assert.Error(t, err)
+}
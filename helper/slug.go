@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength leaves room in the 100 characters of the slug columns for the "-N" suffix of a de-duplicated slug
+const MaxSlugLength = 80
+
+// slugReplacer transliterates the letters that don't decompose into a latin letter and a diacritic
+var slugReplacer = strings.NewReplacer(
+	"ß", "ss",
+	"æ", "ae", "Æ", "ae",
+	"œ", "oe", "Œ", "oe",
+	"ø", "o", "Ø", "o",
+	"đ", "d", "Đ", "d",
+	"ł", "l", "Ł", "l",
+	"&", " dan ",
+)
+
+// Slugify turns a title into a lowercase url slug of ascii letters and digits separated by a hyphen
+// eg: "Soto Ayam & Perkedel" -> "soto-ayam-dan-perkedel", "Crème Brûlée" -> "creme-brulee".
+// Other characters are dropped, an empty slug is returned when nothing is left
+func Slugify(text string) string {
+	decomposed, _, err := transform.String(transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn))), slugReplacer.Replace(text))
+	if err != nil {
+		decomposed = text
+	}
+	var slug strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(decomposed) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	return truncateSlug(slug.String())
+}
+
+// truncateSlug cuts a slug longer than MaxSlugLength at its last hyphen so no word is cut in half
+func truncateSlug(slug string) string {
+	if len(slug) <= MaxSlugLength {
+		return slug
+	}
+	slug = slug[:MaxSlugLength]
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		slug = slug[:i]
+	}
+	return strings.TrimSuffix(slug, "-")
+}
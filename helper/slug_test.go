@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	testCases := map[string]string{
+		"Nasi Goreng Kampung":       "nasi-goreng-kampung",
+		"  Soto Ayam & Perkedel!! ": "soto-ayam-dan-perkedel",
+		"Crème Brûlée":              "creme-brulee",
+		"Kue Lapis Legit (8 Lapis)": "kue-lapis-legit-8-lapis",
+		"Bratwurst mit Soße":        "bratwurst-mit-sosse",
+		"Rendang -- Padang":         "rendang-padang",
+		"炒饭":                        "",
+		"":                          "",
+	}
+	for title, slug := range testCases {
+		assert.Equal(t, slug, Slugify(title), "title: %q", title)
+	}
+}
+
+func TestSlugify_TruncatesAtWordBoundary(t *testing.T) {
+	slug := Slugify(strings.Repeat("panjang ", 20))
+	assert.LessOrEqual(t, len(slug), MaxSlugLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	for _, word := range strings.Split(slug, "-") {
+		assert.Equal(t, "panjang", word)
+	}
+}
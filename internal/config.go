@@ -10,6 +10,8 @@ type Config struct {
 type Application struct {
 	Port  string
 	Debug bool
+	// RequireVerifiedEmail restricts the users who did not verify their email from posting ratings and discussions
+	RequireVerifiedEmail bool
 }
 
 // Database configuration.
@@ -38,13 +40,31 @@ type JWT struct {
 	Audience          string
 	Subject           string
 	JwtExpirationTime int
+	// RefreshTokenExpirationTime in seconds, the access token expires after JwtExpirationTime seconds
+	RefreshTokenExpirationTime int
+}
+
+// Mailer configuration, Driver is smtp or outbox. The outbox driver writes every mail to a file in OutboxDir for local development.
+type Mailer struct {
+	Driver       string
+	From         string
+	SmtpHost     string
+	SmtpPort     string
+	SmtpUsername string
+	SmtpPassword string
+	OutboxDir    string
+	// PasswordResetUrl is the page of the client receiving the reset token in its token query
+	PasswordResetUrl string
+	// EmailVerificationUrl is the page of the client receiving the verification token in its token query
+	EmailVerificationUrl string
 }
 
 // Configure Application configuration with spf13/viper
 func ConfigureApplication() *Application {
 	return &Application{
-		Port:  ViperReader.GetString("application.port"),
-		Debug: ViperReader.GetBool("application.debug"),
+		Port:                 ViperReader.GetString("application.port"),
+		Debug:                ViperReader.GetBool("application.debug"),
+		RequireVerifiedEmail: ViperReader.GetBool("application.require_verified_email"),
 	}
 }
 
@@ -69,10 +89,26 @@ func ConfigureDatabase() *Database {
 // Configure JWT configuration with spf13/viper
 func ConfigureJWT() *JWT {
 	return &JWT{
-		Issuer:            ViperReader.GetString("jwt.issuer"),
-		Audience:          ViperReader.GetString("jwt.audience"),
-		Secret:            ViperReader.GetString("jwt.secret"),
-		Subject:           ViperReader.GetString("jwt.subject"),
-		JwtExpirationTime: ViperReader.GetInt("jwt.jwt_expiration_time"),
+		Issuer:                     ViperReader.GetString("jwt.issuer"),
+		Audience:                   ViperReader.GetString("jwt.audience"),
+		Secret:                     ViperReader.GetString("jwt.secret"),
+		Subject:                    ViperReader.GetString("jwt.subject"),
+		JwtExpirationTime:          ViperReader.GetInt("jwt.jwt_expiration_time"),
+		RefreshTokenExpirationTime: ViperReader.GetInt("jwt.refresh_token_expiration_time"),
+	}
+}
+
+// Configure Mailer configuration with spf13/viper
+func ConfigureMailer() *Mailer {
+	return &Mailer{
+		Driver:               ViperReader.GetString("mailer.driver"),
+		From:                 ViperReader.GetString("mailer.from"),
+		SmtpHost:             ViperReader.GetString("mailer.smtp_host"),
+		SmtpPort:             ViperReader.GetString("mailer.smtp_port"),
+		SmtpUsername:         ViperReader.GetString("mailer.smtp_username"),
+		SmtpPassword:         ViperReader.GetString("mailer.smtp_password"),
+		OutboxDir:            ViperReader.GetString("mailer.outbox_dir"),
+		PasswordResetUrl:     ViperReader.GetString("mailer.password_reset_url"),
+		EmailVerificationUrl: ViperReader.GetString("mailer.email_verification_url"),
 	}
 }
@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/internal"
+)
+
+const (
+	DriverSmtp   = "smtp"
+	DriverOutbox = "outbox"
+)
+
+// NewMailer returns the mailer of the configured driver, the outbox is used when no driver is configured
+func NewMailer(config *internal.Mailer) (domain.Mailer, error) {
+	switch config.Driver {
+	case DriverSmtp:
+		return NewSmtpMailer(config), nil
+	case DriverOutbox, "":
+		return NewOutboxMailer(config.From, config.OutboxDir), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver: %s", config.Driver)
+	}
+}
@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/victorsantoso/endeus/domain"
+)
+
+// OutboxMailer writes every mail to a .eml file of its directory instead of sending it, for local development.
+// Without a directory it keeps the mails in memory for tests
+type OutboxMailer struct {
+	from  string
+	dir   string
+	mu    sync.Mutex
+	count int
+	sent  []domain.Mail
+}
+
+func NewOutboxMailer(from, dir string) *OutboxMailer {
+	return &OutboxMailer{
+		from: from,
+		dir:  dir,
+	}
+}
+
+func (om *OutboxMailer) Send(ctx context.Context, m *domain.Mail) error {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	if om.dir == "" {
+		om.sent = append(om.sent, *m)
+		return nil
+	}
+	if err := os.MkdirAll(om.dir, 0o755); err != nil {
+		return err
+	}
+	om.count++
+	name := fmt.Sprintf("%s-%d.eml", time.Now().UTC().Format("20060102T150405.000000000"), om.count)
+	path := filepath.Join(om.dir, name)
+	if err := os.WriteFile(path, message(om.from, m), 0o600); err != nil {
+		return err
+	}
+	log.Infof("[outbox_mailer.Send] mail to: %s written to %s", m.To, path)
+	return nil
+}
+
+// Sent returns the mails sent so far without a directory, oldest first
+func (om *OutboxMailer) Sent() []domain.Mail {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	return append([]domain.Mail(nil), om.sent...)
+}
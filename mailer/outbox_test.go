@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/domain"
+)
+
+func TestOutboxMailer_Send(t *testing.T) {
+	t.Run("test send writes the mail to the outbox", func(t *testing.T) {
+		dir := t.TempDir()
+		outboxMailer := NewOutboxMailer("Endeus <no-reply@endeus.id>", dir)
+		err := outboxMailer.Send(context.Background(), &domain.Mail{
+			To:      "testtest@gmail.com",
+			Subject: "Reset your password\r\nBcc: someone@gmail.com",
+			Body:    "Open the link\nhttp://localhost:3000/reset-password?token=abc",
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, outboxMailer.Sent())
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		b, err := os.ReadFile(files[0])
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "To: testtest@gmail.com\r\n")
+		assert.Contains(t, string(b), "Subject: Reset your passwordBcc: someone@gmail.com\r\n")
+		assert.True(t, strings.HasSuffix(string(b), "Open the link\r\nhttp://localhost:3000/reset-password?token=abc"))
+	})
+
+	t.Run("test send without directory keeps the mail in memory", func(t *testing.T) {
+		outboxMailer := NewOutboxMailer("Endeus <no-reply@endeus.id>", "")
+		err := outboxMailer.Send(context.Background(), &domain.Mail{To: "testtest@gmail.com", Subject: "Hi", Body: "Hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Mail{{To: "testtest@gmail.com", Subject: "Hi", Body: "Hello"}}, outboxMailer.Sent())
+	})
+}
@@ -0,0 +1,108 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/internal"
+)
+
+// smtpTimeout bounds a whole smtp session so a stalled server cannot hang the request sending the mail
+const smtpTimeout = 10 * time.Second
+
+type smtpMailer struct {
+	from     string
+	addr     string
+	host     string
+	username string
+	password string
+}
+
+// NewSmtpMailer sends mails through the configured smtp server, it authenticates with PLAIN auth when a username is configured
+func NewSmtpMailer(config *internal.Mailer) domain.Mailer {
+	return &smtpMailer{
+		from:     config.From,
+		addr:     net.JoinHostPort(config.SmtpHost, config.SmtpPort),
+		host:     config.SmtpHost,
+		username: config.SmtpUsername,
+		password: config.SmtpPassword,
+	}
+}
+
+func (sm *smtpMailer) Send(ctx context.Context, m *domain.Mail) error {
+	from, err := mail.ParseAddress(sm.from)
+	if err != nil {
+		return fmt.Errorf("invalid mailer from: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", sm.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, sm.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	return sm.send(client, from.Address, m)
+}
+
+// send runs the session of smtp.SendMail on client: STARTTLS when the server supports it, PLAIN auth and the mail
+func (sm *smtpMailer) send(client *smtp.Client, from string, m *domain.Mail) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: sm.host}); err != nil {
+			return err
+		}
+	}
+	if sm.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", sm.username, sm.password, sm.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(m.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message(sm.from, m)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message formats the mail as a plain text message, header values are stripped of line breaks
+func message(from string, m *domain.Mail) []byte {
+	header := strings.NewReplacer("\r", "", "\n", "")
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", header.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", header.Replace(m.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", header.Replace(m.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
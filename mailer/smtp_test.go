@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/internal"
+)
+
+func TestSmtpMailer_Send(t *testing.T) {
+	t.Run("test send gives up on a stalled server", func(t *testing.T) {
+		// the server accepts connections but never greets
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+		host, port, err := net.SplitHostPort(listener.Addr().String())
+		assert.NoError(t, err)
+		smtpMailer := NewSmtpMailer(&internal.Mailer{From: "Endeus <no-reply@endeus.id>", SmtpHost: host, SmtpPort: port})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err = smtpMailer.Send(ctx, &domain.Mail{To: "testtest@gmail.com", Subject: "Hi", Body: "Hello"})
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
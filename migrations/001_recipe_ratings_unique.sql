@@ -0,0 +1,17 @@
+-- One rating per user per recipe, ratings are deleted along with their recipe.
+-- database.sql already contains these constraints for fresh databases,
+-- this migration is for databases created before ratings became an upsert.
+
+-- keep only the latest rating of every (recipe_id, user_id) pair
+DELETE FROM recipe_ratings older
+USING recipe_ratings newer
+WHERE older.recipe_id = newer.recipe_id
+    AND older.user_id = newer.user_id
+    AND (older.updated_at, older.ctid) < (newer.updated_at, newer.ctid);
+
+ALTER TABLE public.recipe_ratings
+    ADD CONSTRAINT uq_recipe_ratings_recipe_id_user_id UNIQUE(recipe_id, user_id),
+    ADD CONSTRAINT ck_recipe_ratings_recipe_rating CHECK(recipe_rating BETWEEN 1 AND 5);
+
+ALTER TABLE public.recipe_ratings DROP CONSTRAINT fk_recipe_ratings_recipe_id;
+ALTER TABLE public.recipe_ratings ADD CONSTRAINT fk_recipe_ratings_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE;
@@ -0,0 +1,16 @@
+-- Discussion Testimonials Table, a discussion with parent_discussion_testimonial_id is a reply
+CREATE TABLE public.discussion_testimonials (
+    discussion_testimonial_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    parent_discussion_testimonial_id INTEGER DEFAULT NULL,
+    discussion TEXT NOT NULL,
+    discussion_image TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_discussion_testimonials_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_discussion_testimonials_user_id FOREIGN KEY(user_id) REFERENCES users(user_id),
+    CONSTRAINT fk_discussion_testimonials_parent_id FOREIGN KEY(parent_discussion_testimonial_id) REFERENCES discussion_testimonials(discussion_testimonial_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_discussion_testimonials_recipe_id ON public.discussion_testimonials(recipe_id, created_at);
+CREATE INDEX idx_discussion_testimonials_parent_id ON public.discussion_testimonials(parent_discussion_testimonial_id, created_at);
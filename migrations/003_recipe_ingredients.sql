@@ -0,0 +1,57 @@
+-- Move recipes.recipe_ingredients JSON blobs into the normalized recipe_ingredients table.
+-- Supported blob shapes:
+--   {"pasta": "200g", "garam": "secukupnya"}
+--   [{"ingredient": "pasta", "amount": "2 cups"}] (or "name" / "quantity" keys)
+--   ["2 butir telur"]
+-- A leading number (1, 1.5, 1,5 or 1/2) of the amount becomes the quantity and the rest becomes the unit,
+-- amounts without a leading number (eg: "secukupnya") are kept as note.
+BEGIN;
+
+CREATE TABLE public.recipe_ingredients (
+    recipe_ingredient_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    name VARCHAR(60) NOT NULL,
+    quantity NUMERIC(10, 3) DEFAULT NULL,
+    unit VARCHAR(20) DEFAULT NULL,
+    note VARCHAR(255) DEFAULT NULL,
+    ingredient_group VARCHAR(60) DEFAULT NULL,
+    CONSTRAINT fk_recipe_ingredients_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT ck_recipe_ingredients_quantity CHECK(quantity IS NULL OR quantity >= 0)
+);
+CREATE INDEX idx_recipe_ingredients_recipe_id ON public.recipe_ingredients(recipe_id, position);
+
+WITH raw AS (
+    SELECT r.recipe_id, e.ordinality AS position, e.key AS name, e.value AS amount
+    FROM recipes r, json_each_text(r.recipe_ingredients) WITH ORDINALITY AS e(key, value, ordinality)
+    WHERE json_typeof(r.recipe_ingredients) = 'object'
+    UNION ALL
+    SELECT r.recipe_id, e.ordinality AS position,
+        CASE json_typeof(e.value) WHEN 'object' THEN COALESCE(e.value->>'ingredient', e.value->>'name') ELSE e.value#>>'{}' END AS name,
+        CASE json_typeof(e.value) WHEN 'object' THEN COALESCE(e.value->>'amount', e.value->>'quantity') END AS amount
+    FROM recipes r, json_array_elements(r.recipe_ingredients) WITH ORDINALITY AS e(value, ordinality)
+    WHERE json_typeof(r.recipe_ingredients) = 'array'
+), parsed AS (
+    SELECT recipe_id, position, trim(name) AS name, trim(amount) AS amount,
+        substring(amount FROM '^\s*([0-9]+/[0-9]+)') AS fraction,
+        substring(amount FROM '^\s*([0-9]+(?:[.,][0-9]+)?)') AS number,
+        trim(regexp_replace(amount, '^\s*[0-9]+(?:[.,/][0-9]+)?', '')) AS rest
+    FROM raw
+    WHERE COALESCE(trim(name), '') <> ''
+)
+INSERT INTO recipe_ingredients(recipe_id, position, name, quantity, unit, note)
+SELECT recipe_id, position, left(name, 60),
+    CASE
+        WHEN fraction IS NOT NULL THEN split_part(fraction, '/', 1)::numeric / NULLIF(split_part(fraction, '/', 2)::numeric, 0)
+        WHEN number IS NOT NULL THEN replace(number, ',', '.')::numeric
+    END,
+    CASE WHEN number IS NOT NULL AND length(rest) BETWEEN 1 AND 20 THEN rest END,
+    CASE
+        WHEN number IS NULL THEN NULLIF(left(amount, 255), '')
+        WHEN length(rest) > 20 THEN left(rest, 255)
+    END
+FROM parsed;
+
+ALTER TABLE public.recipes DROP COLUMN recipe_ingredients;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Ordered cooking steps of a recipe, existing recipes start without steps.
+BEGIN;
+
+CREATE TABLE public.recipe_steps (
+    recipe_step_id SERIAL PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    step_number INTEGER NOT NULL,
+    instruction TEXT NOT NULL,
+    duration_seconds INTEGER DEFAULT NULL,
+    step_image TEXT DEFAULT NULL,
+    CONSTRAINT fk_recipe_steps_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT uq_recipe_steps_recipe_id_step_number UNIQUE(recipe_id, step_number) DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT ck_recipe_steps_step_number CHECK(step_number > 0),
+    CONSTRAINT ck_recipe_steps_duration_seconds CHECK(duration_seconds IS NULL OR duration_seconds > 0)
+);
+
+COMMIT;
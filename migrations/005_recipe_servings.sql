@@ -0,0 +1,7 @@
+-- Base yield of a recipe, ingredient quantities are for this many servings. Existing recipes are assumed to serve 1.
+BEGIN;
+
+ALTER TABLE public.recipes ADD COLUMN servings INTEGER DEFAULT 1 NOT NULL;
+ALTER TABLE public.recipes ADD CONSTRAINT ck_recipes_servings CHECK(servings BETWEEN 1 AND 100);
+
+COMMIT;
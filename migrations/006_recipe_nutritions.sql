@@ -0,0 +1,38 @@
+-- Ingredient nutrition dataset, ingredient links and cached recipe nutrition.
+-- Load the dataset afterwards with: endeus import-nutrition -f ./data/ingredient_nutritions.csv
+BEGIN;
+
+CREATE TABLE public.ingredient_nutritions (
+    nutrition_id SERIAL PRIMARY KEY NOT NULL,
+    name VARCHAR(60) UNIQUE NOT NULL,
+    aliases TEXT[] DEFAULT '{}' NOT NULL,
+    calories NUMERIC(10, 2) NOT NULL,
+    protein NUMERIC(10, 2) NOT NULL,
+    fat NUMERIC(10, 2) NOT NULL,
+    carbohydrate NUMERIC(10, 2) NOT NULL,
+    sodium NUMERIC(10, 2) NOT NULL,
+    grams_per_ml NUMERIC(6, 3) DEFAULT 1 NOT NULL,
+    grams_per_unit NUMERIC(10, 2) DEFAULT NULL,
+    CONSTRAINT ck_ingredient_nutritions_values CHECK(calories >= 0 AND protein >= 0 AND fat >= 0 AND carbohydrate >= 0 AND sodium >= 0),
+    CONSTRAINT ck_ingredient_nutritions_grams_per_ml CHECK(grams_per_ml > 0)
+);
+CREATE INDEX idx_ingredient_nutritions_aliases ON public.ingredient_nutritions USING GIN(aliases);
+
+ALTER TABLE public.recipe_ingredients ADD COLUMN nutrition_id INTEGER DEFAULT NULL;
+ALTER TABLE public.recipe_ingredients ADD CONSTRAINT fk_recipe_ingredients_nutrition_id FOREIGN KEY(nutrition_id) REFERENCES ingredient_nutritions(nutrition_id) ON DELETE SET NULL;
+
+-- Recipe Nutritions Table, cached nutrition of one serving computed whenever the recipe is created or updated
+CREATE TABLE public.recipe_nutritions (
+    recipe_id INTEGER PRIMARY KEY NOT NULL,
+    calories NUMERIC(10, 2) NOT NULL,
+    protein NUMERIC(10, 2) NOT NULL,
+    fat NUMERIC(10, 2) NOT NULL,
+    carbohydrate NUMERIC(10, 2) NOT NULL,
+    sodium NUMERIC(10, 2) NOT NULL,
+    is_complete BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_nutritions_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_nutritions_calories ON public.recipe_nutritions(calories);
+
+COMMIT;
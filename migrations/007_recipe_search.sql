@@ -0,0 +1,41 @@
+-- Full-text search over recipes, replaces the exact title match of GET /api/v1/recipes.
+BEGIN;
+
+ALTER TABLE public.recipes ADD COLUMN search_vector TSVECTOR DEFAULT NULL;
+
+-- Recipe Search, search_vector weights title (A), ingredient names (B), header (C) and description (D).
+-- The 'simple' configuration is used since Postgres has no Indonesian dictionary, it lowercases without stemming.
+CREATE FUNCTION recipe_search_vector(p_recipe_id INTEGER, p_title TEXT, p_header TEXT, p_description TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE((SELECT string_agg(name, ' ') FROM recipe_ingredients WHERE recipe_id = p_recipe_id), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE(p_header, '')), 'C')
+        || setweight(to_tsvector('simple', COALESCE(p_description, '')), 'D');
+$$ LANGUAGE SQL STABLE;
+
+CREATE FUNCTION recipes_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := recipe_search_vector(NEW.recipe_id, NEW.title, NEW.header, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER tg_recipes_search_vector BEFORE INSERT OR UPDATE OF title, header, description ON public.recipes
+    FOR EACH ROW EXECUTE FUNCTION recipes_search_vector_trigger();
+
+CREATE FUNCTION recipe_ingredients_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE recipes
+    SET search_vector = recipe_search_vector(recipe_id, title, header, description)
+    WHERE recipe_id = CASE TG_OP WHEN 'DELETE' THEN OLD.recipe_id ELSE NEW.recipe_id END;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER tg_recipe_ingredients_search_vector AFTER INSERT OR UPDATE OR DELETE ON public.recipe_ingredients
+    FOR EACH ROW EXECUTE FUNCTION recipe_ingredients_search_vector_trigger();
+
+CREATE INDEX idx_recipes_search_vector ON public.recipes USING GIN(search_vector);
+CREATE INDEX idx_recipes_category_id ON public.recipes(category_id);
+
+-- backfill existing recipes
+UPDATE public.recipes SET search_vector = recipe_search_vector(recipe_id, title, header, description);
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Indexes for the keyset pagination of the newest/oldest, title and quickest recipe sorts.
+BEGIN;
+
+CREATE INDEX idx_recipes_created_at ON public.recipes(created_at, recipe_id);
+CREATE INDEX idx_recipes_lower_title ON public.recipes(lower(title), recipe_id);
+CREATE INDEX idx_recipes_estimated_time_minutes ON public.recipes(estimated_time_minutes, recipe_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Trigram indexes for the suggest endpoint, matching recipe titles, category tags and ingredient names by prefix and similarity.
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_recipes_title_trgm ON public.recipes USING GIN(lower(title) gin_trgm_ops);
+CREATE INDEX idx_recipe_categories_category_tag_trgm ON public.recipe_categories USING GIN(lower(category_tag) gin_trgm_ops);
+CREATE INDEX idx_recipe_ingredients_name_trgm ON public.recipe_ingredients USING GIN(lower(name) gin_trgm_ops);
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Synonyms of the fuzzy recipe search, load the defaults with the import-synonyms command.
+BEGIN;
+
+CREATE TABLE public.search_synonyms (
+    search_synonym_id SERIAL PRIMARY KEY NOT NULL,
+    term VARCHAR(60) UNIQUE NOT NULL,
+    replacement VARCHAR(120) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Tags, a recipe has one category but any number of tags eg: "Rendang" is tagged "Daging", "Lebaran" and "Masakan Padang".
+BEGIN;
+
+CREATE TABLE public.tags (
+    tag_id SERIAL PRIMARY KEY NOT NULL,
+    tag_name VARCHAR(60) NOT NULL
+);
+CREATE UNIQUE INDEX idx_tags_lower_tag_name ON public.tags(lower(tag_name));
+
+CREATE TABLE public.recipe_tags (
+    recipe_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY(recipe_id, tag_id),
+    CONSTRAINT fk_recipe_tags_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_tags_tag_id FOREIGN KEY(tag_id) REFERENCES tags(tag_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_tags_tag_id ON public.recipe_tags(tag_id, recipe_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Category hierarchy, a category can have a parent eg: Masakan Nusantara -> Jawa -> Jawa Timur.
+BEGIN;
+
+ALTER TABLE public.recipe_categories
+    ADD COLUMN parent_category_id INTEGER DEFAULT NULL,
+    ADD CONSTRAINT fk_recipe_categories_parent_category_id FOREIGN KEY(parent_category_id) REFERENCES recipe_categories(category_id),
+    ADD CONSTRAINT ck_recipe_categories_parent_category_id CHECK(parent_category_id <> category_id);
+CREATE INDEX idx_recipe_categories_parent_category_id ON public.recipe_categories(parent_category_id);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Unique category tags regardless of their case, existing duplicates are merged into the oldest category of their group.
+BEGIN;
+
+CREATE TEMPORARY TABLE recipe_category_merges ON COMMIT DROP AS
+SELECT category_id, min(category_id) OVER (PARTITION BY lower(trim(category_tag))) AS keep_category_id
+FROM recipe_categories;
+DELETE FROM recipe_category_merges WHERE category_id = keep_category_id;
+
+UPDATE recipes r
+SET category_id = m.keep_category_id
+FROM recipe_category_merges m
+WHERE r.category_id = m.category_id;
+
+-- a kept category whose parent was one of its own duplicates becomes a root category
+UPDATE recipe_categories c
+SET parent_category_id = NULLIF(m.keep_category_id, c.category_id)
+FROM recipe_category_merges m
+WHERE c.parent_category_id = m.category_id;
+
+DELETE FROM recipe_categories c
+USING recipe_category_merges m
+WHERE c.category_id = m.category_id;
+
+UPDATE recipe_categories SET category_tag = trim(category_tag) WHERE category_tag <> trim(category_tag);
+CREATE UNIQUE INDEX idx_recipe_categories_lower_category_tag ON public.recipe_categories(lower(category_tag));
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Category metadata for the homepage, the display order starts out alphabetical among the siblings.
+BEGIN;
+
+ALTER TABLE public.recipe_categories
+    ADD COLUMN description TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN cover_image TEXT DEFAULT '' NOT NULL,
+    ADD COLUMN display_order INTEGER DEFAULT 0 NOT NULL,
+    ADD COLUMN is_featured BOOLEAN DEFAULT FALSE NOT NULL;
+
+UPDATE recipe_categories c
+SET display_order = o.display_order
+FROM (
+    SELECT category_id, row_number() OVER (PARTITION BY parent_category_id ORDER BY lower(category_tag)) AS display_order
+    FROM recipe_categories
+) o
+WHERE c.category_id = o.category_id;
+
+COMMIT;
@@ -0,0 +1,94 @@
+-- Url slugs for recipes and categories, former slugs are kept to redirect to the current one after a rename.
+-- Existing rows get a slug close to helper.Slugify, a duplicated slug gets the first "-N" suffix that no other row uses.
+-- migrations/testdata/015_slugs_test.sql checks the backfill on colliding titles.
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+ALTER TABLE public.recipes
+    ADD COLUMN slug VARCHAR(100) DEFAULT NULL,
+    ADD CONSTRAINT recipes_slug_key UNIQUE(slug);
+CREATE TEMPORARY TABLE recipe_slug_bases ON COMMIT DROP AS
+    SELECT recipe_id, COALESCE(NULLIF(trim(both '-' from left(trim(both '-' from regexp_replace(lower(unaccent(replace(title, '&', ' dan '))), '[^a-z0-9]+', '-', 'g')), 80)), ''), 'resep') AS base
+    FROM recipes;
+-- the oldest recipe of every base keeps the base
+UPDATE recipes r
+SET slug = b.base
+FROM (SELECT DISTINCT ON (base) recipe_id, base FROM recipe_slug_bases ORDER BY base, recipe_id) b
+WHERE r.recipe_id = b.recipe_id;
+-- the others get the first free "-N" suffix, skipping the bases of other recipes eg: "Soto", "Soto" and "Soto 2" get soto, soto-3 and soto-2
+DO $$
+DECLARE
+    duplicate RECORD;
+    candidate VARCHAR(100);
+    n INTEGER;
+BEGIN
+    FOR duplicate IN
+        SELECT b.recipe_id, b.base
+        FROM recipe_slug_bases b
+        JOIN recipes r ON r.recipe_id = b.recipe_id
+        WHERE r.slug IS NULL
+        ORDER BY b.recipe_id
+    LOOP
+        n := 2;
+        candidate := duplicate.base || '-' || n;
+        WHILE EXISTS (SELECT 1 FROM recipes WHERE slug = candidate) OR EXISTS (SELECT 1 FROM recipe_slug_bases WHERE base = candidate) LOOP
+            n := n + 1;
+            candidate := duplicate.base || '-' || n;
+        END LOOP;
+        UPDATE recipes SET slug = candidate WHERE recipe_id = duplicate.recipe_id;
+    END LOOP;
+END $$;
+ALTER TABLE public.recipes ALTER COLUMN slug SET NOT NULL;
+
+ALTER TABLE public.recipe_categories
+    ADD COLUMN slug VARCHAR(100) DEFAULT NULL,
+    ADD CONSTRAINT recipe_categories_slug_key UNIQUE(slug);
+CREATE TEMPORARY TABLE recipe_category_slug_bases ON COMMIT DROP AS
+    SELECT category_id, COALESCE(NULLIF(trim(both '-' from left(trim(both '-' from regexp_replace(lower(unaccent(replace(category_tag, '&', ' dan '))), '[^a-z0-9]+', '-', 'g')), 80)), ''), 'kategori') AS base
+    FROM recipe_categories;
+UPDATE recipe_categories c
+SET slug = b.base
+FROM (SELECT DISTINCT ON (base) category_id, base FROM recipe_category_slug_bases ORDER BY base, category_id) b
+WHERE c.category_id = b.category_id;
+DO $$
+DECLARE
+    duplicate RECORD;
+    candidate VARCHAR(100);
+    n INTEGER;
+BEGIN
+    FOR duplicate IN
+        SELECT b.category_id, b.base
+        FROM recipe_category_slug_bases b
+        JOIN recipe_categories c ON c.category_id = b.category_id
+        WHERE c.slug IS NULL
+        ORDER BY b.category_id
+    LOOP
+        n := 2;
+        candidate := duplicate.base || '-' || n;
+        WHILE EXISTS (SELECT 1 FROM recipe_categories WHERE slug = candidate) OR EXISTS (SELECT 1 FROM recipe_category_slug_bases WHERE base = candidate) LOOP
+            n := n + 1;
+            candidate := duplicate.base || '-' || n;
+        END LOOP;
+        UPDATE recipe_categories SET slug = candidate WHERE category_id = duplicate.category_id;
+    END LOOP;
+END $$;
+ALTER TABLE public.recipe_categories ALTER COLUMN slug SET NOT NULL;
+
+CREATE TABLE public.recipe_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY NOT NULL,
+    recipe_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_slug_redirects_recipe_id FOREIGN KEY(recipe_id) REFERENCES recipes(recipe_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_slug_redirects_recipe_id ON public.recipe_slug_redirects(recipe_id);
+
+CREATE TABLE public.recipe_category_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY NOT NULL,
+    category_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_category_slug_redirects_category_id FOREIGN KEY(category_id) REFERENCES recipe_categories(category_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_recipe_category_slug_redirects_category_id ON public.recipe_category_slug_redirects(category_id);
+
+COMMIT;
@@ -0,0 +1,23 @@
+-- Rotating refresh tokens stored by their sha256 and a denylist of the jti of logged out access tokens.
+-- Access tokens issued before this migration carry the role in the subject and are rejected, users have to login again.
+BEGIN;
+
+CREATE TABLE public.refresh_tokens (
+    refresh_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_refresh_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON public.refresh_tokens(family_id);
+
+CREATE TABLE public.revoked_access_tokens (
+    jti VARCHAR(64) PRIMARY KEY NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_revoked_access_tokens_expires_at ON public.revoked_access_tokens(expires_at);
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Invitations, self registration only creates READER accounts so admins are onboarded by an invitation of an admin
+-- or the create-admin command.
+BEGIN;
+
+CREATE TABLE public.invitations (
+    invitation_id SERIAL PRIMARY KEY NOT NULL,
+    email VARCHAR(60) NOT NULL,
+    role role NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    invited_by INTEGER DEFAULT NULL,
+    accepted_user_id INTEGER DEFAULT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_invitations_invited_by FOREIGN KEY(invited_by) REFERENCES users(user_id) ON DELETE SET NULL,
+    CONSTRAINT fk_invitations_accepted_user_id FOREIGN KEY(accepted_user_id) REFERENCES users(user_id) ON DELETE SET NULL
+);
+CREATE INDEX idx_invitations_email ON public.invitations(email);
+
+COMMIT;
@@ -0,0 +1,53 @@
+-- Permission based access control, routes require permissions granted to a role instead of checking for ADMIN.
+-- EDITOR manages the catalog and moderates discussions, CONTRIBUTOR writes recipes.
+-- The new enum values are added outside of the transaction since they cannot be used in the transaction adding them.
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'EDITOR';
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'CONTRIBUTOR';
+
+BEGIN;
+-- Permissions Table, a route requires permissions and a role is granted them by role_permissions eg: "recipe:create"
+CREATE TABLE public.permissions (
+    permission VARCHAR(60) PRIMARY KEY NOT NULL,
+    description VARCHAR(255) NOT NULL
+);
+
+-- Role Permissions Table, a role without rows is granted nothing eg: READER
+CREATE TABLE public.role_permissions (
+    role role NOT NULL,
+    permission VARCHAR(60) NOT NULL,
+    PRIMARY KEY(role, permission),
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY(permission) REFERENCES permissions(permission) ON DELETE CASCADE
+);
+
+INSERT INTO public.permissions (permission, description) VALUES
+    ('recipe:create', 'Create recipes'),
+    ('recipe:update', 'Update recipes and their steps'),
+    ('recipe:delete', 'Delete recipes'),
+    ('recipe:publish', 'Publish and unpublish recipes'),
+    ('category:manage', 'Create, update, delete and reorder recipe categories'),
+    ('tag:manage', 'Create, update and delete tags'),
+    ('search:manage', 'Manage search synonyms'),
+    ('discussion:moderate', 'Remove discussions of any author'),
+    ('user:manage', 'Change roles of users and invite users');
+
+INSERT INTO public.role_permissions (role, permission) VALUES
+    ('ADMIN', 'recipe:create'),
+    ('ADMIN', 'recipe:update'),
+    ('ADMIN', 'recipe:delete'),
+    ('ADMIN', 'recipe:publish'),
+    ('ADMIN', 'category:manage'),
+    ('ADMIN', 'tag:manage'),
+    ('ADMIN', 'search:manage'),
+    ('ADMIN', 'discussion:moderate'),
+    ('ADMIN', 'user:manage'),
+    ('EDITOR', 'recipe:create'),
+    ('EDITOR', 'recipe:update'),
+    ('EDITOR', 'recipe:delete'),
+    ('EDITOR', 'recipe:publish'),
+    ('EDITOR', 'category:manage'),
+    ('EDITOR', 'tag:manage'),
+    ('EDITOR', 'discussion:moderate'),
+    ('CONTRIBUTOR', 'recipe:create'),
+    ('CONTRIBUTOR', 'recipe:update');
+
+COMMIT;
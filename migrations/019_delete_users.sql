@@ -0,0 +1,10 @@
+-- Users can delete their account, their ratings and discussions are deleted along with them.
+BEGIN;
+
+ALTER TABLE public.recipe_ratings DROP CONSTRAINT fk_recipe_ratings_user_id;
+ALTER TABLE public.recipe_ratings ADD CONSTRAINT fk_recipe_ratings_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+ALTER TABLE public.discussion_testimonials DROP CONSTRAINT fk_discussion_testimonials_user_id;
+ALTER TABLE public.discussion_testimonials ADD CONSTRAINT fk_discussion_testimonials_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Password reset by mailed single use tokens. tokens_valid_after rejects the access tokens issued before a password
+-- change or reset, a user without it accepts the tokens issued since its creation.
+BEGIN;
+
+ALTER TABLE public.users ADD COLUMN tokens_valid_after TIMESTAMPTZ DEFAULT NULL;
+
+-- Password Reset Tokens Table, only the sha256 of a token is stored. A token is single use and expires after an hour,
+-- a new token replaces the unused ones of the user.
+CREATE TABLE public.password_reset_tokens (
+    password_reset_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_password_reset_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_password_reset_tokens_user_id ON public.password_reset_tokens(user_id);
+
+COMMIT;
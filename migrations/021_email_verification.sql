@@ -0,0 +1,22 @@
+-- Email verification, registered users verify their email with a mailed single use token.
+-- The users registered before this migration are considered verified so they are not restricted.
+BEGIN;
+
+ALTER TABLE public.users ADD COLUMN email_verified_at TIMESTAMPTZ DEFAULT NULL;
+UPDATE public.users SET email_verified_at = created_at;
+
+-- Email Verification Tokens Table, only the sha256 of a token is stored. A token verifies the email it was sent to
+-- while the user still has that email, a new token replaces the unused ones of the user.
+CREATE TABLE public.email_verification_tokens (
+    email_verification_token_id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL,
+    email VARCHAR(60) NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_email_verification_tokens_user_id FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_email_verification_tokens_user_id ON public.email_verification_tokens(user_id, created_at);
+
+COMMIT;
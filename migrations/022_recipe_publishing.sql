@@ -0,0 +1,10 @@
+-- Recipes are drafts until a user with the recipe:publish permission publishes them, only published recipes are listed,
+-- searched, suggested and counted in their category. Existing recipes stay visible, they are published when they were created.
+BEGIN;
+
+ALTER TABLE public.recipes ADD COLUMN published_at TIMESTAMPTZ DEFAULT NULL;
+UPDATE recipes SET published_at = created_at;
+
+UPDATE permissions SET description = 'Publish and unpublish recipes' WHERE permission = 'recipe:publish';
+
+COMMIT;
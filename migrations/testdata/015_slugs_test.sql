@@ -0,0 +1,44 @@
+-- Checks the slug backfill of migrations/015_slugs.sql on colliding titles, a base slug always wins over a "-N" suffix.
+-- Run it on an empty scratch database from the repository root, it fails with an exception when a slug is unexpected:
+-- createdb endeus_migration_test && psql -v ON_ERROR_STOP=1 -d endeus_migration_test -f migrations/testdata/015_slugs_test.sql; dropdb endeus_migration_test
+
+-- only the columns the migration reads
+CREATE TABLE public.recipe_categories (
+    category_id SERIAL PRIMARY KEY NOT NULL,
+    category_tag VARCHAR(60) NOT NULL
+);
+CREATE TABLE public.recipes (
+    recipe_id SERIAL PRIMARY KEY NOT NULL,
+    title VARCHAR(60) NOT NULL
+);
+
+INSERT INTO public.recipe_categories (category_tag) VALUES
+    ('Soto'),
+    ('Soto'),
+    ('Soto 2'),
+    ('Kue & Roti'),
+    ('Kue dan Roti');
+INSERT INTO public.recipes (title) VALUES
+    ('Soto'),
+    ('Soto'),
+    ('Soto 2'),
+    ('Soto'),
+    ('Soto 2'),
+    ('Nasi Goreng');
+
+\i migrations/015_slugs.sql
+
+DO $$
+DECLARE
+    recipe_slugs VARCHAR[];
+    category_slugs VARCHAR[];
+BEGIN
+    SELECT array_agg(slug ORDER BY recipe_id) INTO recipe_slugs FROM recipes;
+    IF recipe_slugs IS DISTINCT FROM ARRAY['soto', 'soto-3', 'soto-2', 'soto-4', 'soto-2-2', 'nasi-goreng']::VARCHAR[] THEN
+        RAISE EXCEPTION 'unexpected recipe slugs: %', recipe_slugs;
+    END IF;
+    SELECT array_agg(slug ORDER BY category_id) INTO category_slugs FROM recipe_categories;
+    IF category_slugs IS DISTINCT FROM ARRAY['soto', 'soto-3', 'soto-2', 'kue-dan-roti', 'kue-dan-roti-2']::VARCHAR[] THEN
+        RAISE EXCEPTION 'unexpected category slugs: %', category_slugs;
+    END IF;
+END $$;
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/victorsantoso/endeus/domain"
+	entity "github.com/victorsantoso/endeus/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DiscussionRepository is an autogenerated mock type for the DiscussionRepository type
+type DiscussionRepository struct {
+	mock.Mock
+}
+
+// CreateDiscussionTestimonial provides a mock function with given fields: ctx, discussionTestimonial
+func (_m *DiscussionRepository) CreateDiscussionTestimonial(ctx context.Context, discussionTestimonial *entity.DiscussionTestimonial) error {
+	ret := _m.Called(ctx, discussionTestimonial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDiscussionTestimonial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.DiscussionTestimonial) error); ok {
+		r0 = rf(ctx, discussionTestimonial)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDiscussionTestimonialById provides a mock function with given fields: ctx, discussionTestimonialId
+func (_m *DiscussionRepository) DeleteDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) error {
+	ret := _m.Called(ctx, discussionTestimonialId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDiscussionTestimonialById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, discussionTestimonialId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDiscussionTestimonialById provides a mock function with given fields: ctx, discussionTestimonialId
+func (_m *DiscussionRepository) GetDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64) (*entity.DiscussionTestimonial, error) {
+	ret := _m.Called(ctx, discussionTestimonialId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiscussionTestimonialById")
+	}
+
+	var r0 *entity.DiscussionTestimonial
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.DiscussionTestimonial, error)); ok {
+		return rf(ctx, discussionTestimonialId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.DiscussionTestimonial); ok {
+		r0 = rf(ctx, discussionTestimonialId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DiscussionTestimonial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, discussionTestimonialId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDiscussionTestimonials provides a mock function with given fields: ctx, getDiscussionTestimonialsQueryFilter
+func (_m *DiscussionRepository) GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error) {
+	ret := _m.Called(ctx, getDiscussionTestimonialsQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiscussionTestimonials")
+	}
+
+	var r0 []entity.DiscussionTestimonial
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error)); ok {
+		return rf(ctx, getDiscussionTestimonialsQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) []entity.DiscussionTestimonial); ok {
+		r0 = rf(ctx, getDiscussionTestimonialsQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DiscussionTestimonial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) error); ok {
+		r1 = rf(ctx, getDiscussionTestimonialsQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDiscussionTestimonialById provides a mock function with given fields: ctx, discussionTestimonialId, updateDiscussionTestimonialByIdQueryFilter
+func (_m *DiscussionRepository) UpdateDiscussionTestimonialById(ctx context.Context, discussionTestimonialId int64, updateDiscussionTestimonialByIdQueryFilter *domain.UpdateDiscussionTestimonialByIdQueryFilter) error {
+	ret := _m.Called(ctx, discussionTestimonialId, updateDiscussionTestimonialByIdQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDiscussionTestimonialById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdateDiscussionTestimonialByIdQueryFilter) error); ok {
+		r0 = rf(ctx, discussionTestimonialId, updateDiscussionTestimonialByIdQueryFilter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDiscussionRepository creates a new instance of DiscussionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDiscussionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DiscussionRepository {
+	mock := &DiscussionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,133 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/victorsantoso/endeus/domain"
+	entity "github.com/victorsantoso/endeus/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DiscussionUsecase is an autogenerated mock type for the DiscussionUsecase type
+type DiscussionUsecase struct {
+	mock.Mock
+}
+
+// CreateDiscussionTestimonial provides a mock function with given fields: ctx, recipeId, userId, createDiscussionTestimonialDTO
+func (_m *DiscussionUsecase) CreateDiscussionTestimonial(ctx context.Context, recipeId int64, userId int64, createDiscussionTestimonialDTO *domain.CreateDiscussionTestimonialDTO) error {
+	ret := _m.Called(ctx, recipeId, userId, createDiscussionTestimonialDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDiscussionTestimonial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.CreateDiscussionTestimonialDTO) error); ok {
+		r0 = rf(ctx, recipeId, userId, createDiscussionTestimonialDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDiscussionTestimonial provides a mock function with given fields: ctx, discussionTestimonialId, userId
+func (_m *DiscussionUsecase) DeleteDiscussionTestimonial(ctx context.Context, discussionTestimonialId int64, userId int64) error {
+	ret := _m.Called(ctx, discussionTestimonialId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDiscussionTestimonial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, discussionTestimonialId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDiscussionTestimonials provides a mock function with given fields: ctx, getDiscussionTestimonialsQueryFilter
+func (_m *DiscussionUsecase) GetDiscussionTestimonials(ctx context.Context, getDiscussionTestimonialsQueryFilter *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error) {
+	ret := _m.Called(ctx, getDiscussionTestimonialsQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiscussionTestimonials")
+	}
+
+	var r0 []entity.DiscussionTestimonial
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) ([]entity.DiscussionTestimonial, error)); ok {
+		return rf(ctx, getDiscussionTestimonialsQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) []entity.DiscussionTestimonial); ok {
+		r0 = rf(ctx, getDiscussionTestimonialsQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DiscussionTestimonial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetDiscussionTestimonialsQueryFilter) error); ok {
+		r1 = rf(ctx, getDiscussionTestimonialsQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModerateDiscussionTestimonial provides a mock function with given fields: ctx, discussionTestimonialId
+func (_m *DiscussionUsecase) ModerateDiscussionTestimonial(ctx context.Context, discussionTestimonialId int64) error {
+	ret := _m.Called(ctx, discussionTestimonialId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModerateDiscussionTestimonial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, discussionTestimonialId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDiscussionTestimonial provides a mock function with given fields: ctx, discussionTestimonialId, userId, updateDiscussionTestimonialDTO
+func (_m *DiscussionUsecase) UpdateDiscussionTestimonial(ctx context.Context, discussionTestimonialId int64, userId int64, updateDiscussionTestimonialDTO *domain.UpdateDiscussionTestimonialDTO) error {
+	ret := _m.Called(ctx, discussionTestimonialId, userId, updateDiscussionTestimonialDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDiscussionTestimonial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.UpdateDiscussionTestimonialDTO) error); ok {
+		r0 = rf(ctx, discussionTestimonialId, userId, updateDiscussionTestimonialDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDiscussionUsecase creates a new instance of DiscussionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDiscussionUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DiscussionUsecase {
+	mock := &DiscussionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
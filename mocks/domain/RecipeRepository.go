@@ -34,17 +34,17 @@ func (_m *RecipeRepository) CreateRecipe(ctx context.Context, recipe *entity.Rec
 	return r0
 }
 
-// CreateRecipeCategory provides a mock function with given fields: ctx, categoryTag
-func (_m *RecipeRepository) CreateRecipeCategory(ctx context.Context, categoryTag string) error {
-	ret := _m.Called(ctx, categoryTag)
+// CreateRecipeCategory provides a mock function with given fields: ctx, recipeCategory
+func (_m *RecipeRepository) CreateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error {
+	ret := _m.Called(ctx, recipeCategory)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateRecipeCategory")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, categoryTag)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.RecipeCategory) error); ok {
+		r0 = rf(ctx, recipeCategory)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -70,6 +70,60 @@ func (_m *RecipeRepository) CreateRecipeRating(ctx context.Context, recipeId int
 	return r0
 }
 
+// CreateRecipeStep provides a mock function with given fields: ctx, recipeId, recipeStep
+func (_m *RecipeRepository) CreateRecipeStep(ctx context.Context, recipeId int64, recipeStep *entity.RecipeStep) error {
+	ret := _m.Called(ctx, recipeId, recipeStep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecipeStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *entity.RecipeStep) error); ok {
+		r0 = rf(ctx, recipeId, recipeStep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSearchSynonym provides a mock function with given fields: ctx, searchSynonym
+func (_m *RecipeRepository) CreateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error {
+	ret := _m.Called(ctx, searchSynonym)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.SearchSynonym) error); ok {
+		r0 = rf(ctx, searchSynonym)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTag provides a mock function with given fields: ctx, tag
+func (_m *RecipeRepository) CreateTag(ctx context.Context, tag *entity.Tag) error {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tag) error); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRecipeById provides a mock function with given fields: ctx, recipeId
 func (_m *RecipeRepository) DeleteRecipeById(ctx context.Context, recipeId int64) error {
 	ret := _m.Called(ctx, recipeId)
@@ -88,6 +142,126 @@ func (_m *RecipeRepository) DeleteRecipeById(ctx context.Context, recipeId int64
 	return r0
 }
 
+// DeleteRecipeCategory provides a mock function with given fields: ctx, categoryId, reassignToCategoryId
+func (_m *RecipeRepository) DeleteRecipeCategory(ctx context.Context, categoryId int64, reassignToCategoryId int64) error {
+	ret := _m.Called(ctx, categoryId, reassignToCategoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, categoryId, reassignToCategoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecipeRating provides a mock function with given fields: ctx, recipeId, userId
+func (_m *RecipeRepository) DeleteRecipeRating(ctx context.Context, recipeId int64, userId int64) error {
+	ret := _m.Called(ctx, recipeId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, recipeId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecipeStep provides a mock function with given fields: ctx, recipeId, recipeStepId
+func (_m *RecipeRepository) DeleteRecipeStep(ctx context.Context, recipeId int64, recipeStepId int64) error {
+	ret := _m.Called(ctx, recipeId, recipeStepId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, recipeId, recipeStepId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSearchSynonym provides a mock function with given fields: ctx, searchSynonymId
+func (_m *RecipeRepository) DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error {
+	ret := _m.Called(ctx, searchSynonymId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, searchSynonymId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTag provides a mock function with given fields: ctx, tagId
+func (_m *RecipeRepository) DeleteTag(ctx context.Context, tagId int64) error {
+	ret := _m.Called(ctx, tagId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, tagId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetIngredientNutritionsByRecipeId provides a mock function with given fields: ctx, recipeId
+func (_m *RecipeRepository) GetIngredientNutritionsByRecipeId(ctx context.Context, recipeId int64) (map[int64]entity.IngredientNutrition, error) {
+	ret := _m.Called(ctx, recipeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIngredientNutritionsByRecipeId")
+	}
+
+	var r0 map[int64]entity.IngredientNutrition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[int64]entity.IngredientNutrition, error)); ok {
+		return rf(ctx, recipeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[int64]entity.IngredientNutrition); ok {
+		r0 = rf(ctx, recipeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]entity.IngredientNutrition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, recipeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecipeById provides a mock function with given fields: ctx, recipeId
 func (_m *RecipeRepository) GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error) {
 	ret := _m.Called(ctx, recipeId)
@@ -178,6 +352,96 @@ func (_m *RecipeRepository) GetRecipeCategoryById(ctx context.Context, categoryI
 	return r0, r1
 }
 
+// GetRecipeFacets provides a mock function with given fields: ctx, getRecipesQueryFilter
+func (_m *RecipeRepository) GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error) {
+	ret := _m.Called(ctx, getRecipesQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeFacets")
+	}
+
+	var r0 *entity.RecipeFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error)); ok {
+		return rf(ctx, getRecipesQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) *entity.RecipeFacets); ok {
+		r0 = rf(ctx, getRecipesQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RecipeFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetRecipesQueryFilter) error); ok {
+		r1 = rf(ctx, getRecipesQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipeIds provides a mock function with given fields: ctx
+func (_m *RecipeRepository) GetRecipeIds(ctx context.Context) ([]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeIds")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipeRatingByUserId provides a mock function with given fields: ctx, recipeId, userId
+func (_m *RecipeRepository) GetRecipeRatingByUserId(ctx context.Context, recipeId int64, userId int64) (*entity.RecipeRating, error) {
+	ret := _m.Called(ctx, recipeId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeRatingByUserId")
+	}
+
+	var r0 *entity.RecipeRating
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*entity.RecipeRating, error)); ok {
+		return rf(ctx, recipeId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *entity.RecipeRating); ok {
+		r0 = rf(ctx, recipeId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RecipeRating)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, recipeId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecipeRatingSummary provides a mock function with given fields: ctx, recipeId
 func (_m *RecipeRepository) GetRecipeRatingSummary(ctx context.Context, recipeId int64) (float64, int, error) {
 	ret := _m.Called(ctx, recipeId)
@@ -213,24 +477,54 @@ func (_m *RecipeRepository) GetRecipeRatingSummary(ctx context.Context, recipeId
 	return r0, r1, r2
 }
 
+// GetRecipeStepsByRecipeId provides a mock function with given fields: ctx, recipeId
+func (_m *RecipeRepository) GetRecipeStepsByRecipeId(ctx context.Context, recipeId int64) ([]entity.RecipeStep, error) {
+	ret := _m.Called(ctx, recipeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeStepsByRecipeId")
+	}
+
+	var r0 []entity.RecipeStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]entity.RecipeStep, error)); ok {
+		return rf(ctx, recipeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.RecipeStep); ok {
+		r0 = rf(ctx, recipeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipeStep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, recipeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecipes provides a mock function with given fields: ctx, getRecipesQueryFilter
-func (_m *RecipeRepository) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) ([]entity.Recipe, error) {
+func (_m *RecipeRepository) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipePage, error) {
 	ret := _m.Called(ctx, getRecipesQueryFilter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecipes")
 	}
 
-	var r0 []entity.Recipe
+	var r0 *entity.RecipePage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) ([]entity.Recipe, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) (*entity.RecipePage, error)); ok {
 		return rf(ctx, getRecipesQueryFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) []entity.Recipe); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) *entity.RecipePage); ok {
 		r0 = rf(ctx, getRecipesQueryFilter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Recipe)
+			r0 = ret.Get(0).(*entity.RecipePage)
 		}
 	}
 
@@ -243,6 +537,232 @@ func (_m *RecipeRepository) GetRecipes(ctx context.Context, getRecipesQueryFilte
 	return r0, r1
 }
 
+// GetRecipesByIngredients provides a mock function with given fields: ctx, getRecipesByIngredientsQueryFilter
+func (_m *RecipeRepository) GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error) {
+	ret := _m.Called(ctx, getRecipesByIngredientsQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipesByIngredients")
+	}
+
+	var r0 []entity.RecipeMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error)); ok {
+		return rf(ctx, getRecipesByIngredientsQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) []entity.RecipeMatch); ok {
+		r0 = rf(ctx, getRecipesByIngredientsQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipeMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) error); ok {
+		r1 = rf(ctx, getRecipesByIngredientsQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchSynonyms provides a mock function with given fields: ctx
+func (_m *RecipeRepository) GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchSynonyms")
+	}
+
+	var r0 []entity.SearchSynonym
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.SearchSynonym, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.SearchSynonym); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SearchSynonym)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSuggestions provides a mock function with given fields: ctx, query, limit
+func (_m *RecipeRepository) GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuggestions")
+	}
+
+	var r0 []entity.Suggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]entity.Suggestion, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []entity.Suggestion); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Suggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx
+func (_m *RecipeRepository) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []entity.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Tag, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Tag); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReorderRecipeCategories provides a mock function with given fields: ctx, categoryIds
+func (_m *RecipeRepository) ReorderRecipeCategories(ctx context.Context, categoryIds []int64) error {
+	ret := _m.Called(ctx, categoryIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderRecipeCategories")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, categoryIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderRecipeSteps provides a mock function with given fields: ctx, recipeId, recipeStepIds
+func (_m *RecipeRepository) ReorderRecipeSteps(ctx context.Context, recipeId int64, recipeStepIds []int64) error {
+	ret := _m.Called(ctx, recipeId, recipeStepIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderRecipeSteps")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64) error); ok {
+		r0 = rf(ctx, recipeId, recipeStepIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveRecipeCategorySlug provides a mock function with given fields: ctx, slug
+func (_m *RecipeRepository) ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRecipeCategorySlug")
+	}
+
+	var r0 int64
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, string, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResolveRecipeSlug provides a mock function with given fields: ctx, slug
+func (_m *RecipeRepository) ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRecipeSlug")
+	}
+
+	var r0 int64
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, string, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateRecipeById provides a mock function with given fields: ctx, recipeId, updateRecipeByIdQueryFilter
 func (_m *RecipeRepository) UpdateRecipeById(ctx context.Context, recipeId int64, updateRecipeByIdQueryFilter *domain.UpdateRecipeByIdQueryFilter) error {
 	ret := _m.Called(ctx, recipeId, updateRecipeByIdQueryFilter)
@@ -261,6 +781,150 @@ func (_m *RecipeRepository) UpdateRecipeById(ctx context.Context, recipeId int64
 	return r0
 }
 
+// UpdateRecipeCategory provides a mock function with given fields: ctx, recipeCategory
+func (_m *RecipeRepository) UpdateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error {
+	ret := _m.Called(ctx, recipeCategory)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipeCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.RecipeCategory) error); ok {
+		r0 = rf(ctx, recipeCategory)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRecipePublished provides a mock function with given fields: ctx, recipeId, published
+func (_m *RecipeRepository) UpdateRecipePublished(ctx context.Context, recipeId int64, published bool) error {
+	ret := _m.Called(ctx, recipeId, published)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipePublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, recipeId, published)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRecipeRating provides a mock function with given fields: ctx, recipeId, userId, rating
+func (_m *RecipeRepository) UpdateRecipeRating(ctx context.Context, recipeId int64, userId int64, rating int) error {
+	ret := _m.Called(ctx, recipeId, userId, rating)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipeRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int) error); ok {
+		r0 = rf(ctx, recipeId, userId, rating)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSearchSynonym provides a mock function with given fields: ctx, searchSynonym
+func (_m *RecipeRepository) UpdateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error {
+	ret := _m.Called(ctx, searchSynonym)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.SearchSynonym) error); ok {
+		r0 = rf(ctx, searchSynonym)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTag provides a mock function with given fields: ctx, tag
+func (_m *RecipeRepository) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Tag) error); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertIngredientNutritions provides a mock function with given fields: ctx, ingredientNutritions
+func (_m *RecipeRepository) UpsertIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error {
+	ret := _m.Called(ctx, ingredientNutritions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertIngredientNutritions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.IngredientNutrition) error); ok {
+		r0 = rf(ctx, ingredientNutritions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertRecipeNutrition provides a mock function with given fields: ctx, recipeId, recipeNutrition
+func (_m *RecipeRepository) UpsertRecipeNutrition(ctx context.Context, recipeId int64, recipeNutrition *entity.RecipeNutrition) error {
+	ret := _m.Called(ctx, recipeId, recipeNutrition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRecipeNutrition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *entity.RecipeNutrition) error); ok {
+		r0 = rf(ctx, recipeId, recipeNutrition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertSearchSynonyms provides a mock function with given fields: ctx, searchSynonyms
+func (_m *RecipeRepository) UpsertSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error {
+	ret := _m.Called(ctx, searchSynonyms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSearchSynonyms")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SearchSynonym) error); ok {
+		r0 = rf(ctx, searchSynonyms)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRecipeRepository creates a new instance of RecipeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRecipeRepository(t interface {
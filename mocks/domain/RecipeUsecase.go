@@ -52,6 +52,78 @@ func (_m *RecipeUsecase) CreateRecipeCategory(ctx context.Context, createRecipeC
 	return r0
 }
 
+// CreateRecipeRating provides a mock function with given fields: ctx, recipeId, userId, createRecipeRatingDTO
+func (_m *RecipeUsecase) CreateRecipeRating(ctx context.Context, recipeId int64, userId int64, createRecipeRatingDTO *domain.CreateRecipeRatingDTO) error {
+	ret := _m.Called(ctx, recipeId, userId, createRecipeRatingDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecipeRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.CreateRecipeRatingDTO) error); ok {
+		r0 = rf(ctx, recipeId, userId, createRecipeRatingDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRecipeStep provides a mock function with given fields: ctx, recipeId, createRecipeStepDTO
+func (_m *RecipeUsecase) CreateRecipeStep(ctx context.Context, recipeId int64, createRecipeStepDTO *domain.CreateRecipeStepDTO) error {
+	ret := _m.Called(ctx, recipeId, createRecipeStepDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecipeStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.CreateRecipeStepDTO) error); ok {
+		r0 = rf(ctx, recipeId, createRecipeStepDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSearchSynonym provides a mock function with given fields: ctx, searchSynonymDTO
+func (_m *RecipeUsecase) CreateSearchSynonym(ctx context.Context, searchSynonymDTO *domain.SearchSynonymDTO) error {
+	ret := _m.Called(ctx, searchSynonymDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SearchSynonymDTO) error); ok {
+		r0 = rf(ctx, searchSynonymDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTag provides a mock function with given fields: ctx, tagDTO
+func (_m *RecipeUsecase) CreateTag(ctx context.Context, tagDTO *domain.TagDTO) error {
+	ret := _m.Called(ctx, tagDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TagDTO) error); ok {
+		r0 = rf(ctx, tagDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRecipeById provides a mock function with given fields: ctx, recipeId
 func (_m *RecipeUsecase) DeleteRecipeById(ctx context.Context, recipeId int64) error {
 	ret := _m.Called(ctx, recipeId)
@@ -70,9 +142,99 @@ func (_m *RecipeUsecase) DeleteRecipeById(ctx context.Context, recipeId int64) e
 	return r0
 }
 
-// GetRecipeById provides a mock function with given fields: ctx, recipeId
-func (_m *RecipeUsecase) GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error) {
-	ret := _m.Called(ctx, recipeId)
+// DeleteRecipeCategory provides a mock function with given fields: ctx, categoryId, reassignToCategoryId
+func (_m *RecipeUsecase) DeleteRecipeCategory(ctx context.Context, categoryId int64, reassignToCategoryId int64) error {
+	ret := _m.Called(ctx, categoryId, reassignToCategoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, categoryId, reassignToCategoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecipeRating provides a mock function with given fields: ctx, recipeId, userId
+func (_m *RecipeUsecase) DeleteRecipeRating(ctx context.Context, recipeId int64, userId int64) error {
+	ret := _m.Called(ctx, recipeId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, recipeId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecipeStep provides a mock function with given fields: ctx, recipeId, recipeStepId
+func (_m *RecipeUsecase) DeleteRecipeStep(ctx context.Context, recipeId int64, recipeStepId int64) error {
+	ret := _m.Called(ctx, recipeId, recipeStepId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipeStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, recipeId, recipeStepId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSearchSynonym provides a mock function with given fields: ctx, searchSynonymId
+func (_m *RecipeUsecase) DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error {
+	ret := _m.Called(ctx, searchSynonymId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, searchSynonymId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTag provides a mock function with given fields: ctx, tagId
+func (_m *RecipeUsecase) DeleteTag(ctx context.Context, tagId int64) error {
+	ret := _m.Called(ctx, tagId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, tagId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRecipeById provides a mock function with given fields: ctx, recipeId, getRecipeByIdQueryFilter
+func (_m *RecipeUsecase) GetRecipeById(ctx context.Context, recipeId int64, getRecipeByIdQueryFilter *domain.GetRecipeByIdQueryFilter) (*entity.Recipe, error) {
+	ret := _m.Called(ctx, recipeId, getRecipeByIdQueryFilter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecipeById")
@@ -80,19 +242,19 @@ func (_m *RecipeUsecase) GetRecipeById(ctx context.Context, recipeId int64) (*en
 
 	var r0 *entity.Recipe
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.Recipe, error)); ok {
-		return rf(ctx, recipeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.GetRecipeByIdQueryFilter) (*entity.Recipe, error)); ok {
+		return rf(ctx, recipeId, getRecipeByIdQueryFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Recipe); ok {
-		r0 = rf(ctx, recipeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.GetRecipeByIdQueryFilter) *entity.Recipe); ok {
+		r0 = rf(ctx, recipeId, getRecipeByIdQueryFilter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Recipe)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, recipeId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.GetRecipeByIdQueryFilter) error); ok {
+		r1 = rf(ctx, recipeId, getRecipeByIdQueryFilter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,24 +322,114 @@ func (_m *RecipeUsecase) GetRecipeCategoryById(ctx context.Context, categoryId i
 	return r0, r1
 }
 
+// GetRecipeFacets provides a mock function with given fields: ctx, getRecipesQueryFilter
+func (_m *RecipeUsecase) GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error) {
+	ret := _m.Called(ctx, getRecipesQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeFacets")
+	}
+
+	var r0 *entity.RecipeFacets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error)); ok {
+		return rf(ctx, getRecipesQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) *entity.RecipeFacets); ok {
+		r0 = rf(ctx, getRecipesQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RecipeFacets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetRecipesQueryFilter) error); ok {
+		r1 = rf(ctx, getRecipesQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipeRatingSummary provides a mock function with given fields: ctx, recipeId, userId
+func (_m *RecipeUsecase) GetRecipeRatingSummary(ctx context.Context, recipeId int64, userId int64) (*entity.RecipeRatingSummary, error) {
+	ret := _m.Called(ctx, recipeId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeRatingSummary")
+	}
+
+	var r0 *entity.RecipeRatingSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*entity.RecipeRatingSummary, error)); ok {
+		return rf(ctx, recipeId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *entity.RecipeRatingSummary); ok {
+		r0 = rf(ctx, recipeId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RecipeRatingSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, recipeId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecipeSteps provides a mock function with given fields: ctx, recipeId, includeDrafts
+func (_m *RecipeUsecase) GetRecipeSteps(ctx context.Context, recipeId int64, includeDrafts bool) ([]entity.RecipeStep, error) {
+	ret := _m.Called(ctx, recipeId, includeDrafts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipeSteps")
+	}
+
+	var r0 []entity.RecipeStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) ([]entity.RecipeStep, error)); ok {
+		return rf(ctx, recipeId, includeDrafts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) []entity.RecipeStep); ok {
+		r0 = rf(ctx, recipeId, includeDrafts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipeStep)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, recipeId, includeDrafts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecipes provides a mock function with given fields: ctx, getRecipesQueryFilter
-func (_m *RecipeUsecase) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) ([]entity.Recipe, error) {
+func (_m *RecipeUsecase) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipePage, error) {
 	ret := _m.Called(ctx, getRecipesQueryFilter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecipes")
 	}
 
-	var r0 []entity.Recipe
+	var r0 *entity.RecipePage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) ([]entity.Recipe, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) (*entity.RecipePage, error)); ok {
 		return rf(ctx, getRecipesQueryFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) []entity.Recipe); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesQueryFilter) *entity.RecipePage); ok {
 		r0 = rf(ctx, getRecipesQueryFilter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Recipe)
+			r0 = ret.Get(0).(*entity.RecipePage)
 		}
 	}
 
@@ -190,6 +442,304 @@ func (_m *RecipeUsecase) GetRecipes(ctx context.Context, getRecipesQueryFilter *
 	return r0, r1
 }
 
+// GetRecipesByIngredients provides a mock function with given fields: ctx, getRecipesByIngredientsQueryFilter
+func (_m *RecipeUsecase) GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error) {
+	ret := _m.Called(ctx, getRecipesByIngredientsQueryFilter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipesByIngredients")
+	}
+
+	var r0 []entity.RecipeMatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error)); ok {
+		return rf(ctx, getRecipesByIngredientsQueryFilter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) []entity.RecipeMatch); ok {
+		r0 = rf(ctx, getRecipesByIngredientsQueryFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipeMatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.GetRecipesByIngredientsQueryFilter) error); ok {
+		r1 = rf(ctx, getRecipesByIngredientsQueryFilter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchSynonyms provides a mock function with given fields: ctx
+func (_m *RecipeUsecase) GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSearchSynonyms")
+	}
+
+	var r0 []entity.SearchSynonym
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.SearchSynonym, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.SearchSynonym); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SearchSynonym)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSuggestions provides a mock function with given fields: ctx, query, limit
+func (_m *RecipeUsecase) GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuggestions")
+	}
+
+	var r0 []entity.Suggestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]entity.Suggestion, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []entity.Suggestion); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Suggestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx
+func (_m *RecipeUsecase) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []entity.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Tag, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Tag); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportIngredientNutritions provides a mock function with given fields: ctx, ingredientNutritions
+func (_m *RecipeUsecase) ImportIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error {
+	ret := _m.Called(ctx, ingredientNutritions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportIngredientNutritions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.IngredientNutrition) error); ok {
+		r0 = rf(ctx, ingredientNutritions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportSearchSynonyms provides a mock function with given fields: ctx, searchSynonyms
+func (_m *RecipeUsecase) ImportSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error {
+	ret := _m.Called(ctx, searchSynonyms)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportSearchSynonyms")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.SearchSynonym) error); ok {
+		r0 = rf(ctx, searchSynonyms)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishRecipe provides a mock function with given fields: ctx, recipeId, published
+func (_m *RecipeUsecase) PublishRecipe(ctx context.Context, recipeId int64, published bool) error {
+	ret := _m.Called(ctx, recipeId, published)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRecipe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, recipeId, published)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshRecipeNutrition provides a mock function with given fields: ctx, recipeId
+func (_m *RecipeUsecase) RefreshRecipeNutrition(ctx context.Context, recipeId int64) error {
+	ret := _m.Called(ctx, recipeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRecipeNutrition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, recipeId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderRecipeCategories provides a mock function with given fields: ctx, reorderRecipeCategoriesDTO
+func (_m *RecipeUsecase) ReorderRecipeCategories(ctx context.Context, reorderRecipeCategoriesDTO *domain.ReorderRecipeCategoriesDTO) error {
+	ret := _m.Called(ctx, reorderRecipeCategoriesDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderRecipeCategories")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ReorderRecipeCategoriesDTO) error); ok {
+		r0 = rf(ctx, reorderRecipeCategoriesDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderRecipeSteps provides a mock function with given fields: ctx, recipeId, reorderRecipeStepsDTO
+func (_m *RecipeUsecase) ReorderRecipeSteps(ctx context.Context, recipeId int64, reorderRecipeStepsDTO *domain.ReorderRecipeStepsDTO) error {
+	ret := _m.Called(ctx, recipeId, reorderRecipeStepsDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderRecipeSteps")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.ReorderRecipeStepsDTO) error); ok {
+		r0 = rf(ctx, recipeId, reorderRecipeStepsDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveRecipeCategorySlug provides a mock function with given fields: ctx, slug
+func (_m *RecipeUsecase) ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRecipeCategorySlug")
+	}
+
+	var r0 int64
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, string, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResolveRecipeSlug provides a mock function with given fields: ctx, slug
+func (_m *RecipeUsecase) ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error) {
+	ret := _m.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveRecipeSlug")
+	}
+
+	var r0 int64
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, string, error)); ok {
+		return rf(ctx, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateRecipe provides a mock function with given fields: ctx, recipeId, updateRecipeDTO
 func (_m *RecipeUsecase) UpdateRecipe(ctx context.Context, recipeId int64, updateRecipeDTO *domain.UpdateRecipeDTO) error {
 	ret := _m.Called(ctx, recipeId, updateRecipeDTO)
@@ -208,6 +758,78 @@ func (_m *RecipeUsecase) UpdateRecipe(ctx context.Context, recipeId int64, updat
 	return r0
 }
 
+// UpdateRecipeCategory provides a mock function with given fields: ctx, categoryId, updateRecipeCategoryDTO
+func (_m *RecipeUsecase) UpdateRecipeCategory(ctx context.Context, categoryId int64, updateRecipeCategoryDTO *domain.UpdateRecipeCategoryDTO) error {
+	ret := _m.Called(ctx, categoryId, updateRecipeCategoryDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipeCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdateRecipeCategoryDTO) error); ok {
+		r0 = rf(ctx, categoryId, updateRecipeCategoryDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRecipeRating provides a mock function with given fields: ctx, recipeId, userId, updateRecipeRatingDTO
+func (_m *RecipeUsecase) UpdateRecipeRating(ctx context.Context, recipeId int64, userId int64, updateRecipeRatingDTO *domain.UpdateRecipeRatingDTO) error {
+	ret := _m.Called(ctx, recipeId, userId, updateRecipeRatingDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipeRating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.UpdateRecipeRatingDTO) error); ok {
+		r0 = rf(ctx, recipeId, userId, updateRecipeRatingDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSearchSynonym provides a mock function with given fields: ctx, searchSynonymId, searchSynonymDTO
+func (_m *RecipeUsecase) UpdateSearchSynonym(ctx context.Context, searchSynonymId int64, searchSynonymDTO *domain.SearchSynonymDTO) error {
+	ret := _m.Called(ctx, searchSynonymId, searchSynonymDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSearchSynonym")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.SearchSynonymDTO) error); ok {
+		r0 = rf(ctx, searchSynonymId, searchSynonymDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTag provides a mock function with given fields: ctx, tagId, tagDTO
+func (_m *RecipeUsecase) UpdateTag(ctx context.Context, tagId int64, tagDTO *domain.TagDTO) error {
+	ret := _m.Called(ctx, tagId, tagDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.TagDTO) error); ok {
+		r0 = rf(ctx, tagId, tagDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRecipeUsecase creates a new instance of RecipeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRecipeUsecase(t interface {
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
 	domain "github.com/victorsantoso/endeus/domain"
+	entity "github.com/victorsantoso/endeus/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -14,23 +18,234 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// AcceptInvitation provides a mock function with given fields: ctx, acceptInvitationDTO
+func (_m *UserUsecase) AcceptInvitation(ctx context.Context, acceptInvitationDTO *domain.AcceptInvitationDTO) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, acceptInvitationDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AcceptInvitationDTO) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, acceptInvitationDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AcceptInvitationDTO) *domain.AuthTokens); ok {
+		r0 = rf(ctx, acceptInvitationDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AcceptInvitationDTO) error); ok {
+		r1 = rf(ctx, acceptInvitationDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAdmin provides a mock function with given fields: ctx, createAdminDTO
+func (_m *UserUsecase) CreateAdmin(ctx context.Context, createAdminDTO *domain.CreateAdminDTO) (int64, error) {
+	ret := _m.Called(ctx, createAdminDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdmin")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAdminDTO) (int64, error)); ok {
+		return rf(ctx, createAdminDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.CreateAdminDTO) int64); ok {
+		r0 = rf(ctx, createAdminDTO)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.CreateAdminDTO) error); ok {
+		r1 = rf(ctx, createAdminDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, adminId, createInvitationDTO
+func (_m *UserUsecase) CreateInvitation(ctx context.Context, adminId int64, createInvitationDTO *domain.CreateInvitationDTO) (*entity.Invitation, string, error) {
+	ret := _m.Called(ctx, adminId, createInvitationDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 *entity.Invitation
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.CreateInvitationDTO) (*entity.Invitation, string, error)); ok {
+		return rf(ctx, adminId, createInvitationDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.CreateInvitationDTO) *entity.Invitation); ok {
+		r0 = rf(ctx, adminId, createInvitationDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.CreateInvitationDTO) string); ok {
+		r1 = rf(ctx, adminId, createInvitationDTO)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *domain.CreateInvitationDTO) error); ok {
+		r2 = rf(ctx, adminId, createInvitationDTO)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteInvitation provides a mock function with given fields: ctx, invitationId
+func (_m *UserUsecase) DeleteInvitation(ctx context.Context, invitationId int64) error {
+	ret := _m.Called(ctx, invitationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, invitationId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMe provides a mock function with given fields: ctx, userId, deleteMeDTO
+func (_m *UserUsecase) DeleteMe(ctx context.Context, userId int64, deleteMeDTO *domain.DeleteMeDTO) error {
+	ret := _m.Called(ctx, userId, deleteMeDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.DeleteMeDTO) error); ok {
+		r0 = rf(ctx, userId, deleteMeDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, forgotPasswordDTO
+func (_m *UserUsecase) ForgotPassword(ctx context.Context, forgotPasswordDTO *domain.ForgotPasswordDTO) error {
+	ret := _m.Called(ctx, forgotPasswordDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ForgotPasswordDTO) error); ok {
+		r0 = rf(ctx, forgotPasswordDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetInvitations provides a mock function with given fields: ctx
+func (_m *UserUsecase) GetInvitations(ctx context.Context) ([]entity.Invitation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 []entity.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Invitation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Invitation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMe provides a mock function with given fields: ctx, userId
+func (_m *UserUsecase) GetMe(ctx context.Context, userId int64) (*entity.User, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMe")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*entity.User, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.User); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, loginDTO
-func (_m *UserUsecase) Login(ctx context.Context, loginDTO *domain.LoginDTO) (string, error) {
+func (_m *UserUsecase) Login(ctx context.Context, loginDTO *domain.LoginDTO) (*domain.AuthTokens, error) {
 	ret := _m.Called(ctx, loginDTO)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 *domain.AuthTokens
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginDTO) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginDTO) (*domain.AuthTokens, error)); ok {
 		return rf(ctx, loginDTO)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginDTO) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginDTO) *domain.AuthTokens); ok {
 		r0 = rf(ctx, loginDTO)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *domain.LoginDTO) error); ok {
@@ -42,23 +257,73 @@ func (_m *UserUsecase) Login(ctx context.Context, loginDTO *domain.LoginDTO) (st
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, userId, jti, accessTokenExpiresAt, logoutDTO
+func (_m *UserUsecase) Logout(ctx context.Context, userId int64, jti string, accessTokenExpiresAt time.Time, logoutDTO *domain.LogoutDTO) error {
+	ret := _m.Called(ctx, userId, jti, accessTokenExpiresAt, logoutDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time, *domain.LogoutDTO) error); ok {
+		r0 = rf(ctx, userId, jti, accessTokenExpiresAt, logoutDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshTokenDTO
+func (_m *UserUsecase) RefreshToken(ctx context.Context, refreshTokenDTO *domain.RefreshTokenDTO) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, refreshTokenDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RefreshTokenDTO) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, refreshTokenDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RefreshTokenDTO) *domain.AuthTokens); ok {
+		r0 = rf(ctx, refreshTokenDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.RefreshTokenDTO) error); ok {
+		r1 = rf(ctx, refreshTokenDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, registerDTO
-func (_m *UserUsecase) Register(ctx context.Context, registerDTO *domain.RegisterDTO) (string, error) {
+func (_m *UserUsecase) Register(ctx context.Context, registerDTO *domain.RegisterDTO) (*domain.AuthTokens, error) {
 	ret := _m.Called(ctx, registerDTO)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
 	}
 
-	var r0 string
+	var r0 *domain.AuthTokens
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.RegisterDTO) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RegisterDTO) (*domain.AuthTokens, error)); ok {
 		return rf(ctx, registerDTO)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.RegisterDTO) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.RegisterDTO) *domain.AuthTokens); ok {
 		r0 = rf(ctx, registerDTO)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *domain.RegisterDTO) error); ok {
@@ -70,6 +335,156 @@ func (_m *UserUsecase) Register(ctx context.Context, registerDTO *domain.Registe
 	return r0, r1
 }
 
+// ResendEmailVerification provides a mock function with given fields: ctx, userId
+func (_m *UserUsecase) ResendEmailVerification(ctx context.Context, userId int64) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendEmailVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, resetPasswordDTO
+func (_m *UserUsecase) ResetPassword(ctx context.Context, resetPasswordDTO *domain.ResetPasswordDTO) error {
+	ret := _m.Called(ctx, resetPasswordDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ResetPasswordDTO) error); ok {
+		r0 = rf(ctx, resetPasswordDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEmail provides a mock function with given fields: ctx, userId, updateEmailDTO
+func (_m *UserUsecase) UpdateEmail(ctx context.Context, userId int64, updateEmailDTO *domain.UpdateEmailDTO) error {
+	ret := _m.Called(ctx, userId, updateEmailDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdateEmailDTO) error); ok {
+		r0 = rf(ctx, userId, updateEmailDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMe provides a mock function with given fields: ctx, userId, updateMeDTO
+func (_m *UserUsecase) UpdateMe(ctx context.Context, userId int64, updateMeDTO *domain.UpdateMeDTO) (*entity.User, error) {
+	ret := _m.Called(ctx, userId, updateMeDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMe")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdateMeDTO) (*entity.User, error)); ok {
+		return rf(ctx, userId, updateMeDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdateMeDTO) *entity.User); ok {
+		r0 = rf(ctx, userId, updateMeDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.UpdateMeDTO) error); ok {
+		r1 = rf(ctx, userId, updateMeDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, userId, updatePasswordDTO
+func (_m *UserUsecase) UpdatePassword(ctx context.Context, userId int64, updatePasswordDTO *domain.UpdatePasswordDTO) (*domain.AuthTokens, error) {
+	ret := _m.Called(ctx, userId, updatePasswordDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 *domain.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdatePasswordDTO) (*domain.AuthTokens, error)); ok {
+		return rf(ctx, userId, updatePasswordDTO)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.UpdatePasswordDTO) *domain.AuthTokens); ok {
+		r0 = rf(ctx, userId, updatePasswordDTO)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.UpdatePasswordDTO) error); ok {
+		r1 = rf(ctx, userId, updatePasswordDTO)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, adminId, userId, updateUserRoleDTO
+func (_m *UserUsecase) UpdateUserRole(ctx context.Context, adminId int64, userId int64, updateUserRoleDTO *domain.UpdateUserRoleDTO) error {
+	ret := _m.Called(ctx, adminId, userId, updateUserRoleDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *domain.UpdateUserRoleDTO) error); ok {
+		r0 = rf(ctx, adminId, userId, updateUserRoleDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, verifyEmailDTO
+func (_m *UserUsecase) VerifyEmail(ctx context.Context, verifyEmailDTO *domain.VerifyEmailDTO) error {
+	ret := _m.Called(ctx, verifyEmailDTO)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.VerifyEmailDTO) error); ok {
+		r0 = rf(ctx, verifyEmailDTO)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserUsecase creates a new instance of UserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecase(t interface {
@@ -3,19 +3,26 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/search"
+	"github.com/victorsantoso/endeus/units"
 )
 
 type recipeHandler struct {
 	recipeUsecase domain.RecipeUsecase
 }
 
-func NewRecipeHandler(g *gin.Engine, authMiddleware gin.HandlerFunc, recipeUsecase domain.RecipeUsecase) {
+func NewRecipeHandler(g *gin.Engine, authMiddleware gin.HandlerFunc, requirePermission func(permissions ...string) gin.HandlerFunc, requireVerifiedEmail gin.HandlerFunc, recipeUsecase domain.RecipeUsecase) {
 	recipeHandler := &recipeHandler{
 		recipeUsecase: recipeUsecase,
 	}
@@ -25,31 +32,59 @@ func NewRecipeHandler(g *gin.Engine, authMiddleware gin.HandlerFunc, recipeUseca
 	// Recipe Category
 	noAuthGroup.GET("/recipe_categories", recipeHandler.GetRecipeCategories)
 	noAuthGroup.GET("/recipe_category/:recipeCategoryId", recipeHandler.GetRecipeCategoryById)
+	noAuthGroup.GET("/recipe_category/slug/:slug", recipeHandler.GetRecipeCategoryBySlug)
+	// Tag
+	noAuthGroup.GET("/tags", recipeHandler.GetTags)
 	// Recipe
 	noAuthGroup.GET("/recipe/:recipeId", recipeHandler.GetRecipeById)
+	noAuthGroup.GET("/recipe/slug/:slug", recipeHandler.GetRecipeBySlug)
 	noAuthGroup.GET("/recipes", recipeHandler.GetRecipes)
+	noAuthGroup.GET("/recipes/by-ingredients", recipeHandler.GetRecipesByIngredients)
+	// Suggest, type-ahead over recipe titles, category tags and ingredient names
+	noAuthGroup.GET("/suggest", recipeHandler.GetSuggestions)
+	noAuthGroup.GET("/recipe/:recipeId/steps", recipeHandler.GetRecipeSteps)
 
-	// Auth group with ADMIN role only
+	// Auth group, mutating routes require a permission granted to the role of the user
 	authGroup := g.Group("/api/v1", authMiddleware)
 	// Recipe Category
-	authGroup.POST("/recipe_category", recipeHandler.CreateRecipeCategory)
+	authGroup.POST("/recipe_category", requirePermission(domain.PermissionCategoryManage), recipeHandler.CreateRecipeCategory)
+	authGroup.PUT("/recipe_category/:recipeCategoryId", requirePermission(domain.PermissionCategoryManage), recipeHandler.UpdateRecipeCategory)
+	authGroup.DELETE("/recipe_category/:recipeCategoryId", requirePermission(domain.PermissionCategoryManage), recipeHandler.DeleteRecipeCategory)
+	authGroup.PUT("/recipe_categories/order", requirePermission(domain.PermissionCategoryManage), recipeHandler.ReorderRecipeCategories)
+	// Tag
+	authGroup.POST("/tag", requirePermission(domain.PermissionTagManage), recipeHandler.CreateTag)
+	authGroup.PUT("/tag/:tagId", requirePermission(domain.PermissionTagManage), recipeHandler.UpdateTag)
+	authGroup.DELETE("/tag/:tagId", requirePermission(domain.PermissionTagManage), recipeHandler.DeleteTag)
 	// Recipe
-	authGroup.POST("/recipe", recipeHandler.CreateRecipe)
-	authGroup.PUT("/recipe/:recipeId", recipeHandler.UpdateRecipe)
-	authGroup.DELETE("/recipe/:recipeId", recipeHandler.DeleteRecipe)
+	authGroup.POST("/recipe", requirePermission(domain.PermissionRecipeCreate), recipeHandler.CreateRecipe)
+	authGroup.PUT("/recipe/:recipeId", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.UpdateRecipe)
+	authGroup.DELETE("/recipe/:recipeId", requirePermission(domain.PermissionRecipeDelete), recipeHandler.DeleteRecipe)
+	// Recipe Draft, new recipes are drafts until they are published
+	authGroup.GET("/recipes/drafts", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.GetDrafts)
+	authGroup.GET("/recipes/drafts/:recipeId", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.GetDraftById)
+	authGroup.GET("/recipes/drafts/:recipeId/steps", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.GetDraftSteps)
+	authGroup.PUT("/recipe/:recipeId/publish", requirePermission(domain.PermissionRecipePublish), recipeHandler.PublishRecipe)
+	authGroup.DELETE("/recipe/:recipeId/publish", requirePermission(domain.PermissionRecipePublish), recipeHandler.UnpublishRecipe)
+	// Recipe Step, steps are part of the recipe
+	authGroup.POST("/recipe/:recipeId/steps", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.CreateRecipeStep)
+	authGroup.PUT("/recipe/:recipeId/steps", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.ReorderRecipeSteps)
+	authGroup.DELETE("/recipe/:recipeId/steps/:recipeStepId", requirePermission(domain.PermissionRecipeUpdate), recipeHandler.DeleteRecipeStep)
+
+	// Recipe Rating, any authenticated user can rate a recipe once, posting may require a verified email
+	authGroup.GET("/recipe/:recipeId/rating", recipeHandler.GetRecipeRatingSummary)
+	authGroup.POST("/recipe/:recipeId/rating", requireVerifiedEmail, recipeHandler.CreateRecipeRating)
+	authGroup.PUT("/recipe/:recipeId/rating", requireVerifiedEmail, recipeHandler.UpdateRecipeRating)
+	authGroup.DELETE("/recipe/:recipeId/rating", recipeHandler.DeleteRecipeRating)
+
+	// Search Synonym
+	authGroup.GET("/search_synonyms", requirePermission(domain.PermissionSearchManage), recipeHandler.GetSearchSynonyms)
+	authGroup.POST("/search_synonym", requirePermission(domain.PermissionSearchManage), recipeHandler.CreateSearchSynonym)
+	authGroup.PUT("/search_synonym/:searchSynonymId", requirePermission(domain.PermissionSearchManage), recipeHandler.UpdateSearchSynonym)
+	authGroup.DELETE("/search_synonym/:searchSynonymId", requirePermission(domain.PermissionSearchManage), recipeHandler.DeleteSearchSynonym)
 }
 
 // Recipe Category
 func (rh *recipeHandler) CreateRecipeCategory(c *gin.Context) {
-	key, _ := c.Get("user")
-	user, ok := key.(*entity.User)
-	if !ok || user.Role != domain.ADMIN {
-		c.JSON(http.StatusForbidden, &domain.CreateRecipeCategoryResponse{
-			Message: domain.ErrForbidenAccess.Error(),
-			Code:    http.StatusForbidden,
-		})
-		return
-	}
 	createRecipeCategoryDTO := &domain.CreateRecipeCategoryDTO{}
 	if err := c.ShouldBindJSON(createRecipeCategoryDTO); err != nil {
 		c.JSON(http.StatusBadRequest, &domain.CreateRecipeCategoryResponse{
@@ -59,6 +94,20 @@ func (rh *recipeHandler) CreateRecipeCategory(c *gin.Context) {
 		return
 	}
 	if err := rh.recipeUsecase.CreateRecipeCategory(context.Background(), createRecipeCategoryDTO); err != nil {
+		switch err {
+		case domain.ErrUnknownParentCategory:
+			c.JSON(http.StatusBadRequest, &domain.CreateRecipeCategoryResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		case domain.ErrDuplicateCategory:
+			c.JSON(http.StatusConflict, &domain.CreateRecipeCategoryResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, &domain.CreateRecipeCategoryResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
@@ -80,7 +129,36 @@ func (rh *recipeHandler) GetRecipeCategoryById(c *gin.Context) {
 		})
 		return
 	}
-	recipeCategory, err := rh.recipeUsecase.GetRecipeCategoryById(context.Background(), int64(categoryId))
+	rh.getRecipeCategory(c, int64(categoryId))
+}
+
+// GetRecipeCategoryBySlug permanently redirects a former slug of a renamed category to its current slug
+func (rh *recipeHandler) GetRecipeCategoryBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	categoryId, currentSlug, err := rh.recipeUsecase.ResolveRecipeCategorySlug(context.Background(), slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetRecipeCategoryByIdResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipeCategoryByIdResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if currentSlug != slug {
+		redirectToSlug(c, "/api/v1/recipe_category/slug/", currentSlug)
+		return
+	}
+	rh.getRecipeCategory(c, categoryId)
+}
+
+func (rh *recipeHandler) getRecipeCategory(c *gin.Context, categoryId int64) {
+	recipeCategory, err := rh.recipeUsecase.GetRecipeCategoryById(context.Background(), categoryId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, &domain.GetRecipeCategoryByIdResponse{
@@ -125,198 +203,1338 @@ func (rh *recipeHandler) GetRecipeCategories(c *gin.Context) {
 	})
 }
 
-// Recipe
-func (rh *recipeHandler) CreateRecipe(c *gin.Context) {
-	key, _ := c.Get("user")
-	user, ok := key.(*entity.User)
-	if !ok || user.Role != domain.ADMIN {
-		c.JSON(http.StatusForbidden, &domain.CreateRecipeResponse{
-			Message: domain.ErrForbidenAccess.Error(),
-			Code:    http.StatusForbidden,
+func (rh *recipeHandler) UpdateRecipeCategory(c *gin.Context) {
+	categoryId, err := strconv.Atoi(c.Param("recipeCategoryId"))
+	if err != nil || categoryId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeCategoryResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	createRecipeDTO := &domain.CreateRecipeDTO{}
-	if err := c.ShouldBindJSON(createRecipeDTO); err != nil {
-		c.JSON(http.StatusBadRequest, &domain.CreateRecipeResponse{
+	updateRecipeCategoryDTO := &domain.UpdateRecipeCategoryDTO{}
+	if err := c.ShouldBindJSON(updateRecipeCategoryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeCategoryResponse{
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	if err := rh.recipeUsecase.CreateRecipe(context.Background(), createRecipeDTO); err != nil {
-		c.JSON(http.StatusInternalServerError, &domain.CreateRecipeResponse{
+	if err := rh.recipeUsecase.UpdateRecipeCategory(context.Background(), int64(categoryId), updateRecipeCategoryDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateRecipeCategoryResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		case domain.ErrUnknownParentCategory, domain.ErrCategoryCycle:
+			c.JSON(http.StatusBadRequest, &domain.UpdateRecipeCategoryResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		case domain.ErrDuplicateCategory:
+			c.JSON(http.StatusConflict, &domain.UpdateRecipeCategoryResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateRecipeCategoryResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
-	c.JSON(http.StatusOK, &domain.CreateRecipeResponse{
-		Message: "successfully created a new recipe",
+	c.JSON(http.StatusOK, &domain.UpdateRecipeCategoryResponse{
+		Message: "successfully updated recipe category",
 		Code:    http.StatusOK,
 	})
 }
-func (rh *recipeHandler) GetRecipeById(c *gin.Context) {
-	recipeParam := c.Param("recipeId")
-	recipeId, err := strconv.Atoi(recipeParam)
-	if err != nil || recipeId <= 0 {
-		c.JSON(http.StatusBadRequest, &domain.GetRecipeByIdResponse{
+
+// DeleteRecipeCategory requires the reassign_to query, the category its recipes are moved to
+func (rh *recipeHandler) DeleteRecipeCategory(c *gin.Context) {
+	categoryId, err := strconv.Atoi(c.Param("recipeCategoryId"))
+	if err != nil || categoryId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeCategoryResponse{
 			Message: domain.ErrInvalidId.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	recipe, err := rh.recipeUsecase.GetRecipeById(context.Background(), int64(recipeId))
-	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, &domain.GetRecipeByIdResponse{
+	reassignTo, err := strconv.Atoi(c.Query("reassign_to"))
+	if err != nil || reassignTo <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeCategoryResponse{
+			Message: "reassign_to is required and must be a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.DeleteRecipeCategory(context.Background(), int64(categoryId), int64(reassignTo)); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.DeleteRecipeCategoryResponse{
 				Message: domain.ErrNotFound.Error(),
 				Code:    http.StatusNotFound,
 			})
 			return
+		case domain.ErrInvalidReassignCategory:
+			c.JSON(http.StatusBadRequest, &domain.DeleteRecipeCategoryResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
 		}
-		c.JSON(http.StatusInternalServerError, &domain.GetRecipeByIdResponse{
+		c.JSON(http.StatusInternalServerError, &domain.DeleteRecipeCategoryResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
-	c.JSON(http.StatusOK, &domain.GetRecipeByIdResponse{
-		Recipe:  recipe,
-		Message: "successfully retrieved recipe by id",
+	c.JSON(http.StatusOK, &domain.DeleteRecipeCategoryResponse{
+		Message: "successfully deleted recipe category",
 		Code:    http.StatusOK,
 	})
 }
-func (rh *recipeHandler) GetRecipes(c *gin.Context) {
-	// get queries
-	nameQuery := c.Query("name")
-	categoryIdQuery := c.Query("category_id")
-	limit := c.DefaultQuery("limit", "10")
-	offset := c.DefaultQuery("offset", "0")
-	queryFilter := &domain.GetRecipesQueryFilter{}
-	if len(nameQuery) > 0 {
-		queryFilter.Name = nameQuery
-	}
-	categoryId, _ := strconv.Atoi(categoryIdQuery)
-	if categoryId > 0 {
-		queryFilter.CategoryId = int64(categoryId)
+
+func (rh *recipeHandler) ReorderRecipeCategories(c *gin.Context) {
+	reorderRecipeCategoriesDTO := &domain.ReorderRecipeCategoriesDTO{}
+	if err := c.ShouldBindJSON(reorderRecipeCategoriesDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.ReorderRecipeCategoriesResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
 	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		limitInt = 10
+	if err := rh.recipeUsecase.ReorderRecipeCategories(context.Background(), reorderRecipeCategoriesDTO); err != nil {
+		switch err {
+		case domain.ErrBadRequest:
+			c.JSON(http.StatusBadRequest, &domain.ReorderRecipeCategoriesResponse{
+				Message: "category_ids must contain existing categories at most once",
+				Code:    http.StatusBadRequest,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.ReorderRecipeCategoriesResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
+	c.JSON(http.StatusOK, &domain.ReorderRecipeCategoriesResponse{
+		Message: "successfully reordered recipe categories",
+		Code:    http.StatusOK,
+	})
+}
+
+// Tag
+func (rh *recipeHandler) GetTags(c *gin.Context) {
+	tags, err := rh.recipeUsecase.GetTags(context.Background())
 	if err != nil {
-		offsetInt = 0
+		c.JSON(http.StatusInternalServerError, &domain.GetTagsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
 	}
-	queryFilter.Limit = limitInt
-	queryFilter.Offset = offsetInt
+	c.JSON(http.StatusOK, &domain.GetTagsResponse{
+		Tags:    tags,
+		Message: "successfully get all tags",
+		Code:    http.StatusOK,
+	})
+}
 
-	recipes, err := rh.recipeUsecase.GetRecipes(context.Background(), queryFilter)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, &domain.GetRecipesResponse{
-				Message: domain.ErrNotFound.Error(),
-				Code:    http.StatusNotFound,
+func (rh *recipeHandler) CreateTag(c *gin.Context) {
+	tagDTO := &domain.TagDTO{}
+	if err := c.ShouldBindJSON(tagDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateTagResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.CreateTag(context.Background(), tagDTO); err != nil {
+		if err == domain.ErrDuplicateTag {
+			c.JSON(http.StatusConflict, &domain.CreateTagResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, &domain.GetRecipesResponse{
+		c.JSON(http.StatusInternalServerError, &domain.CreateTagResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
-	c.JSON(http.StatusOK, &domain.GetRecipesResponse{
-		Recipes: recipes,
-		Message: "successfully retrieved recipes",
+	c.JSON(http.StatusOK, &domain.CreateTagResponse{
+		Message: "successfully created a new tag",
 		Code:    http.StatusOK,
 	})
 }
-func (rh *recipeHandler) UpdateRecipe(c *gin.Context) {
-	key, _ := c.Get("user")
-	user, ok := key.(*entity.User)
-	if !ok || user.Role != domain.ADMIN {
-		c.JSON(http.StatusForbidden, &domain.UpdateRecipeResponse{
-			Message: domain.ErrForbidenAccess.Error(),
-			Code:    http.StatusForbidden,
+
+func (rh *recipeHandler) UpdateTag(c *gin.Context) {
+	tagId, err := strconv.Atoi(c.Param("tagId"))
+	if err != nil || tagId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateTagResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	recipeParam := c.Param("recipeId")
-	recipeId, err := strconv.Atoi(recipeParam)
-	if err != nil || recipeId <= 0 {
-		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeResponse{
-			Message: domain.ErrInvalidId.Error(),
+	tagDTO := &domain.TagDTO{}
+	if err := c.ShouldBindJSON(tagDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateTagResponse{
+			Message: err.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	updateRecipeDTO := &domain.UpdateRecipeDTO{}
-	if err := c.ShouldBindJSON(updateRecipeDTO); err != nil {
-		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeResponse{
-			Message: err.Error(),
+	if err := rh.recipeUsecase.UpdateTag(context.Background(), int64(tagId), tagDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateTagResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		case domain.ErrDuplicateTag:
+			c.JSON(http.StatusConflict, &domain.UpdateTagResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateTagResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateTagResponse{
+		Message: "successfully updated tag",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) DeleteTag(c *gin.Context) {
+	tagId, err := strconv.Atoi(c.Param("tagId"))
+	if err != nil || tagId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteTagResponse{
+			Message: domain.ErrInvalidId.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	err = rh.recipeUsecase.UpdateRecipe(context.Background(), int64(recipeId), updateRecipeDTO)
-	if err != nil {
+	if err := rh.recipeUsecase.DeleteTag(context.Background(), int64(tagId)); err != nil {
 		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, &domain.UpdateRecipeResponse{
+			c.JSON(http.StatusNotFound, &domain.DeleteTagResponse{
 				Message: domain.ErrNotFound.Error(),
 				Code:    http.StatusNotFound,
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, &domain.UpdateRecipeResponse{
+		c.JSON(http.StatusInternalServerError, &domain.DeleteTagResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
-	c.JSON(http.StatusOK, &domain.UpdateRecipeResponse{
-		Message: "succesfully updated recipe",
+	c.JSON(http.StatusOK, &domain.DeleteTagResponse{
+		Message: "successfully deleted tag",
 		Code:    http.StatusOK,
 	})
 }
 
-func (rh *recipeHandler) DeleteRecipe(c *gin.Context) {
-	key, _ := c.Get("user")
-	user, ok := key.(*entity.User)
-	if !ok || user.Role != domain.ADMIN {
-		c.JSON(http.StatusForbidden, &domain.DeleteRecipeResponse{
-			Message: domain.ErrForbidenAccess.Error(),
-			Code:    http.StatusForbidden,
+// Recipe
+func (rh *recipeHandler) CreateRecipe(c *gin.Context) {
+	createRecipeDTO := &domain.CreateRecipeDTO{}
+	if err := c.ShouldBindJSON(createRecipeDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateRecipeResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.CreateRecipe(context.Background(), createRecipeDTO); err != nil {
+		if err == domain.ErrUnknownTag {
+			c.JSON(http.StatusBadRequest, &domain.CreateRecipeResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.CreateRecipeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
+	c.JSON(http.StatusOK, &domain.CreateRecipeResponse{
+		Message: "successfully created a new recipe",
+		Code:    http.StatusOK,
+	})
+}
+func (rh *recipeHandler) GetRecipeById(c *gin.Context) {
+	rh.getRecipeByIdParam(c, false)
+}
+
+// GetDraftById also finds a recipe that is not published yet
+func (rh *recipeHandler) GetDraftById(c *gin.Context) {
+	rh.getRecipeByIdParam(c, true)
+}
+func (rh *recipeHandler) getRecipeByIdParam(c *gin.Context, includeDrafts bool) {
 	recipeParam := c.Param("recipeId")
 	recipeId, err := strconv.Atoi(recipeParam)
 	if err != nil || recipeId <= 0 {
-		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeResponse{
+		c.JSON(http.StatusBadRequest, &domain.GetRecipeByIdResponse{
 			Message: domain.ErrInvalidId.Error(),
 			Code:    http.StatusBadRequest,
 		})
 		return
 	}
-	err = rh.recipeUsecase.DeleteRecipeById(context.Background(), int64(recipeId))
+	rh.getRecipe(c, int64(recipeId), includeDrafts)
+}
+
+// GetRecipeBySlug permanently redirects a former slug of a renamed recipe to its current slug, the query is kept
+func (rh *recipeHandler) GetRecipeBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	recipeId, currentSlug, err := rh.recipeUsecase.ResolveRecipeSlug(context.Background(), slug)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, &domain.DeleteRecipeResponse{
+			c.JSON(http.StatusNotFound, &domain.GetRecipeByIdResponse{
 				Message: domain.ErrNotFound.Error(),
 				Code:    http.StatusNotFound,
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, &domain.DeleteRecipeResponse{
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipeByIdResponse{
 			Message: domain.ErrInternalServerError.Error(),
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
-	c.JSON(http.StatusOK, &domain.DeleteRecipeResponse{
-		Message: "succesfully updated recipe",
+	if currentSlug != slug {
+		redirectToSlug(c, "/api/v1/recipe/slug/", currentSlug)
+		return
+	}
+	rh.getRecipe(c, recipeId, false)
+}
+
+// getRecipe responds with the recipe scaled to the servings and units queries
+func (rh *recipeHandler) getRecipe(c *gin.Context, recipeId int64, includeDrafts bool) {
+	getRecipeByIdQueryFilter := &domain.GetRecipeByIdQueryFilter{IncludeDrafts: includeDrafts}
+	if servingsQuery := c.Query("servings"); servingsQuery != "" {
+		servings, err := strconv.Atoi(servingsQuery)
+		if err != nil || servings <= 0 || servings > domain.MaxServings {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipeByIdResponse{
+				Message: fmt.Sprintf("servings must be a number between 1 and %d", domain.MaxServings),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		getRecipeByIdQueryFilter.Servings = servings
+	}
+	system, err := units.ParseSystem(c.Query("units"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipeByIdResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	getRecipeByIdQueryFilter.Units = system
+	recipe, err := rh.recipeUsecase.GetRecipeById(context.Background(), recipeId, getRecipeByIdQueryFilter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetRecipeByIdResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipeByIdResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetRecipeByIdResponse{
+		Recipe:  recipe,
+		Message: "successfully retrieved recipe by id",
+		Code:    http.StatusOK,
+	})
+}
+func (rh *recipeHandler) GetRecipes(c *gin.Context) {
+	rh.getRecipes(c, false)
+}
+
+// GetDrafts lists the recipes that are not published yet, with the same queries as GetRecipes
+func (rh *recipeHandler) GetDrafts(c *gin.Context) {
+	rh.getRecipes(c, true)
+}
+func (rh *recipeHandler) getRecipes(c *gin.Context, drafts bool) {
+	// get queries
+	searchQuery := strings.TrimSpace(c.Query("q"))
+	nameQuery := c.Query("name")
+	queryFilter := &domain.GetRecipesQueryFilter{Drafts: drafts}
+	if utf8.RuneCountInString(searchQuery) > domain.MaxSearchQueryLength {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: fmt.Sprintf("q must be at most %d characters", domain.MaxSearchQueryLength),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter.SearchQuery = searchQuery
+	if len(nameQuery) > 0 {
+		queryFilter.Name = nameQuery
+	}
+	if categoryIdQuery := c.Query("category_id"); categoryIdQuery != "" {
+		categoryId, err := strconv.Atoi(categoryIdQuery)
+		if err != nil || categoryId <= 0 {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "category_id must be a positive number",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.CategoryId = int64(categoryId)
+	}
+	// include_descendants also lists the recipes of every category below category_id
+	if includeDescendantsQuery := c.Query("include_descendants"); includeDescendantsQuery != "" {
+		includeDescendants, err := strconv.ParseBool(includeDescendantsQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "include_descendants must be true or false",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.IncludeDescendants = includeDescendants
+	}
+	limitInt, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limitInt < 1 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: "limit must be a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if limitInt > domain.MaxRecipesLimit {
+		limitInt = domain.MaxRecipesLimit
+	}
+	offsetInt, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offsetInt < 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: "offset must be zero or a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter.Limit = limitInt
+	queryFilter.Offset = offsetInt
+	sort, err := domain.ParseRecipeSort(c.Query("sort"), searchQuery != "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter.Sort = sort
+	queryFilter.Cursor = c.Query("cursor")
+	system, err := units.ParseSystem(c.Query("units"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queryFilter.Units = system
+	if maxCaloriesQuery := c.Query("max_calories"); maxCaloriesQuery != "" {
+		maxCalories, err := strconv.ParseFloat(maxCaloriesQuery, 64)
+		if err != nil || math.IsNaN(maxCalories) || maxCalories <= 0 {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "max_calories must be a positive number",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.MaxCalories = maxCalories
+	}
+	if timeQuery := c.Query("time"); timeQuery != "" {
+		if _, ok := domain.GetRecipeTimeBucket(timeQuery); !ok {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "time must be one of: under_15, 15_30, 30_60, 60_plus",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.TimeBucket = timeQuery
+	}
+	if minRatingQuery := c.Query("min_rating"); minRatingQuery != "" {
+		minRating, err := strconv.Atoi(minRatingQuery)
+		if err != nil || minRating < 1 || minRating > 5 {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "min_rating must be a number between 1 and 5",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.MinRating = minRating
+	}
+	if minTimeQuery := c.Query("min_time"); minTimeQuery != "" {
+		minTime, err := strconv.Atoi(minTimeQuery)
+		if err != nil || minTime < 0 {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "min_time must be zero or a positive number of minutes",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.MinTime = minTime
+	}
+	if maxTimeQuery := c.Query("max_time"); maxTimeQuery != "" {
+		maxTime, err := strconv.Atoi(maxTimeQuery)
+		if err != nil || maxTime <= 0 || maxTime < queryFilter.MinTime {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "max_time must be a positive number of minutes not less than min_time",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.MaxTime = maxTime
+	}
+	if createdAfterQuery := c.Query("created_after"); createdAfterQuery != "" {
+		createdAfter, err := parseRecipeDate(createdAfterQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "created_after must be a date (2006-01-02) or a RFC 3339 time (2006-01-02T15:04:05Z)",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.CreatedAfter = createdAfter
+	}
+	if createdBeforeQuery := c.Query("created_before"); createdBeforeQuery != "" {
+		createdBefore, err := parseRecipeDate(createdBeforeQuery)
+		if err != nil || !createdBefore.After(queryFilter.CreatedAfter) {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "created_before must be a date (2006-01-02) or a RFC 3339 time (2006-01-02T15:04:05Z) after created_after",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.CreatedBefore = createdBefore
+	}
+	// tag can be repeated or comma separated eg: tag=lebaran&tag=daging or tag=lebaran,daging
+	queryFilter.Tags = parseNames(strings.Join(c.QueryArray("tag"), ","))
+	if len(queryFilter.Tags) > domain.MaxRecipeTags {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: fmt.Sprintf("tag must contain at most %d tags", domain.MaxRecipeTags),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	switch tagMode := domain.TagMode(strings.ToLower(c.DefaultQuery("tag_mode", string(domain.TagModeAnd)))); tagMode {
+	case domain.TagModeAnd, domain.TagModeOr:
+		queryFilter.TagMode = tagMode
+	default:
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+			Message: "tag_mode must be one of: and, or",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if hasImageQuery := c.Query("has_image"); hasImageQuery != "" {
+		hasImage, err := strconv.ParseBool(hasImageQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "has_image must be true or false",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queryFilter.HasImage = &hasImage
+	}
+	// fuzzy tolerates typos, synonyms and affixes in q and name
+	if fuzzyQuery := c.Query("fuzzy"); fuzzyQuery != "" {
+		if queryFilter.Fuzzy, err = strconv.ParseBool(fuzzyQuery); err != nil {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "fuzzy must be true or false",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+	var withFacets bool
+	if facetsQuery := c.Query("facets"); facetsQuery != "" {
+		if withFacets, err = strconv.ParseBool(facetsQuery); err != nil {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: "facets must be true or false",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	recipePage, err := rh.recipeUsecase.GetRecipes(context.Background(), queryFilter)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.GetRecipesResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		case domain.ErrInvalidCursor, domain.ErrUnknownRecipeSort:
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipesResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	var recipeFacets *entity.RecipeFacets
+	if withFacets {
+		recipeFacets, err = rh.recipeUsecase.GetRecipeFacets(context.Background(), queryFilter)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, &domain.GetRecipesResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, &domain.GetRecipesResponse{
+		Recipes:    recipePage.Recipes,
+		Facets:     recipeFacets,
+		NextCursor: recipePage.NextCursor,
+		Total:      recipePage.Total,
+		HasMore:    recipePage.HasMore,
+		Message:    "successfully retrieved recipes",
+		Code:       http.StatusOK,
+	})
+}
+func (rh *recipeHandler) GetRecipesByIngredients(c *gin.Context) {
+	have := parseNames(c.Query("have"))
+	exclude := parseNames(c.Query("exclude"))
+	if len(have) == 0 || len(have) > domain.MaxIngredientNames || len(exclude) > domain.MaxIngredientNames {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesByIngredientsResponse{
+			Message: fmt.Sprintf("have must contain 1 to %d comma separated ingredients and exclude at most %d", domain.MaxIngredientNames, domain.MaxIngredientNames),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesByIngredientsResponse{
+			Message: "limit must be a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if limit > domain.MaxRecipesLimit {
+		limit = domain.MaxRecipesLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesByIngredientsResponse{
+			Message: "offset must be zero or a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	system, err := units.ParseSystem(c.Query("units"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipesByIngredientsResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	recipeMatches, err := rh.recipeUsecase.GetRecipesByIngredients(context.Background(), &domain.GetRecipesByIngredientsQueryFilter{
+		Have:    have,
+		Exclude: exclude,
+		Units:   system,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		if err == domain.ErrBadRequest {
+			c.JSON(http.StatusBadRequest, &domain.GetRecipesByIngredientsResponse{
+				Message: domain.ErrBadRequest.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipesByIngredientsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetRecipesByIngredientsResponse{
+		RecipeMatches: recipeMatches,
+		Message:       "successfully retrieved recipes by ingredients",
+		Code:          http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) GetSuggestions(c *gin.Context) {
+	query := strings.Join(strings.Fields(c.Query("q")), " ")
+	queryLength := utf8.RuneCountInString(query)
+	if queryLength < domain.MinSuggestQueryLength || queryLength > domain.MaxSuggestQueryLength {
+		c.JSON(http.StatusBadRequest, &domain.GetSuggestionsResponse{
+			Message: fmt.Sprintf("q must be %d to %d characters", domain.MinSuggestQueryLength, domain.MaxSuggestQueryLength),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, &domain.GetSuggestionsResponse{
+			Message: "limit must be a positive number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if limit > domain.MaxSuggestionsPerType {
+		limit = domain.MaxSuggestionsPerType
+	}
+
+	suggestions, err := rh.recipeUsecase.GetSuggestions(context.Background(), query, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &domain.GetSuggestionsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetSuggestionsResponse{
+		Suggestions: suggestions,
+		Message:     "successfully retrieved suggestions",
+		Code:        http.StatusOK,
+	})
+}
+
+// parseRecipeDate parses a date as midnight UTC or a RFC 3339 time
+func parseRecipeDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// redirectToSlug responds with a permanent redirect to the current slug, keeping the query
+func redirectToSlug(c *gin.Context, path string, slug string) {
+	location := path + slug
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(http.StatusMovedPermanently, location)
+}
+
+// parseNames splits a comma separated query eg: "telur, Bawang,nasi" into unique lowercased names
+func parseNames(query string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(query, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func (rh *recipeHandler) UpdateRecipe(c *gin.Context) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	updateRecipeDTO := &domain.UpdateRecipeDTO{}
+	if err := c.ShouldBindJSON(updateRecipeDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	err = rh.recipeUsecase.UpdateRecipe(context.Background(), int64(recipeId), updateRecipeDTO)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateRecipeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		case domain.ErrUnknownTag:
+			c.JSON(http.StatusBadRequest, &domain.UpdateRecipeResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateRecipeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateRecipeResponse{
+		Message: "succesfully updated recipe",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) DeleteRecipe(c *gin.Context) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	err = rh.recipeUsecase.DeleteRecipeById(context.Background(), int64(recipeId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteRecipeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteRecipeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteRecipeResponse{
+		Message: "succesfully updated recipe",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) PublishRecipe(c *gin.Context) {
+	rh.publishRecipe(c, true)
+}
+func (rh *recipeHandler) UnpublishRecipe(c *gin.Context) {
+	rh.publishRecipe(c, false)
+}
+func (rh *recipeHandler) publishRecipe(c *gin.Context, published bool) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.PublishRecipeResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.PublishRecipe(context.Background(), int64(recipeId), published); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.PublishRecipeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.PublishRecipeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	message := "successfully published recipe"
+	if !published {
+		message = "successfully unpublished recipe"
+	}
+	c.JSON(http.StatusOK, &domain.PublishRecipeResponse{
+		Message: message,
+		Code:    http.StatusOK,
+	})
+}
+
+// Recipe Step
+func (rh *recipeHandler) GetRecipeSteps(c *gin.Context) {
+	rh.getRecipeSteps(c, false)
+}
+
+// GetDraftSteps also lists the steps of a recipe that is not published yet
+func (rh *recipeHandler) GetDraftSteps(c *gin.Context) {
+	rh.getRecipeSteps(c, true)
+}
+func (rh *recipeHandler) getRecipeSteps(c *gin.Context, includeDrafts bool) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipeStepsResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	recipeSteps, err := rh.recipeUsecase.GetRecipeSteps(context.Background(), int64(recipeId), includeDrafts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetRecipeStepsResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipeStepsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetRecipeStepsResponse{
+		RecipeSteps: recipeSteps,
+		Message:     "successfully retrieved recipe steps",
+		Code:        http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) CreateRecipeStep(c *gin.Context) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.CreateRecipeStepResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	createRecipeStepDTO := &domain.CreateRecipeStepDTO{}
+	if err := c.ShouldBindJSON(createRecipeStepDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateRecipeStepResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.CreateRecipeStep(context.Background(), int64(recipeId), createRecipeStepDTO); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.CreateRecipeStepResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.CreateRecipeStepResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.CreateRecipeStepResponse{
+		Message: "successfully created recipe step",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) ReorderRecipeSteps(c *gin.Context) {
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.ReorderRecipeStepsResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	reorderRecipeStepsDTO := &domain.ReorderRecipeStepsDTO{}
+	if err := c.ShouldBindJSON(reorderRecipeStepsDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.ReorderRecipeStepsResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.ReorderRecipeSteps(context.Background(), int64(recipeId), reorderRecipeStepsDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.ReorderRecipeStepsResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		case domain.ErrBadRequest:
+			c.JSON(http.StatusBadRequest, &domain.ReorderRecipeStepsResponse{
+				Message: "recipe_step_ids must contain every step of the recipe exactly once",
+				Code:    http.StatusBadRequest,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.ReorderRecipeStepsResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ReorderRecipeStepsResponse{
+		Message: "successfully reordered recipe steps",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) DeleteRecipeStep(c *gin.Context) {
+	recipeId, err := strconv.Atoi(c.Param("recipeId"))
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeStepResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	recipeStepId, err := strconv.Atoi(c.Param("recipeStepId"))
+	if err != nil || recipeStepId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeStepResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.DeleteRecipeStep(context.Background(), int64(recipeId), int64(recipeStepId)); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteRecipeStepResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteRecipeStepResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteRecipeStepResponse{
+		Message: "successfully deleted recipe step",
+		Code:    http.StatusOK,
+	})
+}
+
+// Recipe Rating
+func (rh *recipeHandler) CreateRecipeRating(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.CreateRecipeRatingResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.CreateRecipeRatingResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	createRecipeRatingDTO := &domain.CreateRecipeRatingDTO{}
+	if err := c.ShouldBindJSON(createRecipeRatingDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateRecipeRatingResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.CreateRecipeRating(context.Background(), int64(recipeId), user.UserId, createRecipeRatingDTO); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.CreateRecipeRatingResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.CreateRecipeRatingResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.CreateRecipeRatingResponse{
+		Message: "successfully rated recipe",
+		Code:    http.StatusOK,
+	})
+}
+func (rh *recipeHandler) UpdateRecipeRating(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdateRecipeRatingResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeRatingResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	updateRecipeRatingDTO := &domain.UpdateRecipeRatingDTO{}
+	if err := c.ShouldBindJSON(updateRecipeRatingDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateRecipeRatingResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.UpdateRecipeRating(context.Background(), int64(recipeId), user.UserId, updateRecipeRatingDTO); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.UpdateRecipeRatingResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateRecipeRatingResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateRecipeRatingResponse{
+		Message: "successfully updated recipe rating",
+		Code:    http.StatusOK,
+	})
+}
+func (rh *recipeHandler) DeleteRecipeRating(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.DeleteRecipeRatingResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteRecipeRatingResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.DeleteRecipeRating(context.Background(), int64(recipeId), user.UserId); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteRecipeRatingResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteRecipeRatingResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteRecipeRatingResponse{
+		Message: "successfully deleted recipe rating",
+		Code:    http.StatusOK,
+	})
+}
+func (rh *recipeHandler) GetRecipeRatingSummary(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.GetRecipeRatingSummaryResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	recipeParam := c.Param("recipeId")
+	recipeId, err := strconv.Atoi(recipeParam)
+	if err != nil || recipeId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.GetRecipeRatingSummaryResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	recipeRatingSummary, err := rh.recipeUsecase.GetRecipeRatingSummary(context.Background(), int64(recipeId), user.UserId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetRecipeRatingSummaryResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetRecipeRatingSummaryResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetRecipeRatingSummaryResponse{
+		RecipeRatingSummary: recipeRatingSummary,
+		Message:             "successfully retrieved recipe rating summary",
+		Code:                http.StatusOK,
+	})
+}
+
+// Search Synonym
+func (rh *recipeHandler) GetSearchSynonyms(c *gin.Context) {
+	searchSynonyms, err := rh.recipeUsecase.GetSearchSynonyms(context.Background())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &domain.GetSearchSynonymsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetSearchSynonymsResponse{
+		SearchSynonyms: searchSynonyms,
+		Message:        "successfully get all search synonyms",
+		Code:           http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) CreateSearchSynonym(c *gin.Context) {
+	searchSynonymDTO := &domain.SearchSynonymDTO{}
+	if err := c.ShouldBindJSON(searchSynonymDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateSearchSynonymResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.CreateSearchSynonym(context.Background(), searchSynonymDTO); err != nil {
+		switch err {
+		case domain.ErrBadRequest:
+			c.JSON(http.StatusBadRequest, &domain.CreateSearchSynonymResponse{
+				Message: fmt.Sprintf("term and replacement must be different words, term at most %d words", search.MaxSynonymWords),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		case domain.ErrDuplicateSearchSynonym:
+			c.JSON(http.StatusConflict, &domain.CreateSearchSynonymResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.CreateSearchSynonymResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.CreateSearchSynonymResponse{
+		Message: "successfully created a new search synonym",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) UpdateSearchSynonym(c *gin.Context) {
+	searchSynonymId, err := strconv.Atoi(c.Param("searchSynonymId"))
+	if err != nil || searchSynonymId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateSearchSynonymResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	searchSynonymDTO := &domain.SearchSynonymDTO{}
+	if err := c.ShouldBindJSON(searchSynonymDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateSearchSynonymResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.UpdateSearchSynonym(context.Background(), int64(searchSynonymId), searchSynonymDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateSearchSynonymResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		case domain.ErrBadRequest:
+			c.JSON(http.StatusBadRequest, &domain.UpdateSearchSynonymResponse{
+				Message: fmt.Sprintf("term and replacement must be different words, term at most %d words", search.MaxSynonymWords),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		case domain.ErrDuplicateSearchSynonym:
+			c.JSON(http.StatusConflict, &domain.UpdateSearchSynonymResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateSearchSynonymResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateSearchSynonymResponse{
+		Message: "successfully updated search synonym",
+		Code:    http.StatusOK,
+	})
+}
+
+func (rh *recipeHandler) DeleteSearchSynonym(c *gin.Context) {
+	searchSynonymId, err := strconv.Atoi(c.Param("searchSynonymId"))
+	if err != nil || searchSynonymId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteSearchSynonymResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := rh.recipeUsecase.DeleteSearchSynonym(context.Background(), int64(searchSynonymId)); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteSearchSynonymResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteSearchSynonymResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteSearchSynonymResponse{
+		Message: "successfully deleted search synonym",
 		Code:    http.StatusOK,
 	})
 }
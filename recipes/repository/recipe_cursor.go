@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victorsantoso/endeus/domain"
+)
+
+// recipeSortOrder orders recipes by a sort value then recipe_id, both in the same direction so a page can continue
+// after the (sort value, recipe_id) of the previous page's last recipe
+type recipeSortOrder struct {
+	expr     string // sort value expression, also selected as sort_value
+	castType string // postgres type the cursor value is cast back to
+	desc     bool
+}
+
+var recipeSortOrders = map[domain.RecipeSort]recipeSortOrder{
+	domain.SortNewest:    {expr: "created_at", castType: "timestamptz", desc: true},
+	domain.SortOldest:    {expr: "created_at", castType: "timestamptz"},
+	domain.SortTitle:     {expr: "lower(title)", castType: "text"},
+	domain.SortQuickest:  {expr: "estimated_time_minutes", castType: "integer"},
+	domain.SortTopRated:  {expr: "COALESCE((SELECT avg(recipe_rating) FROM recipe_ratings WHERE recipe_ratings.recipe_id = recipes.recipe_id), 0)", castType: "numeric", desc: true},
+	domain.SortRelevance: {expr: "ts_rank(search_vector, to_tsquery('simple', $1))", castType: "real", desc: true},
+}
+
+// fuzzyRelevanceSortOrder also ranks the recipes found by the title similarity to the fuzzy search phrase ($2)
+var fuzzyRelevanceSortOrder = recipeSortOrder{
+	expr:     "ts_rank(search_vector, to_tsquery('simple', $1)) + word_similarity($2, lower(title))",
+	castType: "real",
+	desc:     true,
+}
+
+func (so recipeSortOrder) orderBy() string {
+	if so.desc {
+		return " ORDER BY sort_value DESC, recipe_id DESC"
+	}
+	return " ORDER BY sort_value, recipe_id"
+}
+
+// after is the condition of the recipes after the cursor, valueParam and recipeIdParam are the positional parameters of the cursor
+func (so recipeSortOrder) after(valueParam, recipeIdParam int) string {
+	operator := ">"
+	if so.desc {
+		operator = "<"
+	}
+	return fmt.Sprintf("(%s, recipe_id) %s ($%d::%s, $%d)", so.expr, operator, valueParam, so.castType, recipeIdParam)
+}
+
+// decimalPattern matches the decimal numbers postgres casts to numeric and real, eg: "4.5", "1e-05"
+var decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// validValue reports whether postgres can cast value to castType, a value of a tampered or stale cursor would fail the query otherwise.
+// Cursor values are created from the sort_value scanned into a string, eg: a timestamptz is formatted as RFC 3339
+func (so recipeSortOrder) validValue(value string) bool {
+	switch so.castType {
+	case "timestamptz":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "integer":
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	case "numeric":
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil && decimalPattern.MatchString(value)
+	case "real":
+		_, err := strconv.ParseFloat(value, 32)
+		return err == nil && decimalPattern.MatchString(value)
+	default:
+		// postgres text cannot contain NUL characters
+		return !strings.ContainsRune(value, 0)
+	}
+}
+
+// recipeCursor is the position after the last recipe of a page, it's only valid for the sort it was created with
+type recipeCursor struct {
+	Sort     domain.RecipeSort `json:"s"`
+	Value    string            `json:"v"`
+	RecipeId int64             `json:"id"`
+}
+
+func encodeRecipeCursor(cursor *recipeCursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeRecipeCursor(s string) (*recipeCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var cursor recipeCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, err
+	}
+	sortOrder, ok := recipeSortOrders[cursor.Sort]
+	if cursor.RecipeId <= 0 || !ok || !sortOrder.validValue(cursor.Value) {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
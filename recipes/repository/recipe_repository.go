@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"unicode"
 
+	"github.com/lib/pq"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/entity"
 )
@@ -25,244 +27,2031 @@ const (
 
 	// Recipe Categories
 	CreateRecipeCategoryQuery = `
-		INSERT INTO recipe_categories(category_tag)
-		VALUES($1);
+		INSERT INTO recipe_categories(category_tag, parent_category_id, description, cover_image, display_order, is_featured, slug)
+		VALUES($1, NULLIF($2, 0), $3, $4, $5, $6, $7)
+		RETURNING category_id;
 	`
+	// every category query selects the columns scanned by scanRecipeCategory, recipe_count only counts the published recipes directly in the category
 	GetRecipeCategoryByIdQuery = `
-		SELECT category_id, category_tag
-		FROM recipe_categories
-		WHERE category_id = $1
+		SELECT c.category_id, c.category_tag, c.slug, COALESCE(c.parent_category_id, 0), c.description, c.cover_image, c.display_order, c.is_featured,
+			(SELECT count(*) FROM recipes r WHERE r.category_id = c.category_id AND r.published_at IS NOT NULL) AS recipe_count
+		FROM recipe_categories c
+		WHERE c.category_id = $1
 		LIMIT 1
 	`
 	GetRecipeCategoriesQuery = `
-		SELECT category_id, category_tag
+		SELECT c.category_id, c.category_tag, c.slug, COALESCE(c.parent_category_id, 0), c.description, c.cover_image, c.display_order, c.is_featured,
+			(SELECT count(*) FROM recipes r WHERE r.category_id = c.category_id AND r.published_at IS NOT NULL) AS recipe_count
+		FROM recipe_categories c
+		ORDER BY c.display_order, lower(c.category_tag), c.category_id
+	`
+	GetRecipeCategoryChildrenQuery = `
+		SELECT c.category_id, c.category_tag, c.slug, COALESCE(c.parent_category_id, 0), c.description, c.cover_image, c.display_order, c.is_featured,
+			(SELECT count(*) FROM recipes r WHERE r.category_id = c.category_id AND r.published_at IS NOT NULL) AS recipe_count
+		FROM recipe_categories c
+		WHERE c.parent_category_id = $1
+		ORDER BY c.display_order, lower(c.category_tag), c.category_id
+	`
+	// GetRecipeCategoryBreadcrumbsQuery walks up from the category to its root, depth stops a cycle from looping forever
+	GetRecipeCategoryBreadcrumbsQuery = `
+		WITH RECURSIVE ancestors AS (
+			SELECT category_id, parent_category_id, 0 AS depth
+			FROM recipe_categories
+			WHERE category_id = $1
+			UNION ALL
+			SELECT c.category_id, c.parent_category_id, a.depth + 1
+			FROM recipe_categories c
+			JOIN ancestors a ON c.category_id = a.parent_category_id
+			WHERE a.depth < 32
+		)
+		SELECT c.category_id, c.category_tag, c.slug, COALESCE(c.parent_category_id, 0), c.description, c.cover_image, c.display_order, c.is_featured,
+			(SELECT count(*) FROM recipes r WHERE r.category_id = c.category_id AND r.published_at IS NOT NULL) AS recipe_count
+		FROM ancestors a
+		JOIN recipe_categories c ON c.category_id = a.category_id
+		ORDER BY a.depth DESC
+	`
+	// LockRecipeCategoriesQuery serializes the category writes that move categories around so two of them cannot build a cycle,
+	// reading categories and referencing them from recipes is not blocked
+	LockRecipeCategoriesQuery = `
+		LOCK TABLE recipe_categories IN SHARE ROW EXCLUSIVE MODE;
+	`
+	// IsRecipeCategoryAncestorQuery tells whether the category $2 is $1 itself or one of its ancestors
+	IsRecipeCategoryAncestorQuery = `
+		WITH RECURSIVE ancestors AS (
+			SELECT category_id, parent_category_id, 0 AS depth
+			FROM recipe_categories
+			WHERE category_id = $1
+			UNION ALL
+			SELECT c.category_id, c.parent_category_id, a.depth + 1
+			FROM recipe_categories c
+			JOIN ancestors a ON c.category_id = a.parent_category_id
+			WHERE a.depth < 32
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE category_id = $2)
+	`
+	UpdateRecipeCategoryQuery = `
+		UPDATE recipe_categories
+		SET category_tag = $2, parent_category_id = NULLIF($3, 0), description = $4, cover_image = $5, display_order = $6, is_featured = $7, slug = $8
+		WHERE category_id = $1;
+	`
+	// ReorderRecipeCategoriesQuery sets the display order of the categories $1 to their position in the array
+	ReorderRecipeCategoriesQuery = `
+		UPDATE recipe_categories c
+		SET display_order = o.display_order
+		FROM unnest($1::integer[]) WITH ORDINALITY AS o(category_id, display_order)
+		WHERE c.category_id = o.category_id;
+	`
+	GetRecipeCategoryParentIdQuery = `
+		SELECT COALESCE(parent_category_id, 0)
 		FROM recipe_categories
+		WHERE category_id = $1
+	`
+	RecipeCategoryExistsQuery = `
+		SELECT EXISTS(SELECT 1 FROM recipe_categories WHERE category_id = $1)
+	`
+	// MoveRecipeCategoryChildrenQuery hands the children of the category $1 over to its parent $2, 0 makes them root categories
+	MoveRecipeCategoryChildrenQuery = `
+		UPDATE recipe_categories
+		SET parent_category_id = NULLIF($2, 0)
+		WHERE parent_category_id = $1;
+	`
+	ReassignRecipesCategoryQuery = `
+		UPDATE recipes
+		SET category_id = $2, updated_at = now()::timestamptz
+		WHERE category_id = $1;
+	`
+	DeleteRecipeCategoryQuery = `
+		DELETE FROM recipe_categories
+		WHERE category_id = $1;
+	`
+	// Tags, tag names are unique regardless of their case
+	GetTagsQuery = `
+		SELECT tag_id, tag_name
+		FROM tags
+		ORDER BY lower(tag_name)
+	`
+	CreateTagQuery = `
+		INSERT INTO tags(tag_name)
+		VALUES($1)
+		RETURNING tag_id;
+	`
+	UpdateTagQuery = `
+		UPDATE tags
+		SET tag_name = $2
+		WHERE tag_id = $1;
+	`
+	DeleteTagQuery = `
+		DELETE FROM tags
+		WHERE tag_id = $1;
 	`
 	// Recipes
 	CreateRecipeQuery = `
-		INSERT INTO recipes(category_id, title, header, image_preview, description, estimated_time_minutes, recipe_ingredients, created_at, updated_at)
-		VALUES($1, $2, $3, $4, $5, $6, $7, now()::timestamptz, now()::timestamptz);
+		INSERT INTO recipes(category_id, title, header, image_preview, description, estimated_time_minutes, servings, slug, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, now()::timestamptz, now()::timestamptz)
+		RETURNING recipe_id, created_at, updated_at;
 	`
 	GetRecipeByIdQuery = `
-		SELECT recipe_id, category_id, title, header, image_preview, description, estimated_time_minutes, recipe_ingredients, created_at, updated_at
+		SELECT recipe_id, category_id, title, slug, header, image_preview, description, estimated_time_minutes, servings, created_at, updated_at, published_at
 		FROM recipes
 		WHERE recipe_id = $1
 	`
-	GetRecipesQuery = `
-		SELECT recipe_id, category_id, title, header, image_preview, description, estimated_time_minutes, recipe_ingredients, created_at, updated_at
+	// GetRecipesQueryFormat selects the sort value (%s) of the requested sort along with the recipe
+	GetRecipesQueryFormat = `
+		SELECT recipe_id, category_id, title, slug, header, image_preview, description, estimated_time_minutes, servings, created_at, updated_at, published_at,
+			%s AS sort_value
+		FROM recipes
+	`
+	// $1 is reserved for the search tsquery, recipeConditions adds the matching condition
+	SearchRecipesQueryFormat = `
+		SELECT recipe_id, category_id, title, slug, header, image_preview, description, estimated_time_minutes, servings, created_at, updated_at, published_at,
+			ts_rank(search_vector, to_tsquery('simple', $1)) AS search_rank, %s AS sort_value
+		FROM recipes
+	`
+	// SearchRecipesHighlightQueryFormat wraps the paginated SearchRecipesQueryFormat (%s) so only the returned page is highlighted
+	SearchRecipesHighlightQueryFormat = `
+		SELECT recipe_id, category_id, title, slug, header, image_preview, description, estimated_time_minutes, servings, created_at, updated_at, published_at, search_rank, sort_value,
+			ts_headline('simple', concat_ws(' ', header, description), to_tsquery('simple', $1), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM (%s) searched_recipes
+	`
+	// RecipeCategoryDescendantsConditionFormat matches the recipes of the category (%s) and every category below it
+	RecipeCategoryDescendantsConditionFormat = `category_id IN (
+		WITH RECURSIVE descendants AS (
+			SELECT category_id, 0 AS depth FROM recipe_categories WHERE category_id = %s
+			UNION ALL
+			SELECT c.category_id, d.depth + 1 FROM recipe_categories c JOIN descendants d ON c.parent_category_id = d.category_id WHERE d.depth < 32
+		)
+		SELECT category_id FROM descendants
+	)`
+	CountRecipesQuery = `
+		SELECT count(*)
 		FROM recipes
 	`
+	// Recipe Facets, %s is replaced with the WHERE clause of the current filter
+	GetRecipeCategoryFacetsQueryFormat = `
+		SELECT c.category_id, c.category_tag, count(*)
+		FROM (SELECT category_id FROM recipes %s) filtered_recipes
+		JOIN recipe_categories c ON c.category_id = filtered_recipes.category_id
+		GROUP BY c.category_id, c.category_tag
+		ORDER BY count(*) DESC, c.category_id
+	`
+	GetRecipeTimeFacetsQueryFormat = `
+		SELECT estimated_time_minutes, count(*)
+		FROM recipes
+		%s
+		GROUP BY estimated_time_minutes
+	`
+	// GetRecipeRatingFacetsQueryFormat counts rated recipes per average rating rounded down, unrated recipes are not counted
+	GetRecipeRatingFacetsQueryFormat = `
+		SELECT floor(average_rating)::int, count(*)
+		FROM (
+			SELECT avg(recipe_rating) AS average_rating
+			FROM recipe_ratings
+			WHERE recipe_id IN (SELECT recipe_id FROM recipes %s)
+			GROUP BY recipe_id
+		) recipe_average_ratings
+		GROUP BY 1
+	`
+	// Suggestions of published recipes, $1 is the lowercased query, $2 and $3 match it as the prefix of the text and of any word of the text,
+	// $1 <% also matches misspellings using the word_similarity threshold of pg_trgm. $4 limits each suggestion type
+	GetSuggestionsQuery = `
+		SELECT suggestion_type, id, text, recipe_count, score
+		FROM (
+			(
+				SELECT 1 AS type_order, 'recipe' AS suggestion_type, recipe_id AS id, title AS text, 0 AS recipe_count,
+					lower(title) LIKE $2 AS is_prefix, word_similarity($1, lower(title)) AS score
+				FROM recipes
+				WHERE published_at IS NOT NULL AND (lower(title) LIKE $2 OR lower(title) LIKE $3 OR $1 <% lower(title))
+				ORDER BY is_prefix DESC, score DESC, text
+				LIMIT $4
+			)
+			UNION ALL
+			(
+				SELECT 2 AS type_order, 'category' AS suggestion_type, c.category_id AS id, c.category_tag AS text,
+					(SELECT count(*) FROM recipes WHERE recipes.category_id = c.category_id AND recipes.published_at IS NOT NULL) AS recipe_count,
+					lower(c.category_tag) LIKE $2 AS is_prefix, word_similarity($1, lower(c.category_tag)) AS score
+				FROM recipe_categories c
+				WHERE lower(c.category_tag) LIKE $2 OR lower(c.category_tag) LIKE $3 OR $1 <% lower(c.category_tag)
+				ORDER BY is_prefix DESC, score DESC, text
+				LIMIT $4
+			)
+			UNION ALL
+			(
+				SELECT 3 AS type_order, 'ingredient' AS suggestion_type, 0 AS id, min(name) AS text, count(DISTINCT recipe_id) AS recipe_count,
+					lower(name) LIKE $2 AS is_prefix, word_similarity($1, lower(name)) AS score
+				FROM recipe_ingredients
+				WHERE recipe_id IN (SELECT recipe_id FROM recipes WHERE published_at IS NOT NULL)
+					AND (lower(name) LIKE $2 OR lower(name) LIKE $3 OR $1 <% lower(name))
+				GROUP BY lower(name)
+				ORDER BY is_prefix DESC, score DESC, recipe_count DESC
+				LIMIT $4
+			)
+		) suggestions
+		ORDER BY type_order, is_prefix DESC, score DESC, recipe_count DESC, text
+	`
+	// GetRecipesByIngredientsQuery ranks published recipes using any of the available ingredient patterns ($1) by the most matched
+	// and the least missing ingredients, recipes with an ingredient matching the excluded patterns ($2) are left out
+	GetRecipesByIngredientsQuery = `
+		SELECT r.recipe_id, r.category_id, r.title, r.slug, r.header, r.image_preview, r.description, r.estimated_time_minutes, r.servings, r.created_at, r.updated_at, r.published_at,
+			m.matched_ingredients, m.missing_ingredients, m.matched_count, m.missing_count
+		FROM (
+			SELECT recipe_id,
+				array_agg(name ORDER BY position) FILTER (WHERE is_available) AS matched_ingredients,
+				array_agg(name ORDER BY position) FILTER (WHERE NOT is_available) AS missing_ingredients,
+				count(*) FILTER (WHERE is_available) AS matched_count,
+				count(*) FILTER (WHERE NOT is_available) AS missing_count
+			FROM (
+				SELECT recipe_id, position, name, lower(name) LIKE ANY($1) AS is_available
+				FROM recipe_ingredients
+			) ingredients
+			GROUP BY recipe_id
+		) m
+		JOIN recipes r ON r.recipe_id = m.recipe_id
+		WHERE m.matched_count > 0
+			AND r.published_at IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM recipe_ingredients excluded
+				WHERE excluded.recipe_id = r.recipe_id AND lower(excluded.name) LIKE ANY($2)
+			)
+		ORDER BY m.matched_count DESC, m.missing_count ASC, r.recipe_id DESC
+		LIMIT $3 OFFSET $4
+	`
 	UpdateRecipeByIdQuery = `
 		UPDATE recipes
 		SET
 			updated_at = now()::timestamptz
 	`
+	// UpdateRecipePublishedQuery publishes ($2 true) or unpublishes the recipe, publishing again keeps the first published_at
+	UpdateRecipePublishedQuery = `
+		UPDATE recipes
+		SET published_at = CASE WHEN $2 THEN COALESCE(published_at, now()::timestamptz) END
+		WHERE recipe_id = $1
+	`
 	DeleteRecipeByIdQuery = `
 		DELETE FROM recipes
 		WHERE recipe_id = $1
 	`
+	// Recipe Ingredients
+	// ingredients are linked to their nutrition by name or alias, an exact name wins over an alias
+	CreateRecipeIngredientQuery = `
+		INSERT INTO recipe_ingredients(recipe_id, position, name, quantity, unit, note, ingredient_group, nutrition_id)
+		VALUES($1, $2, $3, NULLIF($4, 0), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), (
+			SELECT nutrition_id
+			FROM ingredient_nutritions
+			WHERE name = LOWER($3) OR LOWER($3) = ANY(aliases)
+			ORDER BY name = LOWER($3) DESC
+			LIMIT 1
+		));
+	`
+	GetRecipeIngredientsByRecipeIdsQuery = `
+		SELECT recipe_ingredient_id, recipe_id, position, name, COALESCE(quantity, 0), COALESCE(unit, ''), COALESCE(note, ''), COALESCE(ingredient_group, '')
+		FROM recipe_ingredients
+		WHERE recipe_id = ANY($1)
+		ORDER BY recipe_id, position
+	`
+	DeleteRecipeIngredientsByRecipeIdQuery = `
+		DELETE FROM recipe_ingredients
+		WHERE recipe_id = $1
+	`
+	// Recipe Tags
+	CreateRecipeTagsQuery = `
+		INSERT INTO recipe_tags(recipe_id, tag_id)
+		SELECT $1, unnest($2::integer[])
+		ON CONFLICT DO NOTHING;
+	`
+	GetRecipeTagsByRecipeIdsQuery = `
+		SELECT rt.recipe_id, t.tag_id, t.tag_name
+		FROM recipe_tags rt
+		JOIN tags t ON t.tag_id = rt.tag_id
+		WHERE rt.recipe_id = ANY($1)
+		ORDER BY rt.recipe_id, lower(t.tag_name)
+	`
+	DeleteRecipeTagsByRecipeIdQuery = `
+		DELETE FROM recipe_tags
+		WHERE recipe_id = $1
+	`
+	// Recipe Steps, step numbers are only unique at the end of a transaction so steps can be shifted and reordered
+	LockRecipeByIdQuery = `
+		SELECT recipe_id
+		FROM recipes
+		WHERE recipe_id = $1
+		FOR UPDATE
+	`
+	CreateRecipeStepQuery = `
+		INSERT INTO recipe_steps(recipe_id, step_number, instruction, duration_seconds, step_image)
+		VALUES($1, $2, $3, NULLIF($4, 0), NULLIF($5, ''))
+		RETURNING recipe_step_id;
+	`
+	GetRecipeStepsByRecipeIdQuery = `
+		SELECT recipe_step_id, step_number, instruction, COALESCE(duration_seconds, 0), COALESCE(step_image, '')
+		FROM recipe_steps
+		WHERE recipe_id = $1
+		ORDER BY step_number
+	`
+	ShiftRecipeStepsQuery = `
+		UPDATE recipe_steps
+		SET step_number = step_number + $3
+		WHERE recipe_id = $1 AND step_number >= $2;
+	`
+	UpdateRecipeStepNumberQuery = `
+		UPDATE recipe_steps
+		SET step_number = $3
+		WHERE recipe_id = $1 AND recipe_step_id = $2;
+	`
+	DeleteRecipeStepQuery = `
+		DELETE FROM recipe_steps
+		WHERE recipe_id = $1 AND recipe_step_id = $2
+		RETURNING step_number;
+	`
+	DeleteRecipeStepsByRecipeIdQuery = `
+		DELETE FROM recipe_steps
+		WHERE recipe_id = $1
+	`
+	// Recipe Nutritions, nutrition values are per 100 g of an ingredient and cached per serving of a recipe
+	GetRecipeIdsQuery = `
+		SELECT recipe_id
+		FROM recipes
+		ORDER BY recipe_id
+	`
+	UpsertIngredientNutritionQuery = `
+		INSERT INTO ingredient_nutritions(name, aliases, calories, protein, fat, carbohydrate, sodium, grams_per_ml, grams_per_unit)
+		VALUES(LOWER($1), $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0))
+		ON CONFLICT (name)
+		DO UPDATE SET aliases = EXCLUDED.aliases, calories = EXCLUDED.calories, protein = EXCLUDED.protein, fat = EXCLUDED.fat,
+			carbohydrate = EXCLUDED.carbohydrate, sodium = EXCLUDED.sodium, grams_per_ml = EXCLUDED.grams_per_ml, grams_per_unit = EXCLUDED.grams_per_unit;
+	`
+	// links ingredients which were created before their nutrition was imported
+	LinkRecipeIngredientNutritionsQuery = `
+		UPDATE recipe_ingredients ri
+		SET nutrition_id = n.nutrition_id
+		FROM ingredient_nutritions n
+		WHERE ri.nutrition_id IS NULL AND (n.name = LOWER(ri.name) OR LOWER(ri.name) = ANY(n.aliases));
+	`
+	GetIngredientNutritionsByRecipeIdQuery = `
+		SELECT ri.recipe_ingredient_id, n.nutrition_id, n.name, n.calories, n.protein, n.fat, n.carbohydrate, n.sodium, n.grams_per_ml, COALESCE(n.grams_per_unit, 0)
+		FROM recipe_ingredients ri
+		JOIN ingredient_nutritions n ON n.nutrition_id = ri.nutrition_id
+		WHERE ri.recipe_id = $1
+	`
+	UpsertRecipeNutritionQuery = `
+		INSERT INTO recipe_nutritions(recipe_id, calories, protein, fat, carbohydrate, sodium, is_complete, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, now()::timestamptz)
+		ON CONFLICT (recipe_id)
+		DO UPDATE SET calories = EXCLUDED.calories, protein = EXCLUDED.protein, fat = EXCLUDED.fat, carbohydrate = EXCLUDED.carbohydrate,
+			sodium = EXCLUDED.sodium, is_complete = EXCLUDED.is_complete, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at;
+	`
+	GetRecipeNutritionByRecipeIdQuery = `
+		SELECT calories, protein, fat, carbohydrate, sodium, is_complete, updated_at
+		FROM recipe_nutritions
+		WHERE recipe_id = $1
+	`
 	// Recipe Ratings
+	// rating is an upsert, one user only has one rating per recipe and drafts cannot be rated
 	CreateRecipeRatingQuery = `
 		INSERT INTO recipe_ratings(recipe_id, user_id, recipe_rating, created_at, updated_at)
-		VALUES($1, $2, $3, now()::timestamptz, now()::timestamptz);
+		SELECT recipe_id, $2::integer, $3::integer, now()::timestamptz, now()::timestamptz
+		FROM recipes
+		WHERE recipe_id = $1 AND published_at IS NOT NULL
+		ON CONFLICT (recipe_id, user_id)
+		DO UPDATE SET recipe_rating = EXCLUDED.recipe_rating, updated_at = now()::timestamptz;
+	`
+	UpdateRecipeRatingQuery = `
+		UPDATE recipe_ratings
+		SET recipe_rating = $3, updated_at = now()::timestamptz
+		WHERE recipe_id = $1 AND user_id = $2;
+	`
+	DeleteRecipeRatingQuery = `
+		DELETE FROM recipe_ratings
+		WHERE recipe_id = $1 AND user_id = $2;
+	`
+	GetRecipeRatingByUserIdQuery = `
+		SELECT recipe_id, user_id, recipe_rating, created_at, updated_at
+		FROM recipe_ratings
+		WHERE recipe_id = $1 AND user_id = $2
+		LIMIT 1
 	`
 	GetRecipeRatingSummaryQuery = `
-		SELECT AVG(recipe_rating) AS average_rating, COUNT(recipe_rating) AS rating_count
+		SELECT COALESCE(ROUND(AVG(recipe_rating), 2), 0) AS average_rating, COUNT(recipe_rating) AS rating_count
 		FROM recipe_ratings
+		WHERE recipe_id = $1;
+	`
+	// Search Synonyms
+	GetSearchSynonymsQuery = `
+		SELECT search_synonym_id, term, replacement, created_at, updated_at
+		FROM search_synonyms
+		ORDER BY term
+	`
+	CreateSearchSynonymQuery = `
+		INSERT INTO search_synonyms(term, replacement, created_at, updated_at)
+		VALUES($1, $2, now()::timestamptz, now()::timestamptz)
+		RETURNING search_synonym_id, created_at, updated_at;
+	`
+	UpdateSearchSynonymQuery = `
+		UPDATE search_synonyms
+		SET term = $2, replacement = $3, updated_at = now()::timestamptz
+		WHERE search_synonym_id = $1
+		RETURNING created_at, updated_at;
+	`
+	DeleteSearchSynonymQuery = `
+		DELETE FROM search_synonyms
+		WHERE search_synonym_id = $1;
+	`
+	UpsertSearchSynonymQuery = `
+		INSERT INTO search_synonyms(term, replacement, created_at, updated_at)
+		VALUES($1, $2, now()::timestamptz, now()::timestamptz)
+		ON CONFLICT (term)
+		DO UPDATE SET replacement = EXCLUDED.replacement, updated_at = EXCLUDED.updated_at;
+	`
+	// Slugs, a slug is unique across the current and the former slugs so a former slug always redirects to the same row.
+	// LockSlugQuery holds a lock on the slug ($1) until the transaction ends so two writes cannot pick the same free slug
+	LockSlugQuery = `
+		SELECT pg_advisory_xact_lock(hashtext($1));
+	`
+	// GetTakenRecipeSlugsQuery returns the slugs starting like $1 used by the other recipes than $2
+	GetTakenRecipeSlugsQuery = `
+		SELECT slug FROM recipes WHERE (slug = $1 OR slug LIKE $1 || '-%') AND recipe_id <> $2
+		UNION
+		SELECT slug FROM recipe_slug_redirects WHERE (slug = $1 OR slug LIKE $1 || '-%') AND recipe_id <> $2
+	`
+	GetRecipeSlugForUpdateQuery = `
+		SELECT slug
+		FROM recipes
 		WHERE recipe_id = $1
-		GROUP BY recipe_id;
+		FOR UPDATE
+	`
+	CreateRecipeSlugRedirectQuery = `
+		INSERT INTO recipe_slug_redirects(slug, recipe_id, created_at)
+		VALUES($1, $2, now()::timestamptz)
+		ON CONFLICT (slug)
+		DO UPDATE SET recipe_id = EXCLUDED.recipe_id, created_at = EXCLUDED.created_at;
+	`
+	DeleteRecipeSlugRedirectQuery = `
+		DELETE FROM recipe_slug_redirects
+		WHERE slug = $1;
+	`
+	// ResolveRecipeSlugQuery finds the recipe of a current or a former slug along with its current slug
+	ResolveRecipeSlugQuery = `
+		SELECT recipe_id, slug FROM recipes WHERE slug = $1
+		UNION ALL
+		SELECT r.recipe_id, r.slug FROM recipe_slug_redirects sr JOIN recipes r ON r.recipe_id = sr.recipe_id WHERE sr.slug = $1
+		LIMIT 1
+	`
+	GetTakenRecipeCategorySlugsQuery = `
+		SELECT slug FROM recipe_categories WHERE (slug = $1 OR slug LIKE $1 || '-%') AND category_id <> $2
+		UNION
+		SELECT slug FROM recipe_category_slug_redirects WHERE (slug = $1 OR slug LIKE $1 || '-%') AND category_id <> $2
+	`
+	GetRecipeCategorySlugForUpdateQuery = `
+		SELECT slug
+		FROM recipe_categories
+		WHERE category_id = $1
+		FOR UPDATE
+	`
+	CreateRecipeCategorySlugRedirectQuery = `
+		INSERT INTO recipe_category_slug_redirects(slug, category_id, created_at)
+		VALUES($1, $2, now()::timestamptz)
+		ON CONFLICT (slug)
+		DO UPDATE SET category_id = EXCLUDED.category_id, created_at = EXCLUDED.created_at;
+	`
+	DeleteRecipeCategorySlugRedirectQuery = `
+		DELETE FROM recipe_category_slug_redirects
+		WHERE slug = $1;
+	`
+	ResolveRecipeCategorySlugQuery = `
+		SELECT category_id, slug FROM recipe_categories WHERE slug = $1
+		UNION ALL
+		SELECT c.category_id, c.slug FROM recipe_category_slug_redirects sr JOIN recipe_categories c ON c.category_id = sr.category_id WHERE sr.slug = $1
+		LIMIT 1
 	`
 )
 
 // Recipe Categories
-func (rr *recipeRepository) CreateRecipeCategory(ctx context.Context, categoryTag string) error {
+func (rr *recipeRepository) CreateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error {
 	tx, err := rr.dbConn.Begin()
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	_, err = tx.ExecContext(ctx, CreateRecipeCategoryQuery, categoryTag)
+	slug, err := uniqueSlug(ctx, tx, recipeCategorySlugQueries, recipeCategory.Slug, 0)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.QueryRowContext(ctx, CreateRecipeCategoryQuery,
+		recipeCategory.CategoryTag,
+		recipeCategory.ParentCategoryId,
+		recipeCategory.Description,
+		recipeCategory.CoverImage,
+		recipeCategory.DisplayOrder,
+		recipeCategory.IsFeatured,
+		slug,
+	).Scan(&recipeCategory.CategoryId)
 	if err != nil {
 		tx.Rollback()
+		if pqError, ok := err.(*pq.Error); ok {
+			switch pqError.Code {
+			case "23505": // unique violation, category tag already exists
+				return domain.ErrDuplicateCategory
+			case "23503": // foreign key violation, parent category does not exist
+				return domain.ErrUnknownParentCategory
+			}
+		}
 		return err
 	}
+	recipeCategory.Slug = slug
 	tx.Commit()
 	return nil
 }
-func (rr *recipeRepository) GetRecipeCategoryById(ctx context.Context, categoryId int64) (*entity.RecipeCategory, error) {
-	var recipeCategory entity.RecipeCategory
-	row := rr.dbConn.QueryRowContext(ctx, GetRecipeCategoryByIdQuery, categoryId)
-	if err := row.Scan(&recipeCategory.CategoryId, &recipeCategory.CategoryTag); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, sql.ErrNoRows
+
+// UpdateRecipeCategory renames the category and moves it under ParentCategoryId, a category cannot be moved below itself
+func (rr *recipeRepository) UpdateRecipeCategory(ctx context.Context, recipeCategory *entity.RecipeCategory) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if recipeCategory.ParentCategoryId != 0 {
+		if _, err := tx.ExecContext(ctx, LockRecipeCategoriesQuery); err != nil {
+			tx.Rollback()
+			return err
+		}
+		var isAncestor bool
+		if err := tx.QueryRowContext(ctx, IsRecipeCategoryAncestorQuery, recipeCategory.ParentCategoryId, recipeCategory.CategoryId).Scan(&isAncestor); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if isAncestor {
+			tx.Rollback()
+			return domain.ErrCategoryCycle
 		}
-		return nil, err
 	}
-	return &recipeCategory, nil
-}
-func (rr *recipeRepository) GetRecipeCategories(ctx context.Context) ([]entity.RecipeCategory, error) {
-	var recipeCategories []entity.RecipeCategory
-	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeCategoriesQuery)
+	slug, formerSlug, err := changeSlug(ctx, tx, recipeCategorySlugQueries, recipeCategory.Slug, recipeCategory.CategoryId)
 	if err != nil {
-		return nil, err
+		tx.Rollback()
+		return err
 	}
-	defer rows.Close()
-	var recipeCategory entity.RecipeCategory
-	for rows.Next() {
-		if err := rows.Scan(&recipeCategory.CategoryId, &recipeCategory.CategoryTag); err != nil {
-			return nil, err
+	result, err := tx.ExecContext(ctx, UpdateRecipeCategoryQuery,
+		recipeCategory.CategoryId,
+		recipeCategory.CategoryTag,
+		recipeCategory.ParentCategoryId,
+		recipeCategory.Description,
+		recipeCategory.CoverImage,
+		recipeCategory.DisplayOrder,
+		recipeCategory.IsFeatured,
+		slug,
+	)
+	if err != nil {
+		tx.Rollback()
+		if pqError, ok := err.(*pq.Error); ok {
+			switch pqError.Code {
+			case "23505": // unique violation, category tag already exists
+				return domain.ErrDuplicateCategory
+			case "23503": // foreign key violation, parent category does not exist
+				return domain.ErrUnknownParentCategory
+			}
 		}
-		recipeCategories = append(recipeCategories, recipeCategory)
+		return err
 	}
-	return recipeCategories, nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+	if err := redirectFormerSlug(ctx, tx, recipeCategorySlugQueries, slug, formerSlug, recipeCategory.CategoryId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	recipeCategory.Slug = slug
+	return tx.Commit()
 }
 
-// Recipes
-func (rr *recipeRepository) CreateRecipe(ctx context.Context, recipe *entity.Recipe) error {
+// DeleteRecipeCategory moves the recipes of the category to reassignToCategoryId and its children to its own parent before deleting it
+func (rr *recipeRepository) DeleteRecipeCategory(ctx context.Context, categoryId, reassignToCategoryId int64) error {
 	tx, err := rr.dbConn.Begin()
 	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, LockRecipeCategoriesQuery); err != nil {
+		tx.Rollback()
+		return err
+	}
+	var parentCategoryId int64
+	if err := tx.QueryRowContext(ctx, GetRecipeCategoryParentIdQuery, categoryId).Scan(&parentCategoryId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx, RecipeCategoryExistsQuery, reassignToCategoryId).Scan(&exists); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !exists {
+		tx.Rollback()
+		return domain.ErrInvalidReassignCategory
+	}
+	if _, err := tx.ExecContext(ctx, MoveRecipeCategoryChildrenQuery, categoryId, parentCategoryId); err != nil {
 		tx.Rollback()
 		return err
 	}
-	_, err = tx.ExecContext(ctx, CreateRecipeQuery, recipe.CategoryId, recipe.Title, recipe.Header, recipe.ImagePreview, recipe.Description, recipe.EstimatedTimeMinutes, recipe.RecipeIngredients, recipe.CreatedAt, recipe.UpdatedAt)
+	if _, err := tx.ExecContext(ctx, ReassignRecipesCategoryQuery, categoryId, reassignToCategoryId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, DeleteRecipeCategoryQuery, categoryId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReorderRecipeCategories sets the display order of categoryIds to their position, an unknown category id fails the whole reorder
+func (rr *recipeRepository) ReorderRecipeCategories(ctx context.Context, categoryIds []int64) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, ReorderRecipeCategoriesQuery, pq.Array(categoryIds))
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	tx.Commit()
-	return nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected != int64(len(categoryIds)) {
+		tx.Rollback()
+		return domain.ErrBadRequest
+	}
+	return tx.Commit()
 }
-func (rr *recipeRepository) GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error) {
-	var recipe entity.Recipe
-	row := rr.dbConn.QueryRowContext(ctx, GetRecipeByIdQuery, recipeId)
-	if err := row.Scan(&recipe.RecipeId, &recipe.CategoryId, &recipe.Title, &recipe.Header, &recipe.ImagePreview, &recipe.Description, &recipe.EstimatedTimeMinutes, &recipe.RecipeIngredients, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
+
+// GetRecipeCategoryById returns the category with its breadcrumbs and direct children
+func (rr *recipeRepository) GetRecipeCategoryById(ctx context.Context, categoryId int64) (*entity.RecipeCategory, error) {
+	recipeCategory, err := scanRecipeCategory(rr.dbConn.QueryRowContext(ctx, GetRecipeCategoryByIdQuery, categoryId))
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
 		}
 		return nil, err
 	}
-	return &recipe, nil
+	breadcrumbs, err := rr.queryRecipeCategories(ctx, GetRecipeCategoryBreadcrumbsQuery, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	recipeCategory.Breadcrumbs = breadcrumbs
+	children, err := rr.queryRecipeCategories(ctx, GetRecipeCategoryChildrenQuery, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	recipeCategory.Children = children
+	return recipeCategory, nil
 }
-func (rr *recipeRepository) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) ([]entity.Recipe, error) {
-	// var recipes []entity.Recipe
-	// rows, err := rr.dbConn.QueryContext(ctx, GetRecipesQuery, 1)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// defer rows.Close()
-	// var recipe entity.Recipe
-	// for rows.Next() {
-	// 	if err := rows.Scan(&recipe.RecipeId, &recipe.CategoryId, &recipe.Title, &recipe.Header, &recipe.ImagePreview, &recipe.Description, &recipe.EstimatedTimeMinutes, &recipe.RecipeIngredients, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
-	// 		return nil, err
-	// 	}
-	// 	recipes = append(recipes, recipe)
-	// }
-	// return recipes, nil
 
-	var recipes []entity.Recipe
-	limit := getRecipesQueryFilter.Limit
-	offset := getRecipesQueryFilter.Offset
-	query := GetRecipesQuery
-	var args []interface{}
-	var conditions []string
-	queryParamCount := 1
-	// Handle filtering by name (title)
-	if getRecipesQueryFilter.Name != "" {
-		conditions = append(conditions, "LOWER(title) = $"+fmt.Sprintf("%d", queryParamCount))
-		args = append(args, strings.ToLower(getRecipesQueryFilter.Name))
-		queryParamCount++
-	}
-	if getRecipesQueryFilter.CategoryId != 0 {
-		conditions = append(conditions, "category_id = $"+fmt.Sprintf("%d", queryParamCount))
-		args = append(args, getRecipesQueryFilter.CategoryId)
-		queryParamCount++
-	}
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", queryParamCount, queryParamCount+1)
-	args = append(args, limit, offset)
+func (rr *recipeRepository) queryRecipeCategories(ctx context.Context, query string, args ...interface{}) ([]entity.RecipeCategory, error) {
 	rows, err := rr.dbConn.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-
+	recipeCategories := []entity.RecipeCategory{}
 	for rows.Next() {
-		var recipe entity.Recipe
-		if err := rows.Scan(&recipe.RecipeId, &recipe.CategoryId, &recipe.Title, &recipe.Header, &recipe.ImagePreview, &recipe.Description, &recipe.EstimatedTimeMinutes, &recipe.RecipeIngredients, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
+		recipeCategory, err := scanRecipeCategory(rows)
+		if err != nil {
 			return nil, err
 		}
-		recipes = append(recipes, recipe)
+		recipeCategories = append(recipeCategories, *recipeCategory)
 	}
-	return recipes, nil
+	return recipeCategories, rows.Err()
 }
 
-func (rr *recipeRepository) UpdateRecipeById(ctx context.Context, recipeId int64, updateRecipeByIdQueryFilter *domain.UpdateRecipeByIdQueryFilter) error {
-	updateRecipeQuery := UpdateRecipeByIdQuery
-	var updateValues []interface{}
-	var args []interface{}
-	queryFilterCount := 1
-	// Check each field in the filter and update the query accordingly
-	if updateRecipeByIdQueryFilter.Title != "" {
-		updateRecipeQuery += ", title = $" + fmt.Sprintf("%d", queryFilterCount)
-		updateValues = append(updateValues, updateRecipeByIdQueryFilter.Title)
-		queryFilterCount++
+func scanRecipeCategory(s scanner) (*entity.RecipeCategory, error) {
+	var recipeCategory entity.RecipeCategory
+	if err := s.Scan(
+		&recipeCategory.CategoryId,
+		&recipeCategory.CategoryTag,
+		&recipeCategory.Slug,
+		&recipeCategory.ParentCategoryId,
+		&recipeCategory.Description,
+		&recipeCategory.CoverImage,
+		&recipeCategory.DisplayOrder,
+		&recipeCategory.IsFeatured,
+		&recipeCategory.RecipeCount,
+	); err != nil {
+		return nil, err
 	}
-	if updateRecipeByIdQueryFilter.Header != "" {
-		updateRecipeQuery += ", header = $" + fmt.Sprintf("%d", queryFilterCount)
-		updateValues = append(updateValues, updateRecipeByIdQueryFilter.Header)
-		queryFilterCount++
+	return &recipeCategory, nil
+}
+
+// ResolveRecipeCategorySlug returns the category of a current or former slug and its current slug
+func (rr *recipeRepository) ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error) {
+	var categoryId int64
+	var currentSlug string
+	if err := rr.dbConn.QueryRowContext(ctx, ResolveRecipeCategorySlugQuery, slug).Scan(&categoryId, &currentSlug); err != nil {
+		return 0, "", err
 	}
-	if updateRecipeByIdQueryFilter.ImagePreview != "" {
-		updateRecipeQuery += ", image_preview = $" + fmt.Sprintf("%d", queryFilterCount)
-		updateValues = append(updateValues, updateRecipeByIdQueryFilter.ImagePreview)
-		queryFilterCount++
+	return categoryId, currentSlug, nil
+}
+
+func (rr *recipeRepository) GetRecipeCategories(ctx context.Context) ([]entity.RecipeCategory, error) {
+	var recipeCategories []entity.RecipeCategory
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeCategoriesQuery)
+	if err != nil {
+		return nil, err
 	}
-	if updateRecipeByIdQueryFilter.Description != "" {
-		updateRecipeQuery += ", description = $" + fmt.Sprintf("%d", queryFilterCount)
-		updateValues = append(updateValues, updateRecipeByIdQueryFilter.Description)
-		queryFilterCount++
+	defer rows.Close()
+	for rows.Next() {
+		recipeCategory, err := scanRecipeCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		recipeCategories = append(recipeCategories, *recipeCategory)
 	}
-	updateRecipeQuery += " WHERE recipe_id = $1"
-	args = append(args, recipeId)
-	_, err := rr.dbConn.ExecContext(ctx, updateRecipeQuery, append(updateValues, args...)...)
+	return recipeCategories, nil
+}
+
+// Tags
+func (rr *recipeRepository) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	rows, err := rr.dbConn.QueryContext(ctx, GetTagsQuery)
 	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []entity.Tag{}
+	for rows.Next() {
+		var tag entity.Tag
+		if err := rows.Scan(&tag.TagId, &tag.TagName); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (rr *recipeRepository) CreateTag(ctx context.Context, tag *entity.Tag) error {
+	if err := rr.dbConn.QueryRowContext(ctx, CreateTagQuery, tag.TagName).Scan(&tag.TagId); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation, tag name already exists
+				return domain.ErrDuplicateTag
+			}
+		}
 		return err
 	}
 	return nil
 }
 
-func (rr *recipeRepository) DeleteRecipeById(ctx context.Context, recipeId int64) error {
-	_, err := rr.dbConn.ExecContext(ctx, DeleteRecipeByIdQuery, recipeId)
+func (rr *recipeRepository) UpdateTag(ctx context.Context, tag *entity.Tag) error {
+	result, err := rr.dbConn.ExecContext(ctx, UpdateTagQuery, tag.TagId, tag.TagName)
+	if err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation, tag name already exists
+				return domain.ErrDuplicateTag
+			}
+		}
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
 	return nil
 }
 
-// Recipe Ratings
-func (rr *recipeRepository) CreateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error {
-	tx, err := rr.dbConn.Begin()
+// DeleteTag also removes the tag from its recipes
+func (rr *recipeRepository) DeleteTag(ctx context.Context, tagId int64) error {
+	result, err := rr.dbConn.ExecContext(ctx, DeleteTagQuery, tagId)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	_, err = tx.ExecContext(ctx, CreateRecipeRatingQuery, recipeId, userId, rating)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	tx.Commit()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
 	return nil
 }
 
-func (rr *recipeRepository) GetRecipeRatingSummary(ctx context.Context, recipeId int64) (float64, int, error) {
-	var ratingAvg float64
-	var ratingCount int
-	row := rr.dbConn.QueryRowContext(ctx, GetRecipeRatingSummaryQuery, recipeId)
-	if err := row.Scan(&ratingAvg, &ratingCount); err != nil {
-		return 0, 0, err
+// Recipes
+func (rr *recipeRepository) CreateRecipe(ctx context.Context, recipe *entity.Recipe) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
 	}
-	return ratingAvg, ratingCount, nil
+	slug, err := uniqueSlug(ctx, tx, recipeSlugQueries, recipe.Slug, 0)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	row := tx.QueryRowContext(ctx, CreateRecipeQuery, recipe.CategoryId, recipe.Title, recipe.Header, recipe.ImagePreview, recipe.Description, recipe.EstimatedTimeMinutes, recipe.Servings, slug)
+	if err := row.Scan(&recipe.RecipeId, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	recipe.Slug = slug
+	if err := createRecipeIngredients(ctx, tx, recipe.RecipeId, recipe.RecipeIngredients); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createRecipeSteps(ctx, tx, recipe.RecipeId, recipe.RecipeSteps); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := createRecipeTags(ctx, tx, recipe.RecipeId, recipe.Tags); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+func (rr *recipeRepository) GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error) {
+	row := rr.dbConn.QueryRowContext(ctx, GetRecipeByIdQuery, recipeId)
+	recipe, err := scanRecipe(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	recipes := []entity.Recipe{*recipe}
+	if err := rr.attachRecipeIngredients(ctx, recipes); err != nil {
+		return nil, err
+	}
+	if err := rr.attachRecipeTags(ctx, recipes); err != nil {
+		return nil, err
+	}
+	recipeSteps, err := rr.GetRecipeStepsByRecipeId(ctx, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	recipes[0].RecipeSteps = recipeSteps
+	recipeNutrition, err := rr.getRecipeNutritionByRecipeId(ctx, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	recipes[0].Nutrition = recipeNutrition
+	return &recipes[0], nil
+}
+
+// ResolveRecipeSlug returns the recipe of a current or former slug and its current slug
+func (rr *recipeRepository) ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error) {
+	var recipeId int64
+	var currentSlug string
+	if err := rr.dbConn.QueryRowContext(ctx, ResolveRecipeSlugQuery, slug).Scan(&recipeId, &currentSlug); err != nil {
+		return 0, "", err
+	}
+	return recipeId, currentSlug, nil
+}
+
+func (rr *recipeRepository) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipePage, error) {
+	sortOrder, ok := recipeSortOrders[getRecipesQueryFilter.Sort]
+	if !ok {
+		return nil, domain.ErrUnknownRecipeSort
+	}
+	searchQuery := searchTsQuery(getRecipesQueryFilter)
+	if getRecipesQueryFilter.Sort == domain.SortRelevance {
+		if searchQuery == "" {
+			return nil, domain.ErrUnknownRecipeSort
+		}
+		if getRecipesQueryFilter.Fuzzy {
+			sortOrder = fuzzyRelevanceSortOrder
+		}
+	}
+	var after *recipeCursor
+	if getRecipesQueryFilter.Cursor != "" {
+		recipeCursor, err := decodeRecipeCursor(getRecipesQueryFilter.Cursor)
+		if err != nil || recipeCursor.Sort != getRecipesQueryFilter.Sort {
+			return nil, domain.ErrInvalidCursor
+		}
+		after = recipeCursor
+	}
+	conditions, args := recipeConditions(getRecipesQueryFilter, noFacet)
+
+	recipePage := &entity.RecipePage{Recipes: []entity.Recipe{}}
+	if err := rr.dbConn.QueryRowContext(ctx, CountRecipesQuery+whereClause(conditions), args...).Scan(&recipePage.Total); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(GetRecipesQueryFormat, sortOrder.expr)
+	if searchQuery != "" {
+		query = fmt.Sprintf(SearchRecipesQueryFormat, sortOrder.expr)
+	}
+	if after != nil {
+		args = append(args, after.Value, after.RecipeId)
+		conditions = append(conditions, sortOrder.after(len(args)-1, len(args)))
+	}
+	query += whereClause(conditions) + sortOrder.orderBy()
+	// one more recipe than the limit tells whether there is a next page
+	args = append(args, getRecipesQueryFilter.Limit+1)
+	query += fmt.Sprintf(" LIMIT $%d", len(args))
+	if after == nil && getRecipesQueryFilter.Offset > 0 {
+		args = append(args, getRecipesQueryFilter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+	if searchQuery != "" {
+		query = fmt.Sprintf(SearchRecipesHighlightQueryFormat, query) + sortOrder.orderBy()
+	}
+	rows, err := rr.dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sortValues []string
+	for rows.Next() {
+		var recipe *entity.Recipe
+		var sortValue string
+		if searchQuery != "" {
+			var searchRank float64
+			var searchHighlight string
+			recipe, err = scanRecipe(rows, &searchRank, &sortValue, &searchHighlight)
+			if recipe != nil {
+				recipe.SearchRank = searchRank
+				recipe.SearchHighlight = searchHighlight
+			}
+		} else {
+			recipe, err = scanRecipe(rows, &sortValue)
+		}
+		if err != nil {
+			return nil, err
+		}
+		recipePage.Recipes = append(recipePage.Recipes, *recipe)
+		sortValues = append(sortValues, sortValue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(recipePage.Recipes) > getRecipesQueryFilter.Limit {
+		recipePage.Recipes = recipePage.Recipes[:getRecipesQueryFilter.Limit]
+		recipePage.HasMore = true
+		last := len(recipePage.Recipes) - 1
+		recipePage.NextCursor = encodeRecipeCursor(&recipeCursor{
+			Sort:     getRecipesQueryFilter.Sort,
+			Value:    sortValues[last],
+			RecipeId: recipePage.Recipes[last].RecipeId,
+		})
+	}
+	if err := rr.attachRecipeIngredients(ctx, recipePage.Recipes); err != nil {
+		return nil, err
+	}
+	if err := rr.attachRecipeTags(ctx, recipePage.Recipes); err != nil {
+		return nil, err
+	}
+	return recipePage, nil
+}
+
+func (rr *recipeRepository) GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error) {
+	query = strings.ToLower(query)
+	rows, err := rr.dbConn.QueryContext(ctx, GetSuggestionsQuery, query, escapeLike(query)+"%", "% "+escapeLike(query)+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []entity.Suggestion{}
+	for rows.Next() {
+		var suggestion entity.Suggestion
+		if err := rows.Scan(&suggestion.Type, &suggestion.Id, &suggestion.Text, &suggestion.RecipeCount, &suggestion.Score); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, rows.Err()
+}
+
+// recipeFacet is a filter dimension counted by GetRecipeFacets
+type recipeFacet int
+
+const (
+	noFacet recipeFacet = iota
+	categoryFacet
+	timeFacet
+	ratingFacet
+)
+
+// recipeConditions builds the WHERE conditions of a recipes filter numbered from $1, the search tsquery is always $1
+// and the fuzzy search phrase always $2. The filter of the except facet is left out so its facet counts every value
+// that can be picked instead
+func recipeConditions(getRecipesQueryFilter *domain.GetRecipesQueryFilter, except recipeFacet) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	// placeholder returns the next positional parameter for arg
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if searchQuery := searchTsQuery(getRecipesQueryFilter); searchQuery != "" {
+		condition := "search_vector @@ to_tsquery('simple', " + placeholder(searchQuery) + ")"
+		if getRecipesQueryFilter.Fuzzy {
+			// misspelled words never match the tsquery, the title is compared by trigram similarity instead
+			condition = "(" + condition + " OR " + placeholder(getRecipesQueryFilter.FuzzySearch.Phrase) + " <% lower(title))"
+		}
+		conditions = append(conditions, condition)
+	}
+	// Handle filtering by name (title), any part of the title matches
+	if getRecipesQueryFilter.Fuzzy && len(getRecipesQueryFilter.FuzzyName.Alternatives) > 0 {
+		patterns := make([]string, len(getRecipesQueryFilter.FuzzyName.Alternatives))
+		for i, alternative := range getRecipesQueryFilter.FuzzyName.Alternatives {
+			patterns[i] = "%" + escapeLike(alternative) + "%"
+		}
+		conditions = append(conditions, "(lower(title) LIKE ANY("+placeholder(pq.Array(patterns))+") OR "+placeholder(getRecipesQueryFilter.FuzzyName.Phrase)+" <% lower(title))")
+	} else if getRecipesQueryFilter.Name != "" {
+		conditions = append(conditions, "title ILIKE '%' || "+placeholder(escapeLike(getRecipesQueryFilter.Name))+" || '%'")
+	}
+	if len(getRecipesQueryFilter.Tags) > 0 {
+		tagCondition := "recipe_id IN (SELECT rt.recipe_id FROM recipe_tags rt JOIN tags t ON t.tag_id = rt.tag_id WHERE lower(t.tag_name) = ANY(" + placeholder(pq.Array(getRecipesQueryFilter.Tags)) + ")"
+		if getRecipesQueryFilter.TagMode == domain.TagModeAnd {
+			// tag names are unique so a recipe with every tag has one row per tag
+			tagCondition += " GROUP BY rt.recipe_id HAVING count(*) = " + placeholder(len(getRecipesQueryFilter.Tags))
+		}
+		conditions = append(conditions, tagCondition+")")
+	}
+	if getRecipesQueryFilter.CategoryId != 0 && except != categoryFacet {
+		if getRecipesQueryFilter.IncludeDescendants {
+			conditions = append(conditions, fmt.Sprintf(RecipeCategoryDescendantsConditionFormat, placeholder(getRecipesQueryFilter.CategoryId)))
+		} else {
+			conditions = append(conditions, "category_id = "+placeholder(getRecipesQueryFilter.CategoryId))
+		}
+	}
+	if getRecipesQueryFilter.MaxCalories > 0 {
+		conditions = append(conditions, "recipe_id IN (SELECT recipe_id FROM recipe_nutritions WHERE calories <= "+placeholder(getRecipesQueryFilter.MaxCalories)+")")
+	}
+	if timeBucket, ok := domain.GetRecipeTimeBucket(getRecipesQueryFilter.TimeBucket); ok && except != timeFacet {
+		conditions = append(conditions, "estimated_time_minutes >= "+placeholder(timeBucket.MinMinutes))
+		if timeBucket.MaxMinutes > 0 {
+			conditions = append(conditions, "estimated_time_minutes < "+placeholder(timeBucket.MaxMinutes))
+		}
+	}
+	// min_time and max_time narrow the time too so the time facet ignores them as well
+	if getRecipesQueryFilter.MinTime > 0 && except != timeFacet {
+		conditions = append(conditions, "estimated_time_minutes >= "+placeholder(getRecipesQueryFilter.MinTime))
+	}
+	if getRecipesQueryFilter.MaxTime > 0 && except != timeFacet {
+		conditions = append(conditions, "estimated_time_minutes <= "+placeholder(getRecipesQueryFilter.MaxTime))
+	}
+	if !getRecipesQueryFilter.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= "+placeholder(getRecipesQueryFilter.CreatedAfter))
+	}
+	if !getRecipesQueryFilter.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < "+placeholder(getRecipesQueryFilter.CreatedBefore))
+	}
+	if getRecipesQueryFilter.HasImage != nil {
+		if *getRecipesQueryFilter.HasImage {
+			conditions = append(conditions, "image_preview <> ''")
+		} else {
+			conditions = append(conditions, "image_preview = ''")
+		}
+	}
+	if getRecipesQueryFilter.MinRating > 0 && except != ratingFacet {
+		conditions = append(conditions, "recipe_id IN (SELECT recipe_id FROM recipe_ratings GROUP BY recipe_id HAVING avg(recipe_rating) >= "+placeholder(getRecipesQueryFilter.MinRating)+")")
+	}
+	// drafts are only listed on their own
+	if getRecipesQueryFilter.Drafts {
+		conditions = append(conditions, "published_at IS NULL")
+	} else {
+		conditions = append(conditions, "published_at IS NOT NULL")
+	}
+	return conditions, args
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func (rr *recipeRepository) GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error) {
+	recipeFacets := &entity.RecipeFacets{
+		Categories:  []entity.CategoryFacet{},
+		TimeBuckets: make([]entity.TimeBucketFacet, len(domain.RecipeTimeBuckets)),
+		MinRatings:  make([]entity.RatingFacet, 0, 4),
+	}
+
+	// Categories
+	conditions, args := recipeConditions(getRecipesQueryFilter, categoryFacet)
+	rows, err := rr.dbConn.QueryContext(ctx, fmt.Sprintf(GetRecipeCategoryFacetsQueryFormat, whereClause(conditions)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var category entity.CategoryFacet
+		if err := rows.Scan(&category.CategoryId, &category.CategoryTag, &category.Count); err != nil {
+			return nil, err
+		}
+		recipeFacets.Categories = append(recipeFacets.Categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Estimated time buckets, every bucket is returned even without recipes
+	for i, timeBucket := range domain.RecipeTimeBuckets {
+		recipeFacets.TimeBuckets[i].TimeBucket = timeBucket.Name
+	}
+	conditions, args = recipeConditions(getRecipesQueryFilter, timeFacet)
+	timeRows, err := rr.dbConn.QueryContext(ctx, fmt.Sprintf(GetRecipeTimeFacetsQueryFormat, whereClause(conditions)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer timeRows.Close()
+	for timeRows.Next() {
+		var estimatedTimeMinutes, count int
+		if err := timeRows.Scan(&estimatedTimeMinutes, &count); err != nil {
+			return nil, err
+		}
+		for i, timeBucket := range domain.RecipeTimeBuckets {
+			if timeBucket.Contains(estimatedTimeMinutes) {
+				recipeFacets.TimeBuckets[i].Count += count
+				break
+			}
+		}
+	}
+	if err := timeRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Minimum ratings, a recipe rated 4.5 is counted in 4 and up, 3 and up and so on
+	conditions, args = recipeConditions(getRecipesQueryFilter, ratingFacet)
+	ratingRows, err := rr.dbConn.QueryContext(ctx, fmt.Sprintf(GetRecipeRatingFacetsQueryFormat, whereClause(conditions)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer ratingRows.Close()
+	var ratingCounts [6]int
+	for ratingRows.Next() {
+		var rating, count int
+		if err := ratingRows.Scan(&rating, &count); err != nil {
+			return nil, err
+		}
+		if rating >= 1 && rating <= 5 {
+			ratingCounts[rating] += count
+		}
+	}
+	if err := ratingRows.Err(); err != nil {
+		return nil, err
+	}
+	count := ratingCounts[5]
+	for minRating := 4; minRating >= 1; minRating-- {
+		count += ratingCounts[minRating]
+		recipeFacets.MinRatings = append(recipeFacets.MinRatings, entity.RatingFacet{MinRating: minRating, Count: count})
+	}
+	return recipeFacets, nil
+}
+
+func (rr *recipeRepository) GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error) {
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipesByIngredientsQuery,
+		pq.Array(toIngredientPatterns(getRecipesByIngredientsQueryFilter.Have)),
+		pq.Array(toIngredientPatterns(getRecipesByIngredientsQueryFilter.Exclude)),
+		getRecipesByIngredientsQueryFilter.Limit,
+		getRecipesByIngredientsQueryFilter.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipeMatches []entity.RecipeMatch
+	var recipes []entity.Recipe
+	for rows.Next() {
+		var recipeMatch entity.RecipeMatch
+		recipe, err := scanRecipe(rows, pq.Array(&recipeMatch.MatchedIngredients), pq.Array(&recipeMatch.MissingIngredients), &recipeMatch.MatchedCount, &recipeMatch.MissingCount)
+		if err != nil {
+			return nil, err
+		}
+		// array_agg returns NULL when every ingredient is matched
+		if recipeMatch.MissingIngredients == nil {
+			recipeMatch.MissingIngredients = []string{}
+		}
+		recipeMatches = append(recipeMatches, recipeMatch)
+		recipes = append(recipes, *recipe)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := rr.attachRecipeIngredients(ctx, recipes); err != nil {
+		return nil, err
+	}
+	if err := rr.attachRecipeTags(ctx, recipes); err != nil {
+		return nil, err
+	}
+	for i := range recipeMatches {
+		recipeMatches[i].Recipe = recipes[i]
+	}
+	return recipeMatches, nil
+}
+
+func (rr *recipeRepository) UpdateRecipeById(ctx context.Context, recipeId int64, updateRecipeByIdQueryFilter *domain.UpdateRecipeByIdQueryFilter) error {
+	updateRecipeQuery := UpdateRecipeByIdQuery
+	// $1 is reserved for recipe_id
+	args := []interface{}{recipeId}
+	queryFilterCount := 2
+	// Check each field in the filter and update the query accordingly
+	if updateRecipeByIdQueryFilter.Title != "" {
+		updateRecipeQuery += ", title = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.Title)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.Header != "" {
+		updateRecipeQuery += ", header = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.Header)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.ImagePreview != "" {
+		updateRecipeQuery += ", image_preview = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.ImagePreview)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.Description != "" {
+		updateRecipeQuery += ", description = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.Description)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.CategoryId != 0 {
+		updateRecipeQuery += ", category_id = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.CategoryId)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.EstimatedTimeMinutes != 0 {
+		updateRecipeQuery += ", estimated_time_minutes = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.EstimatedTimeMinutes)
+		queryFilterCount++
+	}
+	if updateRecipeByIdQueryFilter.Servings != 0 {
+		updateRecipeQuery += ", servings = $" + fmt.Sprintf("%d", queryFilterCount)
+		args = append(args, updateRecipeByIdQueryFilter.Servings)
+		queryFilterCount++
+	}
+
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	// a new title gives a new slug, the former slug keeps redirecting to the recipe
+	var slug, formerSlug string
+	if updateRecipeByIdQueryFilter.Slug != "" {
+		slug, formerSlug, err = changeSlug(ctx, tx, recipeSlugQueries, updateRecipeByIdQueryFilter.Slug, recipeId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if formerSlug != "" {
+			updateRecipeQuery += ", slug = $" + fmt.Sprintf("%d", queryFilterCount)
+			args = append(args, slug)
+		}
+	}
+	updateRecipeQuery += " WHERE recipe_id = $1"
+	result, err := tx.ExecContext(ctx, updateRecipeQuery, args...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+	if err := redirectFormerSlug(ctx, tx, recipeSlugQueries, slug, formerSlug, recipeId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	// ingredients are replaced as a whole to keep their positions consistent
+	if updateRecipeByIdQueryFilter.RecipeIngredients != nil {
+		if _, err := tx.ExecContext(ctx, DeleteRecipeIngredientsByRecipeIdQuery, recipeId); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := createRecipeIngredients(ctx, tx, recipeId, updateRecipeByIdQueryFilter.RecipeIngredients); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if updateRecipeByIdQueryFilter.RecipeSteps != nil {
+		if _, err := tx.ExecContext(ctx, DeleteRecipeStepsByRecipeIdQuery, recipeId); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := createRecipeSteps(ctx, tx, recipeId, updateRecipeByIdQueryFilter.RecipeSteps); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if updateRecipeByIdQueryFilter.Tags != nil {
+		if _, err := tx.ExecContext(ctx, DeleteRecipeTagsByRecipeIdQuery, recipeId); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := createRecipeTags(ctx, tx, recipeId, updateRecipeByIdQueryFilter.Tags); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (rr *recipeRepository) UpdateRecipePublished(ctx context.Context, recipeId int64, published bool) error {
+	result, err := rr.dbConn.ExecContext(ctx, UpdateRecipePublishedQuery, recipeId, published)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (rr *recipeRepository) DeleteRecipeById(ctx context.Context, recipeId int64) error {
+	result, err := rr.dbConn.ExecContext(ctx, DeleteRecipeByIdQuery, recipeId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Recipe Ingredients
+func createRecipeIngredients(ctx context.Context, tx *sql.Tx, recipeId int64, recipeIngredients []entity.RecipeIngredient) error {
+	for _, recipeIngredient := range recipeIngredients {
+		if _, err := tx.ExecContext(ctx, CreateRecipeIngredientQuery,
+			recipeId,
+			recipeIngredient.Position,
+			recipeIngredient.Name,
+			recipeIngredient.Quantity,
+			recipeIngredient.Unit,
+			recipeIngredient.Note,
+			recipeIngredient.Group,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createRecipeTags links the tags to a recipe, an unknown tag_id fails with domain.ErrUnknownTag
+func createRecipeTags(ctx context.Context, tx *sql.Tx, recipeId int64, tags []entity.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	tagIds := make([]int64, len(tags))
+	for i, tag := range tags {
+		tagIds[i] = tag.TagId
+	}
+	if _, err := tx.ExecContext(ctx, CreateRecipeTagsQuery, recipeId, pq.Array(tagIds)); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23503" { // foreign key violation, tag does not exist
+				return domain.ErrUnknownTag
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// attachRecipeTags loads tags of all given recipes in a single query
+func (rr *recipeRepository) attachRecipeTags(ctx context.Context, recipes []entity.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	recipeIds := make([]int64, 0, len(recipes))
+	recipeIndexes := make(map[int64]int, len(recipes))
+	for i := range recipes {
+		recipeIds = append(recipeIds, recipes[i].RecipeId)
+		recipeIndexes[recipes[i].RecipeId] = i
+		recipes[i].Tags = []entity.Tag{}
+	}
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeTagsByRecipeIdsQuery, pq.Array(recipeIds))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeId int64
+		var tag entity.Tag
+		if err := rows.Scan(&recipeId, &tag.TagId, &tag.TagName); err != nil {
+			return err
+		}
+		i := recipeIndexes[recipeId]
+		recipes[i].Tags = append(recipes[i].Tags, tag)
+	}
+	return rows.Err()
+}
+
+// attachRecipeIngredients loads ingredients of all given recipes in a single query
+func (rr *recipeRepository) attachRecipeIngredients(ctx context.Context, recipes []entity.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	recipeIds := make([]int64, 0, len(recipes))
+	recipeIndexes := make(map[int64]int, len(recipes))
+	for i := range recipes {
+		recipeIds = append(recipeIds, recipes[i].RecipeId)
+		recipeIndexes[recipes[i].RecipeId] = i
+		recipes[i].RecipeIngredients = []entity.RecipeIngredient{}
+	}
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeIngredientsByRecipeIdsQuery, pq.Array(recipeIds))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeId int64
+		var recipeIngredient entity.RecipeIngredient
+		if err := rows.Scan(&recipeIngredient.RecipeIngredientId, &recipeId, &recipeIngredient.Position, &recipeIngredient.Name, &recipeIngredient.Quantity, &recipeIngredient.Unit, &recipeIngredient.Note, &recipeIngredient.Group); err != nil {
+			return err
+		}
+		i := recipeIndexes[recipeId]
+		recipes[i].RecipeIngredients = append(recipes[i].RecipeIngredients, recipeIngredient)
+	}
+	return rows.Err()
+}
+
+// slugQueries keep the slugs of a table unique and its former slugs redirecting, see the Slugs queries
+type slugQueries struct {
+	getTaken       string
+	getForUpdate   string
+	createRedirect string
+	deleteRedirect string
+}
+
+var (
+	recipeSlugQueries = slugQueries{
+		getTaken:       GetTakenRecipeSlugsQuery,
+		getForUpdate:   GetRecipeSlugForUpdateQuery,
+		createRedirect: CreateRecipeSlugRedirectQuery,
+		deleteRedirect: DeleteRecipeSlugRedirectQuery,
+	}
+	recipeCategorySlugQueries = slugQueries{
+		getTaken:       GetTakenRecipeCategorySlugsQuery,
+		getForUpdate:   GetRecipeCategorySlugForUpdateQuery,
+		createRedirect: CreateRecipeCategorySlugRedirectQuery,
+		deleteRedirect: DeleteRecipeCategorySlugRedirectQuery,
+	}
+)
+
+// uniqueSlug returns base or, when another row already uses it, the first free base-N eg: "nasi-goreng-2"
+func uniqueSlug(ctx context.Context, tx *sql.Tx, queries slugQueries, base string, id int64) (string, error) {
+	if _, err := tx.ExecContext(ctx, LockSlugQuery, base); err != nil {
+		return "", err
+	}
+	rows, err := tx.QueryContext(ctx, queries.getTaken, base, id)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		taken[slug] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	slug := base
+	for n := 2; taken[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
+
+// changeSlug returns the unique slug of the row for base and its current slug when they differ, formerSlug is empty when the slug stays
+func changeSlug(ctx context.Context, tx *sql.Tx, queries slugQueries, base string, id int64) (slug, formerSlug string, err error) {
+	var currentSlug string
+	if err := tx.QueryRowContext(ctx, queries.getForUpdate, id).Scan(&currentSlug); err != nil {
+		return "", "", err
+	}
+	slug, err = uniqueSlug(ctx, tx, queries, base, id)
+	if err != nil {
+		return "", "", err
+	}
+	if slug == currentSlug {
+		return slug, "", nil
+	}
+	return slug, currentSlug, nil
+}
+
+// redirectFormerSlug keeps formerSlug redirecting to the row, the new slug stops redirecting in case it was a former slug of the row
+func redirectFormerSlug(ctx context.Context, tx *sql.Tx, queries slugQueries, slug, formerSlug string, id int64) error {
+	if formerSlug == "" {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, queries.deleteRedirect, slug); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, queries.createRedirect, formerSlug, id)
+	return err
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRecipe scans the recipe columns, extra destinations are scanned from the columns after updated_at
+func scanRecipe(s scanner, extra ...interface{}) (*entity.Recipe, error) {
+	var recipe entity.Recipe
+	dest := []interface{}{
+		&recipe.RecipeId,
+		&recipe.CategoryId,
+		&recipe.Title,
+		&recipe.Slug,
+		&recipe.Header,
+		&recipe.ImagePreview,
+		&recipe.Description,
+		&recipe.EstimatedTimeMinutes,
+		&recipe.Servings,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.PublishedAt,
+	}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &recipe, nil
+}
+
+// toPrefixTsQuery turns a search input into a tsquery matching every word by prefix eg: "nasi gor" -> "nasi:* & gor:*",
+// only letters and digits are kept so the input can never break the tsquery syntax
+func toPrefixTsQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := range words {
+		words[i] += ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// searchTsQuery is the tsquery of the search of a filter, a fuzzy search matches any of its alternatives
+// eg: "nasgor" -> "(nasgor:*) | (nasi:* & goreng:*)"
+func searchTsQuery(getRecipesQueryFilter *domain.GetRecipesQueryFilter) string {
+	if !getRecipesQueryFilter.Fuzzy {
+		return toPrefixTsQuery(getRecipesQueryFilter.SearchQuery)
+	}
+	alternatives := make([]string, 0, len(getRecipesQueryFilter.FuzzySearch.Alternatives))
+	for _, alternative := range getRecipesQueryFilter.FuzzySearch.Alternatives {
+		if tsQuery := toPrefixTsQuery(alternative); tsQuery != "" {
+			alternatives = append(alternatives, "("+tsQuery+")")
+		}
+	}
+	return strings.Join(alternatives, " | ")
+}
+
+// toIngredientPatterns turns ingredient names into LIKE patterns matching ingredients containing a word starting with the name,
+// eg: "bawang" matches "bawang merah" and "daun bawang" but "air" does not match "cair"
+func toIngredientPatterns(names []string) []string {
+	patterns := make([]string, 0, len(names)*2)
+	for _, name := range names {
+		name = escapeLike(strings.ToLower(name))
+		patterns = append(patterns, name+"%", "% "+name+"%")
+	}
+	return patterns
+}
+
+// escapeLike escapes the LIKE wildcards of a user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// Recipe Steps
+func createRecipeSteps(ctx context.Context, tx *sql.Tx, recipeId int64, recipeSteps []entity.RecipeStep) error {
+	for i := range recipeSteps {
+		row := tx.QueryRowContext(ctx, CreateRecipeStepQuery, recipeId, recipeSteps[i].StepNumber, recipeSteps[i].Instruction, recipeSteps[i].DurationSeconds, recipeSteps[i].StepImage)
+		if err := row.Scan(&recipeSteps[i].RecipeStepId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockRecipe serializes step changes of the same recipe, returns sql.ErrNoRows when the recipe does not exist
+func lockRecipe(ctx context.Context, tx *sql.Tx, recipeId int64) error {
+	var lockedRecipeId int64
+	return tx.QueryRowContext(ctx, LockRecipeByIdQuery, recipeId).Scan(&lockedRecipeId)
+}
+
+func (rr *recipeRepository) GetRecipeStepsByRecipeId(ctx context.Context, recipeId int64) ([]entity.RecipeStep, error) {
+	recipeSteps := []entity.RecipeStep{}
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeStepsByRecipeIdQuery, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeStep entity.RecipeStep
+		if err := rows.Scan(&recipeStep.RecipeStepId, &recipeStep.StepNumber, &recipeStep.Instruction, &recipeStep.DurationSeconds, &recipeStep.StepImage); err != nil {
+			return nil, err
+		}
+		recipeSteps = append(recipeSteps, recipeStep)
+	}
+	return recipeSteps, rows.Err()
+}
+
+// CreateRecipeStep inserts the step at recipeStep.StepNumber, a step number outside of the existing steps appends the step
+func (rr *recipeRepository) CreateRecipeStep(ctx context.Context, recipeId int64, recipeStep *entity.RecipeStep) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err := lockRecipe(ctx, tx, recipeId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	recipeStepIds, err := getRecipeStepIds(ctx, tx, recipeId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if recipeStep.StepNumber <= 0 || recipeStep.StepNumber > len(recipeStepIds) {
+		recipeStep.StepNumber = len(recipeStepIds) + 1
+	}
+	if _, err := tx.ExecContext(ctx, ShiftRecipeStepsQuery, recipeId, recipeStep.StepNumber, 1); err != nil {
+		tx.Rollback()
+		return err
+	}
+	recipeSteps := []entity.RecipeStep{*recipeStep}
+	if err := createRecipeSteps(ctx, tx, recipeId, recipeSteps); err != nil {
+		tx.Rollback()
+		return err
+	}
+	recipeStep.RecipeStepId = recipeSteps[0].RecipeStepId
+	return tx.Commit()
+}
+
+// ReorderRecipeSteps renumbers the steps following recipeStepIds, which must contain every step of the recipe exactly once
+func (rr *recipeRepository) ReorderRecipeSteps(ctx context.Context, recipeId int64, recipeStepIds []int64) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err := lockRecipe(ctx, tx, recipeId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	existingRecipeStepIds, err := getRecipeStepIds(ctx, tx, recipeId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(existingRecipeStepIds) != len(recipeStepIds) {
+		tx.Rollback()
+		return domain.ErrBadRequest
+	}
+	reordered := make(map[int64]bool, len(recipeStepIds))
+	for _, recipeStepId := range recipeStepIds {
+		if !existingRecipeStepIds[recipeStepId] || reordered[recipeStepId] {
+			tx.Rollback()
+			return domain.ErrBadRequest
+		}
+		reordered[recipeStepId] = true
+	}
+	for i, recipeStepId := range recipeStepIds {
+		if _, err := tx.ExecContext(ctx, UpdateRecipeStepNumberQuery, recipeId, recipeStepId, i+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteRecipeStep deletes the step and closes the gap in step numbers
+func (rr *recipeRepository) DeleteRecipeStep(ctx context.Context, recipeId, recipeStepId int64) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if err := lockRecipe(ctx, tx, recipeId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	var stepNumber int
+	if err := tx.QueryRowContext(ctx, DeleteRecipeStepQuery, recipeId, recipeStepId).Scan(&stepNumber); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, ShiftRecipeStepsQuery, recipeId, stepNumber+1, -1); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func getRecipeStepIds(ctx context.Context, tx *sql.Tx, recipeId int64) (map[int64]bool, error) {
+	recipeStepIds := make(map[int64]bool)
+	rows, err := tx.QueryContext(ctx, GetRecipeStepsByRecipeIdQuery, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeStep entity.RecipeStep
+		if err := rows.Scan(&recipeStep.RecipeStepId, &recipeStep.StepNumber, &recipeStep.Instruction, &recipeStep.DurationSeconds, &recipeStep.StepImage); err != nil {
+			return nil, err
+		}
+		recipeStepIds[recipeStep.RecipeStepId] = true
+	}
+	return recipeStepIds, rows.Err()
+}
+
+// Recipe Nutritions
+func (rr *recipeRepository) GetRecipeIds(ctx context.Context) ([]int64, error) {
+	var recipeIds []int64
+	rows, err := rr.dbConn.QueryContext(ctx, GetRecipeIdsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeId int64
+		if err := rows.Scan(&recipeId); err != nil {
+			return nil, err
+		}
+		recipeIds = append(recipeIds, recipeId)
+	}
+	return recipeIds, rows.Err()
+}
+
+// UpsertIngredientNutritions creates or replaces the nutritions by name and links the ingredients without nutrition to them
+func (rr *recipeRepository) UpsertIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	for _, ingredientNutrition := range ingredientNutritions {
+		if _, err := tx.ExecContext(ctx, UpsertIngredientNutritionQuery,
+			ingredientNutrition.Name,
+			pq.Array(ingredientNutrition.Aliases),
+			ingredientNutrition.Calories,
+			ingredientNutrition.Protein,
+			ingredientNutrition.Fat,
+			ingredientNutrition.Carbohydrate,
+			ingredientNutrition.Sodium,
+			ingredientNutrition.GramsPerMl,
+			ingredientNutrition.GramsPerUnit,
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, LinkRecipeIngredientNutritionsQuery); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetIngredientNutritionsByRecipeId returns the nutrition of the linked ingredients of a recipe by recipe_ingredient_id
+func (rr *recipeRepository) GetIngredientNutritionsByRecipeId(ctx context.Context, recipeId int64) (map[int64]entity.IngredientNutrition, error) {
+	ingredientNutritions := make(map[int64]entity.IngredientNutrition)
+	rows, err := rr.dbConn.QueryContext(ctx, GetIngredientNutritionsByRecipeIdQuery, recipeId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeIngredientId int64
+		var ingredientNutrition entity.IngredientNutrition
+		if err := rows.Scan(
+			&recipeIngredientId,
+			&ingredientNutrition.NutritionId,
+			&ingredientNutrition.Name,
+			&ingredientNutrition.Calories,
+			&ingredientNutrition.Protein,
+			&ingredientNutrition.Fat,
+			&ingredientNutrition.Carbohydrate,
+			&ingredientNutrition.Sodium,
+			&ingredientNutrition.GramsPerMl,
+			&ingredientNutrition.GramsPerUnit,
+		); err != nil {
+			return nil, err
+		}
+		ingredientNutritions[recipeIngredientId] = ingredientNutrition
+	}
+	return ingredientNutritions, rows.Err()
+}
+
+func (rr *recipeRepository) UpsertRecipeNutrition(ctx context.Context, recipeId int64, recipeNutrition *entity.RecipeNutrition) error {
+	row := rr.dbConn.QueryRowContext(ctx, UpsertRecipeNutritionQuery,
+		recipeId,
+		recipeNutrition.Calories,
+		recipeNutrition.Protein,
+		recipeNutrition.Fat,
+		recipeNutrition.Carbohydrate,
+		recipeNutrition.Sodium,
+		recipeNutrition.IsComplete,
+	)
+	if err := row.Scan(&recipeNutrition.UpdatedAt); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23503" { // foreign key violation, recipe does not exist
+				return sql.ErrNoRows
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// getRecipeNutritionByRecipeId returns nil when the nutrition of the recipe has not been computed
+func (rr *recipeRepository) getRecipeNutritionByRecipeId(ctx context.Context, recipeId int64) (*entity.RecipeNutrition, error) {
+	var recipeNutrition entity.RecipeNutrition
+	row := rr.dbConn.QueryRowContext(ctx, GetRecipeNutritionByRecipeIdQuery, recipeId)
+	if err := row.Scan(
+		&recipeNutrition.Calories,
+		&recipeNutrition.Protein,
+		&recipeNutrition.Fat,
+		&recipeNutrition.Carbohydrate,
+		&recipeNutrition.Sodium,
+		&recipeNutrition.IsComplete,
+		&recipeNutrition.UpdatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &recipeNutrition, nil
+}
+
+// Recipe Ratings
+func (rr *recipeRepository) CreateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	result, err := tx.ExecContext(ctx, CreateRecipeRatingQuery, recipeId, userId, rating)
+	if err != nil {
+		tx.Rollback()
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23503" { // foreign key violation, recipe does not exist
+				return sql.ErrNoRows
+			}
+		}
+		return err
+	}
+	// nothing is inserted when the recipe does not exist or is a draft
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+	tx.Commit()
+	return nil
+}
+
+func (rr *recipeRepository) UpdateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error {
+	result, err := rr.dbConn.ExecContext(ctx, UpdateRecipeRatingQuery, recipeId, userId, rating)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (rr *recipeRepository) DeleteRecipeRating(ctx context.Context, recipeId, userId int64) error {
+	result, err := rr.dbConn.ExecContext(ctx, DeleteRecipeRatingQuery, recipeId, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (rr *recipeRepository) GetRecipeRatingByUserId(ctx context.Context, recipeId, userId int64) (*entity.RecipeRating, error) {
+	var recipeRating entity.RecipeRating
+	row := rr.dbConn.QueryRowContext(ctx, GetRecipeRatingByUserIdQuery, recipeId, userId)
+	if err := row.Scan(&recipeRating.RecipeId, &recipeRating.UserId, &recipeRating.RecipeRating, &recipeRating.CreatedAt, &recipeRating.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return &recipeRating, nil
+}
+
+func (rr *recipeRepository) GetRecipeRatingSummary(ctx context.Context, recipeId int64) (float64, int, error) {
+	var ratingAvg float64
+	var ratingCount int
+	row := rr.dbConn.QueryRowContext(ctx, GetRecipeRatingSummaryQuery, recipeId)
+	if err := row.Scan(&ratingAvg, &ratingCount); err != nil {
+		return 0, 0, err
+	}
+	return ratingAvg, ratingCount, nil
+}
+
+// Search Synonyms
+func (rr *recipeRepository) GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error) {
+	rows, err := rr.dbConn.QueryContext(ctx, GetSearchSynonymsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	searchSynonyms := []entity.SearchSynonym{}
+	for rows.Next() {
+		var searchSynonym entity.SearchSynonym
+		if err := rows.Scan(&searchSynonym.SearchSynonymId, &searchSynonym.Term, &searchSynonym.Replacement, &searchSynonym.CreatedAt, &searchSynonym.UpdatedAt); err != nil {
+			return nil, err
+		}
+		searchSynonyms = append(searchSynonyms, searchSynonym)
+	}
+	return searchSynonyms, rows.Err()
+}
+
+func (rr *recipeRepository) CreateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error {
+	row := rr.dbConn.QueryRowContext(ctx, CreateSearchSynonymQuery, searchSynonym.Term, searchSynonym.Replacement)
+	if err := row.Scan(&searchSynonym.SearchSynonymId, &searchSynonym.CreatedAt, &searchSynonym.UpdatedAt); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation, term already exists
+				return domain.ErrDuplicateSearchSynonym
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (rr *recipeRepository) UpdateSearchSynonym(ctx context.Context, searchSynonym *entity.SearchSynonym) error {
+	row := rr.dbConn.QueryRowContext(ctx, UpdateSearchSynonymQuery, searchSynonym.SearchSynonymId, searchSynonym.Term, searchSynonym.Replacement)
+	if err := row.Scan(&searchSynonym.CreatedAt, &searchSynonym.UpdatedAt); err != nil {
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation, term already exists
+				return domain.ErrDuplicateSearchSynonym
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (rr *recipeRepository) DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error {
+	result, err := rr.dbConn.ExecContext(ctx, DeleteSearchSynonymQuery, searchSynonymId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpsertSearchSynonyms creates or replaces the synonyms by term
+func (rr *recipeRepository) UpsertSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error {
+	tx, err := rr.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	for _, searchSynonym := range searchSynonyms {
+		if _, err := tx.ExecContext(ctx, UpsertSearchSynonymQuery, searchSynonym.Term, searchSynonym.Replacement); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
 }
@@ -0,0 +1,894 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/search"
+)
+
+func TestRecipeRepository_CreateRecipe(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test create recipe with ingredients", func(t *testing.T) {
+		recipe := &entity.Recipe{
+			Title:                "Nasi Goreng Kampung",
+			Slug:                 "nasi-goreng-kampung",
+			Header:               "Nasi goreng sederhana ala rumahan",
+			ImagePreview:         "https://example.com/nasi_goreng.jpg",
+			CategoryId:           1,
+			EstimatedTimeMinutes: 20,
+			RecipeIngredients: []entity.RecipeIngredient{
+				{Name: "nasi putih", Quantity: 2, Unit: "piring", Position: 1},
+				{Name: "bawang merah", Quantity: 5, Unit: "siung", Group: "Bumbu halus", Position: 2},
+			},
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("nasi-goreng-kampung").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeSlugsQuery)).
+			WithArgs("nasi-goreng-kampung", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("nasi-goreng-kampung").AddRow("nasi-goreng-kampung-2"))
+		rows := sqlmock.NewRows([]string{"recipe_id", "created_at", "updated_at"}).AddRow(1, time.Now().UTC(), time.Now().UTC())
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeQuery)).WillReturnRows(rows)
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeIngredientQuery)).
+			WithArgs(int64(1), 1, "nasi putih", float64(2), "piring", "", "").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeIngredientQuery)).
+			WithArgs(int64(1), 2, "bawang merah", float64(5), "siung", "", "Bumbu halus").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.CreateRecipe(context.Background(), recipe)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), recipe.RecipeId) // recipe_id should be returned into the recipe
+		assert.Equal(t, "nasi-goreng-kampung-3", recipe.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_CreateRecipeWithTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test create recipe with unknown tag", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("rendang-daging").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeSlugsQuery)).
+			WithArgs("rendang-daging", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		rows := sqlmock.NewRows([]string{"recipe_id", "created_at", "updated_at"}).AddRow(1, time.Now().UTC(), time.Now().UTC())
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeQuery)).WillReturnRows(rows)
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeTagsQuery)).
+			WithArgs(int64(1), "{3,99}").
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+		err := recipeRepository.CreateRecipe(context.Background(), &entity.Recipe{
+			Title: "Rendang Daging",
+			Slug:  "rendang-daging",
+			Tags:  []entity.Tag{{TagId: 3}, {TagId: 99}},
+		})
+		assert.ErrorIs(t, err, domain.ErrUnknownTag)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_UpdateRecipeById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test update recipe fields keeps recipe_id as the first argument", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("title = $2, estimated_time_minutes = $3 WHERE recipe_id = $1")).
+			WithArgs(int64(1), "Nasi Goreng Spesial", 25).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.UpdateRecipeById(context.Background(), 1, &domain.UpdateRecipeByIdQueryFilter{
+			Title:                "Nasi Goreng Spesial",
+			EstimatedTimeMinutes: 25,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rename recipe back to its former title", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeSlugForUpdateQuery)).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("nasi-goreng-spesial"))
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("nasi-goreng").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeSlugsQuery)).
+			WithArgs("nasi-goreng", int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("nasi-goreng-kampung"))
+		mock.ExpectExec(regexp.QuoteMeta("title = $2, slug = $3 WHERE recipe_id = $1")).
+			WithArgs(int64(1), "Nasi Goreng", "nasi-goreng").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeSlugRedirectQuery)).
+			WithArgs("nasi-goreng").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeSlugRedirectQuery)).
+			WithArgs("nasi-goreng-spesial", int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.UpdateRecipeById(context.Background(), 1, &domain.UpdateRecipeByIdQueryFilter{
+			Title: "Nasi Goreng",
+			Slug:  "nasi-goreng",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test update recipe replaces ingredients", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("WHERE recipe_id = $1")).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeIngredientsByRecipeIdQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeIngredientQuery)).WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.UpdateRecipeById(context.Background(), 1, &domain.UpdateRecipeByIdQueryFilter{
+			RecipeIngredients: []entity.RecipeIngredient{{Name: "telur", Quantity: 2, Unit: "butir", Position: 1}},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test update recipe removes every tag", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("WHERE recipe_id = $1")).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeTagsByRecipeIdQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		err := recipeRepository.UpdateRecipeById(context.Background(), 1, &domain.UpdateRecipeByIdQueryFilter{
+			Tags: []entity.Tag{},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test update unknown recipe", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("WHERE recipe_id = $1")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback() // nothing updated, ingredients should not be touched
+		err := recipeRepository.UpdateRecipeById(context.Background(), 99, &domain.UpdateRecipeByIdQueryFilter{
+			Title:             "Nasi Goreng Spesial",
+			RecipeIngredients: []entity.RecipeIngredient{{Name: "telur", Position: 1}},
+		})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_DeleteRecipeById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test delete recipe", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeByIdQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := recipeRepository.DeleteRecipeById(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test delete unknown recipe", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeByIdQuery)).WithArgs(int64(99)).WillReturnResult(sqlmock.NewResult(0, 0))
+		err := recipeRepository.DeleteRecipeById(context.Background(), 99)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_UpdateRecipePublished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test publish recipe", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(UpdateRecipePublishedQuery)).WithArgs(int64(1), true).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := recipeRepository.UpdateRecipePublished(context.Background(), 1, true)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test unpublish unknown recipe", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(UpdateRecipePublishedQuery)).WithArgs(int64(99), false).WillReturnResult(sqlmock.NewResult(0, 0))
+		err := recipeRepository.UpdateRecipePublished(context.Background(), 99, false)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rate a draft", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeRatingQuery)).WithArgs(int64(2), int64(5), 4).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		err := recipeRepository.CreateRecipeRating(context.Background(), 2, 5, 4)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_CreateRecipeStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+	stepRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"recipe_step_id", "step_number", "instruction", "duration_seconds", "step_image"}).
+			AddRow(10, 1, "Tumis bumbu halus", 180, "").
+			AddRow(11, 2, "Masukkan nasi", 0, "")
+	}
+
+	t.Run("test insert step in the middle shifts the following steps", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(LockRecipeByIdQuery)).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"recipe_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeStepsByRecipeIdQuery)).WithArgs(int64(1)).WillReturnRows(stepRows())
+		mock.ExpectExec(regexp.QuoteMeta(ShiftRecipeStepsQuery)).WithArgs(int64(1), 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeStepQuery)).
+			WithArgs(int64(1), 2, "Masukkan telur", 0, "").
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_step_id"}).AddRow(12))
+		mock.ExpectCommit()
+		recipeStep := &entity.RecipeStep{Instruction: "Masukkan telur", StepNumber: 2}
+		err := recipeRepository.CreateRecipeStep(context.Background(), 1, recipeStep)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), recipeStep.RecipeStepId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test step number past the last step appends the step", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(LockRecipeByIdQuery)).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"recipe_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeStepsByRecipeIdQuery)).WithArgs(int64(1)).WillReturnRows(stepRows())
+		mock.ExpectExec(regexp.QuoteMeta(ShiftRecipeStepsQuery)).WithArgs(int64(1), 3, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeStepQuery)).
+			WithArgs(int64(1), 3, "Sajikan", 0, "").
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_step_id"}).AddRow(13))
+		mock.ExpectCommit()
+		err := recipeRepository.CreateRecipeStep(context.Background(), 1, &entity.RecipeStep{Instruction: "Sajikan", StepNumber: 99})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test insert step into unknown recipe", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(LockRecipeByIdQuery)).WithArgs(int64(99)).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+		err := recipeRepository.CreateRecipeStep(context.Background(), 99, &entity.RecipeStep{Instruction: "Sajikan"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_ReorderRecipeSteps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test reorder without every step of the recipe", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(LockRecipeByIdQuery)).WithArgs(int64(1)).WillReturnRows(sqlmock.NewRows([]string{"recipe_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeStepsByRecipeIdQuery)).WithArgs(int64(1)).WillReturnRows(
+			sqlmock.NewRows([]string{"recipe_step_id", "step_number", "instruction", "duration_seconds", "step_image"}).
+				AddRow(10, 1, "Tumis bumbu halus", 180, "").
+				AddRow(11, 2, "Masukkan nasi", 0, ""))
+		mock.ExpectRollback()
+		err := recipeRepository.ReorderRecipeSteps(context.Background(), 1, []int64{11})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_GetRecipes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test search recipes by prefix and name", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery+" WHERE search_vector @@ to_tsquery('simple', $1) AND title ILIKE '%' || $2 || '%' AND published_at IS NOT NULL")).
+			WithArgs("nasi:* & gor:*", `100\%`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "search_rank", "sort_value", "ts_headline"}
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 1, "Nasi Goreng Kampung", "nasi-goreng-kampung", "Nasi goreng sederhana", "https://example.com/nasi_goreng.jpg", "", 20, 2, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), 0.6, 0.6, "<mark>Nasi</mark> <mark>goreng</mark> sederhana")
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE search_vector @@ to_tsquery('simple', $1) AND title ILIKE '%' || $2 || '%' AND published_at IS NOT NULL ORDER BY sort_value DESC, recipe_id DESC LIMIT $3")).
+			WithArgs("nasi:* & gor:*", `100\%`, 11).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeIngredientsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_ingredient_id", "recipe_id", "position", "name", "quantity", "unit", "note", "ingredient_group"}))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeTagsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "tag_id", "tag_name"}).AddRow(1, 3, "Lebaran"))
+		recipePage, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			SearchQuery: "Nasi, gor!",
+			Name:        "100%",
+			Sort:        domain.SortRelevance,
+			Limit:       10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, recipePage.Recipes, 1)
+		assert.Equal(t, 1, recipePage.Total)
+		assert.False(t, recipePage.HasMore)
+		assert.Empty(t, recipePage.NextCursor)
+		assert.Equal(t, 0.6, recipePage.Recipes[0].SearchRank)
+		assert.Equal(t, "<mark>Nasi</mark> <mark>goreng</mark> sederhana", recipePage.Recipes[0].SearchHighlight)
+		assert.Equal(t, []entity.Tag{{TagId: 3, TagName: "Lebaran"}}, recipePage.Recipes[0].Tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test fuzzy search recipes by alternatives and title similarity", func(t *testing.T) {
+		getRecipesQueryFilter := &domain.GetRecipesQueryFilter{
+			Fuzzy:       true,
+			FuzzySearch: search.Expansion{Phrase: "nasi goreng", Alternatives: []string{"nasgor", "nasi goreng"}},
+			FuzzyName:   search.Expansion{Phrase: "soto ayam lamogan", Alternatives: []string{"soto ayam lamogan"}},
+			Sort:        domain.SortRelevance,
+			Limit:       10,
+		}
+		conditions := " WHERE (search_vector @@ to_tsquery('simple', $1) OR $2 <% lower(title)) AND (lower(title) LIKE ANY($3) OR $4 <% lower(title)) AND published_at IS NOT NULL"
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery+conditions)).
+			WithArgs("(nasgor:*) | (nasi:* & goreng:*)", "nasi goreng", `{"%soto ayam lamogan%"}`, "soto ayam lamogan").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "search_rank", "sort_value", "ts_headline"}
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, 1, "Nasi Goreng Kampung", "nasi-goreng-kampung", "Nasi goreng sederhana", "https://example.com/nasi_goreng.jpg", "", 20, 2, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), 0.6, 1.6, "<mark>Nasi</mark> <mark>goreng</mark> sederhana")
+		mock.ExpectQuery(regexp.QuoteMeta("+ word_similarity($2, lower(title)) AS sort_value")+"(?s).*"+regexp.QuoteMeta(conditions+" ORDER BY sort_value DESC, recipe_id DESC LIMIT $5")).
+			WithArgs("(nasgor:*) | (nasi:* & goreng:*)", "nasi goreng", `{"%soto ayam lamogan%"}`, "soto ayam lamogan", 11).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeIngredientsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_ingredient_id", "recipe_id", "position", "name", "quantity", "unit", "note", "ingredient_group"}))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeTagsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "tag_id", "tag_name"}))
+		recipePage, err := recipeRepository.GetRecipes(context.Background(), getRecipesQueryFilter)
+		assert.NoError(t, err)
+		assert.Len(t, recipePage.Recipes, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test filter recipes by time, creation date and image", func(t *testing.T) {
+		createdAfter := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+		createdBefore := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+		hasImage := true
+		conditions := " WHERE estimated_time_minutes >= $1 AND estimated_time_minutes <= $2 AND created_at >= $3 AND created_at < $4 AND image_preview <> '' AND published_at IS NOT NULL"
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery+conditions)).
+			WithArgs(15, 45, createdAfter, createdBefore).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "sort_value"}
+		mock.ExpectQuery(regexp.QuoteMeta(conditions+" ORDER BY sort_value DESC, recipe_id DESC LIMIT $5")).
+			WithArgs(15, 45, createdAfter, createdBefore, 11).
+			WillReturnRows(sqlmock.NewRows(columns))
+		recipePage, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			MinTime:       15,
+			MaxTime:       45,
+			CreatedAfter:  createdAfter,
+			CreatedBefore: createdBefore,
+			HasImage:      &hasImage,
+			Sort:          domain.SortNewest,
+			Limit:         10,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, recipePage.Recipes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test filter recipes with every tag", func(t *testing.T) {
+		conditions := " WHERE recipe_id IN (SELECT rt.recipe_id FROM recipe_tags rt JOIN tags t ON t.tag_id = rt.tag_id WHERE lower(t.tag_name) = ANY($1) GROUP BY rt.recipe_id HAVING count(*) = $2) AND published_at IS NOT NULL"
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery+conditions)).
+			WithArgs(`{"lebaran","daging"}`, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "sort_value"}
+		mock.ExpectQuery(regexp.QuoteMeta(conditions+" ORDER BY sort_value DESC, recipe_id DESC LIMIT $3")).
+			WithArgs(`{"lebaran","daging"}`, 2, 11).
+			WillReturnRows(sqlmock.NewRows(columns))
+		_, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			Tags:    []string{"lebaran", "daging"},
+			TagMode: domain.TagModeAnd,
+			Sort:    domain.SortNewest,
+			Limit:   10,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test continue quickest recipes after cursor", func(t *testing.T) {
+		cursor := encodeRecipeCursor(&recipeCursor{Sort: domain.SortQuickest, Value: "15", RecipeId: 4})
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery + " WHERE category_id = $1 AND published_at IS NOT NULL")).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "sort_value"}
+		rows := sqlmock.NewRows(columns).
+			AddRow(7, 2, "Sop Ayam", "sop-ayam", "Sop ayam bening", "https://example.com/sop_ayam.jpg", "", 15, 4, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), "15").
+			AddRow(2, 2, "Soto Ayam", "soto-ayam", "Soto ayam kuning", "https://example.com/soto_ayam.jpg", "", 30, 4, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), "30").
+			AddRow(3, 2, "Rawon", "rawon", "Rawon daging", "https://example.com/rawon.jpg", "", 90, 4, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), "90")
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE category_id = $1 AND published_at IS NOT NULL AND (estimated_time_minutes, recipe_id) > ($2::integer, $3) ORDER BY sort_value, recipe_id LIMIT $4")).
+			WithArgs(int64(2), "15", int64(4), 3).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeIngredientsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_ingredient_id", "recipe_id", "position", "name", "quantity", "unit", "note", "ingredient_group"}))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeTagsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "tag_id", "tag_name"}))
+		recipePage, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			CategoryId: 2,
+			Sort:       domain.SortQuickest,
+			Cursor:     cursor,
+			Limit:      2,
+			Offset:     20, // ignored with a cursor
+		})
+		assert.NoError(t, err)
+		assert.Len(t, recipePage.Recipes, 2)
+		assert.Equal(t, 5, recipePage.Total)
+		assert.True(t, recipePage.HasMore)
+		nextCursor, err := decodeRecipeCursor(recipePage.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, &recipeCursor{Sort: domain.SortQuickest, Value: "30", RecipeId: 2}, nextCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test cursor of another sort", func(t *testing.T) {
+		cursor := encodeRecipeCursor(&recipeCursor{Sort: domain.SortQuickest, Value: "15", RecipeId: 4})
+		_, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			Sort:   domain.SortNewest,
+			Cursor: cursor,
+			Limit:  2,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+		_, err = recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			Sort:   domain.SortNewest,
+			Cursor: "not a cursor",
+			Limit:  2,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test cursor with a value that cannot be cast", func(t *testing.T) {
+		for _, cursor := range []*recipeCursor{
+			{Sort: domain.SortNewest, Value: "x", RecipeId: 1},
+			{Sort: domain.SortQuickest, Value: "15.5", RecipeId: 1},
+			{Sort: domain.SortTopRated, Value: "0x1p2", RecipeId: 1},
+			{Sort: domain.SortTopRated, Value: "NaN", RecipeId: 1},
+		} {
+			_, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+				Sort:   cursor.Sort,
+				Cursor: encodeRecipeCursor(cursor),
+				Limit:  2,
+			})
+			assert.ErrorIs(t, err, domain.ErrInvalidCursor, cursor.Value)
+		}
+		// no query runs with an invalid cursor
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_GetRecipeFacets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test each facet ignores its own filter", func(t *testing.T) {
+		getRecipesQueryFilter := &domain.GetRecipesQueryFilter{CategoryId: 1, TimeBucket: "15_30", MinRating: 4}
+		mock.ExpectQuery(regexp.QuoteMeta("FROM (SELECT category_id FROM recipes  WHERE estimated_time_minutes >= $1 AND estimated_time_minutes < $2 AND recipe_id IN")).
+			WithArgs(15, 30, 4).
+			WillReturnRows(sqlmock.NewRows([]string{"category_id", "category_tag", "count"}).AddRow(1, "Nasi", 3).AddRow(2, "Sup", 1))
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE category_id = $1 AND recipe_id IN")).
+			WithArgs(int64(1), 4).
+			WillReturnRows(sqlmock.NewRows([]string{"estimated_time_minutes", "count"}).AddRow(10, 1).AddRow(20, 2).AddRow(25, 1).AddRow(90, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE recipe_id IN (SELECT recipe_id FROM recipes  WHERE category_id = $1 AND estimated_time_minutes >= $2 AND estimated_time_minutes < $3 AND published_at IS NOT NULL)")).
+			WithArgs(int64(1), 15, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"floor", "count"}).AddRow(5, 1).AddRow(4, 2).AddRow(2, 1))
+		recipeFacets, err := recipeRepository.GetRecipeFacets(context.Background(), getRecipesQueryFilter)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.CategoryFacet{{CategoryId: 1, CategoryTag: "Nasi", Count: 3}, {CategoryId: 2, CategoryTag: "Sup", Count: 1}}, recipeFacets.Categories)
+		assert.Equal(t, []entity.TimeBucketFacet{{TimeBucket: "under_15", Count: 1}, {TimeBucket: "15_30", Count: 3}, {TimeBucket: "30_60", Count: 0}, {TimeBucket: "60_plus", Count: 1}}, recipeFacets.TimeBuckets)
+		assert.Equal(t, []entity.RatingFacet{{MinRating: 4, Count: 3}, {MinRating: 3, Count: 3}, {MinRating: 2, Count: 4}, {MinRating: 1, Count: 4}}, recipeFacets.MinRatings)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_GetRecipesByIngredients(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test get recipes by available ingredients", func(t *testing.T) {
+		columns := []string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "matched_ingredients", "missing_ingredients", "matched_count", "missing_count"}
+		rows := sqlmock.NewRows(columns).
+			AddRow(2, 1, "Telur Dadar Bawang", "telur-dadar-bawang", "Telur dadar sederhana", "https://example.com/telur_dadar.jpg", "", 10, 1, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), "{telur,\"daun bawang\"}", nil, 2, 0).
+			AddRow(1, 1, "Nasi Goreng Kampung", "nasi-goreng-kampung", "Nasi goreng sederhana", "https://example.com/nasi_goreng.jpg", "", 20, 2, time.Now().UTC(), time.Now().UTC(), time.Now().UTC(), "{\"nasi putih\"}", "{kecap}", 1, 1)
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipesByIngredientsQuery)).
+			WithArgs(`{"telur%","% telur%","bawang%","% bawang%"}`, `{"udang\\_%","% udang\\_%"}`, 10, 0).
+			WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeIngredientsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_ingredient_id", "recipe_id", "position", "name", "quantity", "unit", "note", "ingredient_group"}).
+				AddRow(1, 1, 1, "nasi putih", 2, "piring", "", "").
+				AddRow(2, 1, 2, "kecap", 1, "sdm", "", "").
+				AddRow(3, 2, 1, "telur", 2, "butir", "", "").
+				AddRow(4, 2, 2, "daun bawang", 1, "batang", "", ""))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeTagsByRecipeIdsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "tag_id", "tag_name"}).AddRow(1, 3, "Lebaran"))
+		recipeMatches, err := recipeRepository.GetRecipesByIngredients(context.Background(), &domain.GetRecipesByIngredientsQueryFilter{
+			Have:    []string{"telur", "Bawang"},
+			Exclude: []string{"udang_"},
+			Limit:   10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, recipeMatches, 2)
+		assert.Equal(t, int64(2), recipeMatches[0].Recipe.RecipeId)
+		assert.Equal(t, []string{"telur", "daun bawang"}, recipeMatches[0].MatchedIngredients)
+		assert.Equal(t, []string{}, recipeMatches[0].MissingIngredients)
+		assert.Len(t, recipeMatches[0].Recipe.RecipeIngredients, 2)
+		assert.Equal(t, []string{"kecap"}, recipeMatches[1].MissingIngredients)
+		assert.Equal(t, 1, recipeMatches[1].MissingCount)
+		assert.Equal(t, []entity.Tag{}, recipeMatches[0].Recipe.Tags)
+		assert.Equal(t, []entity.Tag{{TagId: 3, TagName: "Lebaran"}}, recipeMatches[1].Recipe.Tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_GetSuggestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test suggestions are matched by lowercased prefix", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetSuggestionsQuery)).
+			WithArgs("nas", "nas%", "% nas%", 5).
+			WillReturnRows(sqlmock.NewRows([]string{"suggestion_type", "id", "text", "recipe_count", "score"}).
+				AddRow("recipe", 1, "Nasi Goreng Kampung", 0, 0.75).
+				AddRow("category", 3, "Nasi", 12, 1).
+				AddRow("ingredient", 0, "nasi putih", 4, 0.6))
+		suggestions, err := recipeRepository.GetSuggestions(context.Background(), "Nas", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Suggestion{
+			{Type: "recipe", Id: 1, Text: "Nasi Goreng Kampung", Score: 0.75},
+			{Type: "category", Id: 3, Text: "Nasi", RecipeCount: 12, Score: 1},
+			{Type: "ingredient", Text: "nasi putih", RecipeCount: 4, Score: 0.6},
+		}, suggestions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestToPrefixTsQuery(t *testing.T) {
+	assert.Equal(t, "nasi:* & goreng:*", toPrefixTsQuery("  Nasi   GORENG "))
+	assert.Equal(t, "ayam:* & bakar:* & 2:*", toPrefixTsQuery("ayam-bakar (2)"))
+	assert.Equal(t, "", toPrefixTsQuery("' & !:*"))
+}
+
+func TestRecipeRepository_SearchSynonyms(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test create search synonym", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(CreateSearchSynonymQuery)).
+			WithArgs("fried rice", "nasi goreng").
+			WillReturnRows(sqlmock.NewRows([]string{"search_synonym_id", "created_at", "updated_at"}).AddRow(3, time.Now().UTC(), time.Now().UTC()))
+		searchSynonym := &entity.SearchSynonym{Term: "fried rice", Replacement: "nasi goreng"}
+		err := recipeRepository.CreateSearchSynonym(context.Background(), searchSynonym)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), searchSynonym.SearchSynonymId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test create duplicate search synonym", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(CreateSearchSynonymQuery)).
+			WithArgs("fried rice", "nasi goreng").
+			WillReturnError(&pq.Error{Code: "23505"})
+		err := recipeRepository.CreateSearchSynonym(context.Background(), &entity.SearchSynonym{Term: "fried rice", Replacement: "nasi goreng"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateSearchSynonym)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test update unknown search synonym", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(UpdateSearchSynonymQuery)).
+			WithArgs(int64(99), "nasgor", "nasi goreng").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}))
+		err := recipeRepository.UpdateSearchSynonym(context.Background(), &entity.SearchSynonym{SearchSynonymId: 99, Term: "nasgor", Replacement: "nasi goreng"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test upsert search synonyms", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(UpsertSearchSynonymQuery)).WithArgs("nasgor", "nasi goreng").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(UpsertSearchSynonymQuery)).WithArgs("chicken", "ayam").WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.UpsertSearchSynonyms(context.Background(), []entity.SearchSynonym{
+			{Term: "nasgor", Replacement: "nasi goreng"},
+			{Term: "chicken", Replacement: "ayam"},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_RecipeCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+	columns := []string{"category_id", "category_tag", "slug", "parent_category_id", "description", "cover_image", "display_order", "is_featured", "recipe_count"}
+
+	t.Run("test create sub category", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("jawa-timur").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeCategorySlugsQuery)).
+			WithArgs("jawa-timur", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeCategoryQuery)).
+			WithArgs("Jawa Timur", int64(2), "Masakan khas Jawa Timur", "https://example.com/jawa_timur.jpg", 2, true, "jawa-timur").
+			WillReturnRows(sqlmock.NewRows([]string{"category_id"}).AddRow(3))
+		mock.ExpectCommit()
+		recipeCategory := &entity.RecipeCategory{
+			CategoryTag:      "Jawa Timur",
+			Slug:             "jawa-timur",
+			Description:      "Masakan khas Jawa Timur",
+			CoverImage:       "https://example.com/jawa_timur.jpg",
+			ParentCategoryId: 2,
+			DisplayOrder:     2,
+			IsFeatured:       true,
+		}
+		err := recipeRepository.CreateRecipeCategory(context.Background(), recipeCategory)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), recipeCategory.CategoryId)
+		assert.Equal(t, "jawa-timur", recipeCategory.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test create category under unknown parent", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("jawa-timur").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeCategorySlugsQuery)).
+			WithArgs("jawa-timur", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeCategoryQuery)).
+			WithArgs("Jawa Timur", int64(99), "", "", 0, false, "jawa-timur").
+			WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+		err := recipeRepository.CreateRecipeCategory(context.Background(), &entity.RecipeCategory{CategoryTag: "Jawa Timur", Slug: "jawa-timur", ParentCategoryId: 99})
+		assert.ErrorIs(t, err, domain.ErrUnknownParentCategory)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test create duplicate category", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("jawa-timur").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeCategorySlugsQuery)).
+			WithArgs("jawa-timur", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRecipeCategoryQuery)).
+			WithArgs("jawa timur", int64(0), "", "", 0, false, "jawa-timur").
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+		err := recipeRepository.CreateRecipeCategory(context.Background(), &entity.RecipeCategory{CategoryTag: "jawa timur", Slug: "jawa-timur"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateCategory)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test move category below its own sub category", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockRecipeCategoriesQuery)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(IsRecipeCategoryAncestorQuery)).
+			WithArgs(int64(3), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+		err := recipeRepository.UpdateRecipeCategory(context.Background(), &entity.RecipeCategory{CategoryId: 1, CategoryTag: "Masakan Nusantara", ParentCategoryId: 3})
+		assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rename category to an existing tag", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategorySlugForUpdateQuery)).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("jawa-barat"))
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("jawa").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeCategorySlugsQuery)).
+			WithArgs("jawa", int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("jawa"))
+		mock.ExpectExec(regexp.QuoteMeta(UpdateRecipeCategoryQuery)).
+			WithArgs(int64(4), "JAWA", int64(0), "", "", 0, false, "jawa-2").
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+		err := recipeRepository.UpdateRecipeCategory(context.Background(), &entity.RecipeCategory{CategoryId: 4, CategoryTag: "JAWA", Slug: "jawa"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateCategory)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rename category redirects its former slug", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategorySlugForUpdateQuery)).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("kue"))
+		mock.ExpectExec(regexp.QuoteMeta(LockSlugQuery)).
+			WithArgs("kue-basah").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetTakenRecipeCategorySlugsQuery)).
+			WithArgs("kue-basah", int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectExec(regexp.QuoteMeta(UpdateRecipeCategoryQuery)).
+			WithArgs(int64(4), "Kue Basah", int64(0), "", "", 0, false, "kue-basah").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeCategorySlugRedirectQuery)).
+			WithArgs("kue-basah").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(CreateRecipeCategorySlugRedirectQuery)).
+			WithArgs("kue", int64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		recipeCategory := &entity.RecipeCategory{CategoryId: 4, CategoryTag: "Kue Basah", Slug: "kue-basah"}
+		err := recipeRepository.UpdateRecipeCategory(context.Background(), recipeCategory)
+		assert.NoError(t, err)
+		assert.Equal(t, "kue-basah", recipeCategory.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test resolve former category slug", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(ResolveRecipeCategorySlugQuery)).
+			WithArgs("kue").
+			WillReturnRows(sqlmock.NewRows([]string{"category_id", "slug"}).AddRow(4, "kue-basah"))
+		categoryId, currentSlug, err := recipeRepository.ResolveRecipeCategorySlug(context.Background(), "kue")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), categoryId)
+		assert.Equal(t, "kue-basah", currentSlug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test delete category with reassignment", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockRecipeCategoriesQuery)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategoryParentIdQuery)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(RecipeCategoryExistsQuery)).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec(regexp.QuoteMeta(MoveRecipeCategoryChildrenQuery)).
+			WithArgs(int64(2), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(ReassignRecipesCategoryQuery)).
+			WithArgs(int64(2), int64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteRecipeCategoryQuery)).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := recipeRepository.DeleteRecipeCategory(context.Background(), 2, 4)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test delete category reassigning to an unknown category", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(LockRecipeCategoriesQuery)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategoryParentIdQuery)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"parent_category_id"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(RecipeCategoryExistsQuery)).
+			WithArgs(int64(99)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+		err := recipeRepository.DeleteRecipeCategory(context.Background(), 2, 99)
+		assert.ErrorIs(t, err, domain.ErrInvalidReassignCategory)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test get category with breadcrumbs and children", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategoryByIdQuery)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Jawa", "jawa", 1, "", "", 1, false, 4))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategoryBreadcrumbsQuery)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Masakan Nusantara", "masakan-nusantara", 0, "", "", 1, true, 0).AddRow(2, "Jawa", "jawa", 1, "", "", 1, false, 4))
+		mock.ExpectQuery(regexp.QuoteMeta(GetRecipeCategoryChildrenQuery)).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "Jawa Timur", "jawa-timur", 2, "Masakan khas Jawa Timur", "", 1, false, 7))
+		recipeCategory, err := recipeRepository.GetRecipeCategoryById(context.Background(), 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), recipeCategory.ParentCategoryId)
+		assert.Equal(t, []entity.RecipeCategory{
+			{CategoryId: 1, CategoryTag: "Masakan Nusantara", Slug: "masakan-nusantara", DisplayOrder: 1, IsFeatured: true},
+			{CategoryId: 2, CategoryTag: "Jawa", Slug: "jawa", ParentCategoryId: 1, DisplayOrder: 1, RecipeCount: 4},
+		}, recipeCategory.Breadcrumbs)
+		assert.Equal(t, []entity.RecipeCategory{
+			{CategoryId: 3, CategoryTag: "Jawa Timur", Slug: "jawa-timur", Description: "Masakan khas Jawa Timur", ParentCategoryId: 2, DisplayOrder: 1, RecipeCount: 7},
+		}, recipeCategory.Children)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test reorder categories", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(ReorderRecipeCategoriesQuery)).
+			WithArgs(`{3,1,2}`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+		err := recipeRepository.ReorderRecipeCategories(context.Background(), []int64{3, 1, 2})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test reorder unknown category", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(ReorderRecipeCategoriesQuery)).
+			WithArgs(`{3,99}`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+		err := recipeRepository.ReorderRecipeCategories(context.Background(), []int64{3, 99})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test list recipes of a category and its descendants", func(t *testing.T) {
+		conditions := " WHERE " + fmt.Sprintf(RecipeCategoryDescendantsConditionFormat, "$1") + " AND published_at IS NOT NULL"
+		mock.ExpectQuery(regexp.QuoteMeta(CountRecipesQuery + conditions)).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(conditions+" ORDER BY")).
+			WithArgs(int64(1), 11).
+			WillReturnRows(sqlmock.NewRows([]string{"recipe_id", "category_id", "title", "slug", "header", "image_preview", "description", "estimated_time_minutes", "servings", "created_at", "updated_at", "published_at", "search_rank", "sort_value", "ts_headline"}))
+		recipePage, err := recipeRepository.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			CategoryId:         1,
+			IncludeDescendants: true,
+			Sort:               domain.SortNewest,
+			Limit:              10,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, recipePage.Recipes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecipeRepository_Tags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	recipeRepository := NewRecipeRepository(db)
+
+	t.Run("test create tag", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(CreateTagQuery)).
+			WithArgs("Lebaran").
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id"}).AddRow(3))
+		tag := &entity.Tag{TagName: "Lebaran"}
+		err := recipeRepository.CreateTag(context.Background(), tag)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), tag.TagId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rename tag to an existing name", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(UpdateTagQuery)).
+			WithArgs(int64(4), "lebaran").
+			WillReturnError(&pq.Error{Code: "23505"})
+		err := recipeRepository.UpdateTag(context.Background(), &entity.Tag{TagId: 4, TagName: "lebaran"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateTag)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test delete unknown tag", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(DeleteTagQuery)).
+			WithArgs(int64(99)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := recipeRepository.DeleteTag(context.Background(), 99)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
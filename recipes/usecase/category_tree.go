@@ -0,0 +1,35 @@
+package usecase
+
+import "github.com/victorsantoso/endeus/entity"
+
+// buildRecipeCategoryTree nests the flat category list under their parents and returns the roots,
+// a category whose parent is missing from the list is treated as a root so it never disappears
+func buildRecipeCategoryTree(recipeCategories []entity.RecipeCategory) []entity.RecipeCategory {
+	known := make(map[int64]bool, len(recipeCategories))
+	for _, recipeCategory := range recipeCategories {
+		known[recipeCategory.CategoryId] = true
+	}
+	childrenOf := make(map[int64][]entity.RecipeCategory)
+	for _, recipeCategory := range recipeCategories {
+		parentCategoryId := recipeCategory.ParentCategoryId
+		if !known[parentCategoryId] {
+			parentCategoryId = 0
+		}
+		childrenOf[parentCategoryId] = append(childrenOf[parentCategoryId], recipeCategory)
+	}
+	visited := make(map[int64]bool, len(recipeCategories))
+	var attach func(parentCategoryId int64) []entity.RecipeCategory
+	attach = func(parentCategoryId int64) []entity.RecipeCategory {
+		var nodes []entity.RecipeCategory
+		for _, recipeCategory := range childrenOf[parentCategoryId] {
+			if visited[recipeCategory.CategoryId] {
+				continue
+			}
+			visited[recipeCategory.CategoryId] = true
+			recipeCategory.Children = attach(recipeCategory.CategoryId)
+			nodes = append(nodes, recipeCategory)
+		}
+		return nodes
+	}
+	return attach(0)
+}
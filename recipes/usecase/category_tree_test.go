@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+func TestBuildRecipeCategoryTree(t *testing.T) {
+	t.Run("nests children under their parents", func(t *testing.T) {
+		recipeCategories := []entity.RecipeCategory{
+			{CategoryId: 3, CategoryTag: "Jawa Timur", ParentCategoryId: 2},
+			{CategoryId: 4, CategoryTag: "Kue", ParentCategoryId: 0},
+			{CategoryId: 1, CategoryTag: "Masakan Nusantara", ParentCategoryId: 0},
+			{CategoryId: 2, CategoryTag: "Jawa", ParentCategoryId: 1},
+		}
+		tree := buildRecipeCategoryTree(recipeCategories)
+		assert.Equal(t, []entity.RecipeCategory{
+			{CategoryId: 4, CategoryTag: "Kue"},
+			{CategoryId: 1, CategoryTag: "Masakan Nusantara", Children: []entity.RecipeCategory{
+				{CategoryId: 2, CategoryTag: "Jawa", ParentCategoryId: 1, Children: []entity.RecipeCategory{
+					{CategoryId: 3, CategoryTag: "Jawa Timur", ParentCategoryId: 2},
+				}},
+			}},
+		}, tree)
+	})
+	t.Run("keeps a category with a missing parent as a root", func(t *testing.T) {
+		tree := buildRecipeCategoryTree([]entity.RecipeCategory{
+			{CategoryId: 5, CategoryTag: "Sambal", ParentCategoryId: 99},
+		})
+		assert.Equal(t, []entity.RecipeCategory{
+			{CategoryId: 5, CategoryTag: "Sambal", ParentCategoryId: 99},
+		}, tree)
+	})
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"math"
+
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/units"
+)
+
+// computeRecipeNutrition sums the nutrition of the measured ingredients and divides it by the servings of the recipe,
+// ingredientNutritions is keyed by recipe_ingredient_id. Unmeasured ingredients eg: "garam secukupnya" are ignored.
+func computeRecipeNutrition(recipe *entity.Recipe, ingredientNutritions map[int64]entity.IngredientNutrition) *entity.RecipeNutrition {
+	recipeNutrition := &entity.RecipeNutrition{IsComplete: true}
+	for _, recipeIngredient := range recipe.RecipeIngredients {
+		if recipeIngredient.Quantity <= 0 {
+			continue
+		}
+		ingredientNutrition, ok := ingredientNutritions[recipeIngredient.RecipeIngredientId]
+		if !ok {
+			recipeNutrition.IsComplete = false
+			continue
+		}
+		grams, ok := ingredientGrams(recipeIngredient, ingredientNutrition)
+		if !ok {
+			recipeNutrition.IsComplete = false
+			continue
+		}
+		// nutrition values are per 100 g
+		portion := grams / 100
+		recipeNutrition.Calories += ingredientNutrition.Calories * portion
+		recipeNutrition.Protein += ingredientNutrition.Protein * portion
+		recipeNutrition.Fat += ingredientNutrition.Fat * portion
+		recipeNutrition.Carbohydrate += ingredientNutrition.Carbohydrate * portion
+		recipeNutrition.Sodium += ingredientNutrition.Sodium * portion
+	}
+	servings := float64(recipe.Servings)
+	if servings < 1 {
+		servings = 1
+	}
+	recipeNutrition.Calories = roundNutrition(recipeNutrition.Calories / servings)
+	recipeNutrition.Protein = roundNutrition(recipeNutrition.Protein / servings)
+	recipeNutrition.Fat = roundNutrition(recipeNutrition.Fat / servings)
+	recipeNutrition.Carbohydrate = roundNutrition(recipeNutrition.Carbohydrate / servings)
+	recipeNutrition.Sodium = roundNutrition(recipeNutrition.Sodium / servings)
+	return recipeNutrition
+}
+
+// ingredientGrams weighs an ingredient, mass units are converted directly, volume units use the density of the ingredient
+// and anything else (eg: butir, buah or no unit) is counted in pieces of GramsPerUnit.
+// Approximate units (eg: siung) are also counted in pieces of GramsPerUnit when the ingredient has one, their estimate otherwise
+func ingredientGrams(recipeIngredient entity.RecipeIngredient, ingredientNutrition entity.IngredientNutrition) (float64, bool) {
+	unit, ok := units.Lookup(recipeIngredient.Unit)
+	if ok && !(unit.Approximate && ingredientNutrition.GramsPerUnit > 0) {
+		if unit.Dimension == units.Mass {
+			return recipeIngredient.Quantity * unit.Base, true
+		}
+		return recipeIngredient.Quantity * unit.Base * ingredientNutrition.GramsPerMl, true
+	}
+	if ingredientNutrition.GramsPerUnit > 0 {
+		return recipeIngredient.Quantity * ingredientNutrition.GramsPerUnit, true
+	}
+	return 0, false
+}
+
+func roundNutrition(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+func TestComputeRecipeNutrition(t *testing.T) {
+	ingredientNutritions := map[int64]entity.IngredientNutrition{
+		1: {Name: "nasi putih", Calories: 130, Protein: 2.7, Fat: 0.3, Carbohydrate: 28.2, Sodium: 1, GramsPerMl: 0.8},
+		2: {Name: "telur ayam", Calories: 143, Protein: 12.6, Fat: 9.5, Carbohydrate: 0.7, Sodium: 142, GramsPerMl: 1.03, GramsPerUnit: 55},
+		3: {Name: "minyak goreng", Calories: 884, Fat: 100, GramsPerMl: 0.92},
+		4: {Name: "garam", Sodium: 38758, GramsPerMl: 1.2},
+		5: {Name: "bawang putih", Calories: 149, Protein: 6.4, Fat: 0.5, Carbohydrate: 33.1, Sodium: 17},
+		6: {Name: "bawang bombay", Calories: 40, Protein: 1.1, Fat: 0.1, Carbohydrate: 9.3, Sodium: 4, GramsPerUnit: 150},
+	}
+
+	t.Run("test nutrition per serving", func(t *testing.T) {
+		recipe := &entity.Recipe{
+			Servings: 2,
+			RecipeIngredients: []entity.RecipeIngredient{
+				{RecipeIngredientId: 1, Name: "nasi putih", Quantity: 400, Unit: "gram"}, // 520 kcal
+				{RecipeIngredientId: 2, Name: "telur ayam", Quantity: 2, Unit: "butir"},  // 110 g, 157.3 kcal
+				{RecipeIngredientId: 3, Name: "minyak goreng", Quantity: 2, Unit: "sdm"}, // 27.6 g, 243.98 kcal
+				{RecipeIngredientId: 4, Name: "garam", Note: "secukupnya"},               // unmeasured, ignored
+			},
+		}
+		recipeNutrition := computeRecipeNutrition(recipe, ingredientNutritions)
+		assert.True(t, recipeNutrition.IsComplete)
+		assert.Equal(t, 460.64, recipeNutrition.Calories)
+		assert.Equal(t, 12.33, recipeNutrition.Protein)
+		assert.Equal(t, 19.63, recipeNutrition.Fat)
+		assert.Equal(t, 56.79, recipeNutrition.Carbohydrate)
+		assert.Equal(t, 80.1, recipeNutrition.Sodium)
+	})
+
+	t.Run("test unknown ingredient makes nutrition incomplete", func(t *testing.T) {
+		recipe := &entity.Recipe{
+			Servings: 1,
+			RecipeIngredients: []entity.RecipeIngredient{
+				{RecipeIngredientId: 1, Name: "nasi putih", Quantity: 100, Unit: "g"},
+				{RecipeIngredientId: 5, Name: "daun jeruk", Quantity: 3, Unit: "lembar"}, // no nutrition data
+				{RecipeIngredientId: 1, Name: "nasi putih", Quantity: 1, Unit: "piring"}, // cannot be weighed
+			},
+		}
+		recipeNutrition := computeRecipeNutrition(recipe, ingredientNutritions)
+		assert.False(t, recipeNutrition.IsComplete)
+		assert.Equal(t, float64(130), recipeNutrition.Calories)
+	})
+
+	t.Run("test approximate unit uses the weight per piece of the ingredient", func(t *testing.T) {
+		recipe := &entity.Recipe{
+			Servings: 1,
+			RecipeIngredients: []entity.RecipeIngredient{
+				{RecipeIngredientId: 5, Name: "bawang putih", Quantity: 4, Unit: "siung"},  // estimated 20 g, 29.8 kcal
+				{RecipeIngredientId: 6, Name: "bawang bombay", Quantity: 1, Unit: "siung"}, // 150 g, 60 kcal
+			},
+		}
+		recipeNutrition := computeRecipeNutrition(recipe, ingredientNutritions)
+		assert.True(t, recipeNutrition.IsComplete)
+		assert.Equal(t, 89.8, recipeNutrition.Calories)
+	})
+}
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/apex/log"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/search"
 )
 
 type recipeUsecase struct {
@@ -21,7 +23,24 @@ func NewRecipeUsecase(recipeRepository domain.RecipeRepository) domain.RecipeUse
 
 // Recipe Categories
 func (ru *recipeUsecase) CreateRecipeCategory(ctx context.Context, createRecipeCategoryDTO *domain.CreateRecipeCategoryDTO) error {
-	if err := ru.recipeRepository.CreateRecipeCategory(ctx, createRecipeCategoryDTO.CategoryTag); err != nil {
+	recipeCategory := &entity.RecipeCategory{
+		CategoryTag:      strings.TrimSpace(createRecipeCategoryDTO.CategoryTag),
+		Slug:             toSlug(createRecipeCategoryDTO.CategoryTag, defaultRecipeCategorySlug),
+		Description:      strings.TrimSpace(createRecipeCategoryDTO.Description),
+		CoverImage:       createRecipeCategoryDTO.CoverImage,
+		ParentCategoryId: createRecipeCategoryDTO.ParentCategoryId,
+		DisplayOrder:     createRecipeCategoryDTO.DisplayOrder,
+		IsFeatured:       createRecipeCategoryDTO.IsFeatured,
+	}
+	if err := ru.recipeRepository.CreateRecipeCategory(ctx, recipeCategory); err != nil {
+		switch err {
+		case domain.ErrUnknownParentCategory:
+			log.Debugf("[recipe_usecase.CreateRecipeCategory] parent_category_id: %d does not exist", createRecipeCategoryDTO.ParentCategoryId)
+			return err
+		case domain.ErrDuplicateCategory:
+			log.Debugf("[recipe_usecase.CreateRecipeCategory] category_tag: %s already exists", recipeCategory.CategoryTag)
+			return err
+		}
 		log.Errorf("[recipe_usecase.CreateRecipeCategory] error creating recipe category, err: %v", err)
 		return err
 	}
@@ -50,26 +69,167 @@ func (ru *recipeUsecase) GetRecipeCategories(ctx context.Context) ([]entity.Reci
 		}
 		return nil, err
 	}
-	return recipeCategories, nil
+	return buildRecipeCategoryTree(recipeCategories), nil
+}
+func (ru *recipeUsecase) UpdateRecipeCategory(ctx context.Context, categoryId int64, updateRecipeCategoryDTO *domain.UpdateRecipeCategoryDTO) error {
+	if updateRecipeCategoryDTO.ParentCategoryId == categoryId {
+		return domain.ErrCategoryCycle
+	}
+	recipeCategory := &entity.RecipeCategory{
+		CategoryId:       categoryId,
+		CategoryTag:      strings.TrimSpace(updateRecipeCategoryDTO.CategoryTag),
+		Slug:             toSlug(updateRecipeCategoryDTO.CategoryTag, defaultRecipeCategorySlug),
+		Description:      strings.TrimSpace(updateRecipeCategoryDTO.Description),
+		CoverImage:       updateRecipeCategoryDTO.CoverImage,
+		ParentCategoryId: updateRecipeCategoryDTO.ParentCategoryId,
+		DisplayOrder:     updateRecipeCategoryDTO.DisplayOrder,
+		IsFeatured:       updateRecipeCategoryDTO.IsFeatured,
+	}
+	if err := ru.recipeRepository.UpdateRecipeCategory(ctx, recipeCategory); err != nil {
+		switch err {
+		case sql.ErrNoRows, domain.ErrUnknownParentCategory, domain.ErrDuplicateCategory, domain.ErrCategoryCycle:
+			log.Debugf("[recipe_usecase.UpdateRecipeCategory] category_id: %d not updated, err: %v", categoryId, err)
+			return err
+		}
+		log.Errorf("[recipe_usecase.UpdateRecipeCategory] error updating category_id: %d, err: %v", categoryId, err)
+		return err
+	}
+	log.Debugf("[recipe_usecase.UpdateRecipeCategory] successfully updated category_id: %d", categoryId)
+	return nil
+}
+
+// DeleteRecipeCategory requires another category to move the recipes of the deleted category to,
+// its sub categories are moved up to its parent
+func (ru *recipeUsecase) DeleteRecipeCategory(ctx context.Context, categoryId, reassignToCategoryId int64) error {
+	if reassignToCategoryId == categoryId {
+		return domain.ErrInvalidReassignCategory
+	}
+	if err := ru.recipeRepository.DeleteRecipeCategory(ctx, categoryId, reassignToCategoryId); err != nil {
+		switch err {
+		case sql.ErrNoRows, domain.ErrInvalidReassignCategory:
+			log.Debugf("[recipe_usecase.DeleteRecipeCategory] category_id: %d not deleted, err: %v", categoryId, err)
+			return err
+		}
+		log.Errorf("[recipe_usecase.DeleteRecipeCategory] error deleting category_id: %d, err: %v", categoryId, err)
+		return err
+	}
+	log.Debugf("[recipe_usecase.DeleteRecipeCategory] successfully deleted category_id: %d, recipes reassigned to category_id: %d", categoryId, reassignToCategoryId)
+	return nil
+}
+func (ru *recipeUsecase) ReorderRecipeCategories(ctx context.Context, reorderRecipeCategoriesDTO *domain.ReorderRecipeCategoriesDTO) error {
+	categoryIds := make(map[int64]bool, len(reorderRecipeCategoriesDTO.CategoryIds))
+	for _, categoryId := range reorderRecipeCategoriesDTO.CategoryIds {
+		if categoryIds[categoryId] {
+			log.Debugf("[recipe_usecase.ReorderRecipeCategories] duplicated category_id: %d", categoryId)
+			return domain.ErrBadRequest
+		}
+		categoryIds[categoryId] = true
+	}
+	if err := ru.recipeRepository.ReorderRecipeCategories(ctx, reorderRecipeCategoriesDTO.CategoryIds); err != nil {
+		if err == domain.ErrBadRequest {
+			log.Debugf("[recipe_usecase.ReorderRecipeCategories] category_ids: %v contain an unknown category", reorderRecipeCategoriesDTO.CategoryIds)
+			return err
+		}
+		log.Errorf("[recipe_usecase.ReorderRecipeCategories] error reordering recipe categories, err: %v", err)
+		return err
+	}
+	return nil
+}
+func (ru *recipeUsecase) ResolveRecipeCategorySlug(ctx context.Context, slug string) (int64, string, error) {
+	categoryId, currentSlug, err := ru.recipeRepository.ResolveRecipeCategorySlug(ctx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.ResolveRecipeCategorySlug] no category found for slug: %s", slug)
+			return 0, "", err
+		}
+		log.Errorf("[recipe_usecase.ResolveRecipeCategorySlug] error resolving slug: %s, err: %v", slug, err)
+		return 0, "", err
+	}
+	return categoryId, currentSlug, nil
+}
+
+// Tags
+func (ru *recipeUsecase) GetTags(ctx context.Context) ([]entity.Tag, error) {
+	tags, err := ru.recipeRepository.GetTags(ctx)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetTags] error getting tags, err: %v", err)
+		return nil, err
+	}
+	return tags, nil
+}
+func (ru *recipeUsecase) CreateTag(ctx context.Context, tagDTO *domain.TagDTO) error {
+	tag := &entity.Tag{TagName: strings.TrimSpace(tagDTO.TagName)}
+	if err := ru.recipeRepository.CreateTag(ctx, tag); err != nil {
+		log.Errorf("[recipe_usecase.CreateTag] error creating tag: %s, err: %v", tag.TagName, err)
+		return err
+	}
+	log.Debugf("[recipe_usecase.CreateTag] successfully created tag_id: %d", tag.TagId)
+	return nil
+}
+func (ru *recipeUsecase) UpdateTag(ctx context.Context, tagId int64, tagDTO *domain.TagDTO) error {
+	tag := &entity.Tag{TagId: tagId, TagName: strings.TrimSpace(tagDTO.TagName)}
+	if err := ru.recipeRepository.UpdateTag(ctx, tag); err != nil {
+		log.Errorf("[recipe_usecase.UpdateTag] error updating tag with tag_id: %d, err: %v", tagId, err)
+		return err
+	}
+	return nil
+}
+func (ru *recipeUsecase) DeleteTag(ctx context.Context, tagId int64) error {
+	if err := ru.recipeRepository.DeleteTag(ctx, tagId); err != nil {
+		log.Errorf("[recipe_usecase.DeleteTag] error deleting tag with tag_id: %d, err: %v", tagId, err)
+		return err
+	}
+	return nil
 }
 
 // Recipes
 func (ru *recipeUsecase) CreateRecipe(ctx context.Context, createRecipeDTO *domain.CreateRecipeDTO) error {
-	if err := ru.recipeRepository.CreateRecipe(ctx, &entity.Recipe{
+	servings := createRecipeDTO.Servings
+	if servings == 0 {
+		servings = 1
+	}
+	recipe := &entity.Recipe{
 		Title:                createRecipeDTO.Title,
+		Slug:                 toSlug(createRecipeDTO.Title, defaultRecipeSlug),
 		Header:               createRecipeDTO.Header,
 		ImagePreview:         createRecipeDTO.ImagePreview,
 		Description:          createRecipeDTO.Description,
-		RecipeIngredients:    createRecipeDTO.RecipeIngredients,
+		RecipeIngredients:    toRecipeIngredients(createRecipeDTO.RecipeIngredients),
+		RecipeSteps:          toRecipeSteps(createRecipeDTO.RecipeSteps),
+		Tags:                 toTags(createRecipeDTO.TagIds),
 		CategoryId:           createRecipeDTO.CategoryId,
 		EstimatedTimeMinutes: createRecipeDTO.EstimatedTimeMinutes,
-	}); err != nil {
+		Servings:             servings,
+	}
+	if err := ru.recipeRepository.CreateRecipe(ctx, recipe); err != nil {
+		if err == domain.ErrUnknownTag {
+			log.Debugf("[recipe_usecase.CreateRecipe] unknown tag in tag_ids: %v", createRecipeDTO.TagIds)
+			return err
+		}
 		log.Errorf("[recipe_usecase.CreateRecipe] error creating a new recipe, err: %v", err)
 		return err
 	}
+	// the recipe is already created, a failed nutrition cache is only logged and computed again on the next update
+	if err := ru.RefreshRecipeNutrition(ctx, recipe.RecipeId); err != nil {
+		log.Errorf("[recipe_usecase.CreateRecipe] error caching nutrition of recipe_id: %d, err: %v", recipe.RecipeId, err)
+	}
 	return nil
 }
-func (ru *recipeUsecase) GetRecipeById(ctx context.Context, recipeId int64) (*entity.Recipe, error) {
+
+// ResolveRecipeSlug returns the recipe of a current or former slug and the current slug to redirect a former slug to
+func (ru *recipeUsecase) ResolveRecipeSlug(ctx context.Context, slug string) (int64, string, error) {
+	recipeId, currentSlug, err := ru.recipeRepository.ResolveRecipeSlug(ctx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.ResolveRecipeSlug] no recipe found for slug: %s", slug)
+			return 0, "", err
+		}
+		log.Errorf("[recipe_usecase.ResolveRecipeSlug] error resolving slug: %s, err: %v", slug, err)
+		return 0, "", err
+	}
+	return recipeId, currentSlug, nil
+}
+func (ru *recipeUsecase) GetRecipeById(ctx context.Context, recipeId int64, getRecipeByIdQueryFilter *domain.GetRecipeByIdQueryFilter) (*entity.Recipe, error) {
 	recipe, err := ru.recipeRepository.GetRecipeById(ctx, recipeId)
 	if err != nil {
 		if err == sql.ErrNoRows || recipe == nil {
@@ -78,31 +238,137 @@ func (ru *recipeUsecase) GetRecipeById(ctx context.Context, recipeId int64) (*en
 		}
 		return nil, err
 	}
+	// drafts are not found unless they are asked for
+	if recipe.PublishedAt == nil && !getRecipeByIdQueryFilter.IncludeDrafts {
+		log.Debugf("[recipe_usecase.GetRecipeById] recipe_id: %d is a draft", recipeId)
+		return nil, sql.ErrNoRows
+	}
+	presentRecipeQuantities(recipe, getRecipeByIdQueryFilter.Servings, getRecipeByIdQueryFilter.Units)
 	return recipe, nil
 }
-func (ru *recipeUsecase) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) ([]entity.Recipe, error) {
-	recipes, err := ru.recipeRepository.GetRecipes(ctx, getRecipesQueryFilter)
+func (ru *recipeUsecase) GetRecipes(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipePage, error) {
+	if err := ru.expandFuzzyQueries(ctx, getRecipesQueryFilter); err != nil {
+		return nil, err
+	}
+	recipePage, err := ru.recipeRepository.GetRecipes(ctx, getRecipesQueryFilter)
 	if err != nil {
-		if err == sql.ErrNoRows || recipes == nil {
+		switch err {
+		case sql.ErrNoRows:
 			log.Debugf("[recipe_usecase.GetRecipes] no rows found, err: %v", err)
-			return nil, sql.ErrNoRows
+		case domain.ErrInvalidCursor, domain.ErrUnknownRecipeSort:
+			log.Debugf("[recipe_usecase.GetRecipes] invalid cursor: %s for sort: %s", getRecipesQueryFilter.Cursor, getRecipesQueryFilter.Sort)
+		default:
+			log.Errorf("[recipe_usecase.GetRecipes] error getting recipes, err: %v", err)
 		}
 		return nil, err
 	}
-	return recipes, nil
+	// listed recipes keep their own servings
+	for i := range recipePage.Recipes {
+		presentRecipeQuantities(&recipePage.Recipes[i], 0, getRecipesQueryFilter.Units)
+	}
+	return recipePage, nil
+}
+func (ru *recipeUsecase) GetRecipeFacets(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) (*entity.RecipeFacets, error) {
+	if err := ru.expandFuzzyQueries(ctx, getRecipesQueryFilter); err != nil {
+		return nil, err
+	}
+	recipeFacets, err := ru.recipeRepository.GetRecipeFacets(ctx, getRecipesQueryFilter)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetRecipeFacets] error counting recipe facets, err: %v", err)
+		return nil, err
+	}
+	return recipeFacets, nil
+}
+
+// expandFuzzyQueries rewrites the search and name of a fuzzy filter with the search synonyms and their stems
+func (ru *recipeUsecase) expandFuzzyQueries(ctx context.Context, getRecipesQueryFilter *domain.GetRecipesQueryFilter) error {
+	if !getRecipesQueryFilter.Fuzzy {
+		return nil
+	}
+	searchSynonyms, err := ru.recipeRepository.GetSearchSynonyms(ctx)
+	if err != nil {
+		log.Errorf("[recipe_usecase.expandFuzzyQueries] error getting search synonyms, err: %v", err)
+		return err
+	}
+	synonyms := make(search.Synonyms, len(searchSynonyms))
+	for _, searchSynonym := range searchSynonyms {
+		synonyms[searchSynonym.Term] = searchSynonym.Replacement
+	}
+	getRecipesQueryFilter.FuzzySearch = search.Expand(getRecipesQueryFilter.SearchQuery, synonyms)
+	getRecipesQueryFilter.FuzzyName = search.Expand(getRecipesQueryFilter.Name, synonyms)
+	log.Debugf("[recipe_usecase.expandFuzzyQueries] search: %+v, name: %+v", getRecipesQueryFilter.FuzzySearch, getRecipesQueryFilter.FuzzyName)
+	return nil
+}
+func (ru *recipeUsecase) GetRecipesByIngredients(ctx context.Context, getRecipesByIngredientsQueryFilter *domain.GetRecipesByIngredientsQueryFilter) ([]entity.RecipeMatch, error) {
+	if len(getRecipesByIngredientsQueryFilter.Have) == 0 {
+		log.Debugf("[recipe_usecase.GetRecipesByIngredients] no available ingredients given")
+		return nil, domain.ErrBadRequest
+	}
+	recipeMatches, err := ru.recipeRepository.GetRecipesByIngredients(ctx, getRecipesByIngredientsQueryFilter)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetRecipesByIngredients] error getting recipes by ingredients: %v, err: %v", getRecipesByIngredientsQueryFilter.Have, err)
+		return nil, err
+	}
+	for i := range recipeMatches {
+		presentRecipeQuantities(&recipeMatches[i].Recipe, 0, getRecipesByIngredientsQueryFilter.Units)
+	}
+	return recipeMatches, nil
+}
+func (ru *recipeUsecase) GetSuggestions(ctx context.Context, query string, limit int) ([]entity.Suggestion, error) {
+	suggestions, err := ru.recipeRepository.GetSuggestions(ctx, query, limit)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetSuggestions] error getting suggestions for query: %s, err: %v", query, err)
+		return nil, err
+	}
+	return suggestions, nil
 }
 func (ru *recipeUsecase) UpdateRecipe(ctx context.Context, recipeId int64, updateRecipeDTO *domain.UpdateRecipeDTO) error {
+	var slug string
+	if updateRecipeDTO.Title != "" {
+		slug = toSlug(updateRecipeDTO.Title, defaultRecipeSlug)
+	}
 	if err := ru.recipeRepository.UpdateRecipeById(ctx, recipeId, &domain.UpdateRecipeByIdQueryFilter{
 		Title:                updateRecipeDTO.Title,
+		Slug:                 slug,
 		Header:               updateRecipeDTO.Header,
-		ImagePreview:         updateRecipeDTO.Header,
+		ImagePreview:         updateRecipeDTO.ImagePreview,
 		Description:          updateRecipeDTO.Description,
-		RecipeIngredients:    updateRecipeDTO.RecipeIngredients,
+		RecipeIngredients:    toRecipeIngredients(updateRecipeDTO.RecipeIngredients),
+		RecipeSteps:          toRecipeSteps(updateRecipeDTO.RecipeSteps),
+		Tags:                 toTags(updateRecipeDTO.TagIds),
+		CategoryId:           updateRecipeDTO.CategoryId,
 		EstimatedTimeMinutes: updateRecipeDTO.EstimatedTimeMinutes,
+		Servings:             updateRecipeDTO.Servings,
 	}); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.UpdateRecipe] no row found for recipe_id: %d", recipeId)
+			return sql.ErrNoRows
+		}
+		if err == domain.ErrUnknownTag {
+			log.Debugf("[recipe_usecase.UpdateRecipe] unknown tag in tag_ids: %v", updateRecipeDTO.TagIds)
+			return err
+		}
 		log.Errorf("[recipe_usecase.UpdateRecipe] error updating recipe with recipe_id: %d, err: %v", recipeId, err)
 		return err
 	}
+	// only ingredients and servings change the nutrition of a serving
+	if updateRecipeDTO.RecipeIngredients != nil || updateRecipeDTO.Servings != 0 {
+		if err := ru.RefreshRecipeNutrition(ctx, recipeId); err != nil {
+			log.Errorf("[recipe_usecase.UpdateRecipe] error caching nutrition of recipe_id: %d, err: %v", recipeId, err)
+		}
+	}
+	return nil
+}
+func (ru *recipeUsecase) PublishRecipe(ctx context.Context, recipeId int64, published bool) error {
+	if err := ru.recipeRepository.UpdateRecipePublished(ctx, recipeId, published); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.PublishRecipe] no recipe found for recipe_id: %d", recipeId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.PublishRecipe] error publishing recipe with recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	log.Debugf("[recipe_usecase.PublishRecipe] recipe_id: %d published: %t", recipeId, published)
 	return nil
 }
 func (ru *recipeUsecase) DeleteRecipeById(ctx context.Context, recipeId int64) error {
@@ -115,21 +381,305 @@ func (ru *recipeUsecase) DeleteRecipeById(ctx context.Context, recipeId int64) e
 	return nil
 }
 
+// toTags maps tag ids into tags to link, nil stays nil so updates can leave tags untouched
+func toTags(tagIds []int64) []entity.Tag {
+	if tagIds == nil {
+		return nil
+	}
+	tags := make([]entity.Tag, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		tags = append(tags, entity.Tag{TagId: tagId})
+	}
+	return tags
+}
+
+// toRecipeIngredients maps validated ingredient DTOs into ordered recipe ingredients, nil stays nil so updates can leave ingredients untouched
+func toRecipeIngredients(recipeIngredientDTOs []domain.RecipeIngredientDTO) []entity.RecipeIngredient {
+	if recipeIngredientDTOs == nil {
+		return nil
+	}
+	recipeIngredients := make([]entity.RecipeIngredient, 0, len(recipeIngredientDTOs))
+	for i, recipeIngredientDTO := range recipeIngredientDTOs {
+		recipeIngredients = append(recipeIngredients, entity.RecipeIngredient{
+			Name:     strings.TrimSpace(recipeIngredientDTO.Name),
+			Quantity: recipeIngredientDTO.Quantity,
+			Unit:     strings.TrimSpace(recipeIngredientDTO.Unit),
+			Note:     strings.TrimSpace(recipeIngredientDTO.Note),
+			Group:    strings.TrimSpace(recipeIngredientDTO.Group),
+			Position: i + 1,
+		})
+	}
+	return recipeIngredients
+}
+
+// toRecipeSteps maps validated step DTOs into numbered recipe steps, nil stays nil so updates can leave steps untouched
+func toRecipeSteps(recipeStepDTOs []domain.RecipeStepDTO) []entity.RecipeStep {
+	if recipeStepDTOs == nil {
+		return nil
+	}
+	recipeSteps := make([]entity.RecipeStep, 0, len(recipeStepDTOs))
+	for i, recipeStepDTO := range recipeStepDTOs {
+		recipeSteps = append(recipeSteps, toRecipeStep(recipeStepDTO, i+1))
+	}
+	return recipeSteps
+}
+
+func toRecipeStep(recipeStepDTO domain.RecipeStepDTO, stepNumber int) entity.RecipeStep {
+	return entity.RecipeStep{
+		Instruction:     strings.TrimSpace(recipeStepDTO.Instruction),
+		DurationSeconds: recipeStepDTO.DurationSeconds,
+		StepImage:       strings.TrimSpace(recipeStepDTO.StepImage),
+		StepNumber:      stepNumber,
+	}
+}
+
+// Recipe Steps
+func (ru *recipeUsecase) GetRecipeSteps(ctx context.Context, recipeId int64, includeDrafts bool) ([]entity.RecipeStep, error) {
+	// validate recipe existence, steps of an unknown recipe would be an empty list instead of not found
+	if _, err := ru.GetRecipeById(ctx, recipeId, &domain.GetRecipeByIdQueryFilter{IncludeDrafts: includeDrafts}); err != nil {
+		return nil, err
+	}
+	recipeSteps, err := ru.recipeRepository.GetRecipeStepsByRecipeId(ctx, recipeId)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetRecipeSteps] error getting recipe steps with recipe_id: %d, err: %v", recipeId, err)
+		return nil, err
+	}
+	return recipeSteps, nil
+}
+func (ru *recipeUsecase) CreateRecipeStep(ctx context.Context, recipeId int64, createRecipeStepDTO *domain.CreateRecipeStepDTO) error {
+	recipeStep := toRecipeStep(createRecipeStepDTO.RecipeStepDTO, createRecipeStepDTO.StepNumber)
+	if err := ru.recipeRepository.CreateRecipeStep(ctx, recipeId, &recipeStep); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.CreateRecipeStep] no recipe found for recipe_id: %d", recipeId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.CreateRecipeStep] error creating recipe step with recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	log.Debugf("[recipe_usecase.CreateRecipeStep] recipe_step_id: %d created as step %d of recipe_id: %d", recipeStep.RecipeStepId, recipeStep.StepNumber, recipeId)
+	return nil
+}
+func (ru *recipeUsecase) ReorderRecipeSteps(ctx context.Context, recipeId int64, reorderRecipeStepsDTO *domain.ReorderRecipeStepsDTO) error {
+	// a duplicated step id can never be a valid order, reject it before touching the database
+	recipeStepIds := make(map[int64]bool, len(reorderRecipeStepsDTO.RecipeStepIds))
+	for _, recipeStepId := range reorderRecipeStepsDTO.RecipeStepIds {
+		if recipeStepIds[recipeStepId] {
+			log.Debugf("[recipe_usecase.ReorderRecipeSteps] duplicated recipe_step_id: %d", recipeStepId)
+			return domain.ErrBadRequest
+		}
+		recipeStepIds[recipeStepId] = true
+	}
+	if err := ru.recipeRepository.ReorderRecipeSteps(ctx, recipeId, reorderRecipeStepsDTO.RecipeStepIds); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			log.Debugf("[recipe_usecase.ReorderRecipeSteps] no recipe found for recipe_id: %d", recipeId)
+		case domain.ErrBadRequest:
+			log.Debugf("[recipe_usecase.ReorderRecipeSteps] recipe_step_ids: %v do not match the steps of recipe_id: %d", reorderRecipeStepsDTO.RecipeStepIds, recipeId)
+		default:
+			log.Errorf("[recipe_usecase.ReorderRecipeSteps] error reordering recipe steps with recipe_id: %d, err: %v", recipeId, err)
+		}
+		return err
+	}
+	return nil
+}
+func (ru *recipeUsecase) DeleteRecipeStep(ctx context.Context, recipeId, recipeStepId int64) error {
+	if err := ru.recipeRepository.DeleteRecipeStep(ctx, recipeId, recipeStepId); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.DeleteRecipeStep] no step found for recipe_id: %d, recipe_step_id: %d", recipeId, recipeStepId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.DeleteRecipeStep] error deleting recipe_step_id: %d, err: %v", recipeStepId, err)
+		return err
+	}
+	return nil
+}
+
+// Recipe Nutritions
+
+// ImportIngredientNutritions creates or replaces the ingredient nutritions and computes the nutrition of every recipe again
+func (ru *recipeUsecase) ImportIngredientNutritions(ctx context.Context, ingredientNutritions []entity.IngredientNutrition) error {
+	for i := range ingredientNutritions {
+		ingredientNutritions[i].Name = strings.ToLower(strings.TrimSpace(ingredientNutritions[i].Name))
+		if ingredientNutritions[i].Aliases == nil {
+			ingredientNutritions[i].Aliases = []string{}
+		}
+		for j := range ingredientNutritions[i].Aliases {
+			ingredientNutritions[i].Aliases[j] = strings.ToLower(strings.TrimSpace(ingredientNutritions[i].Aliases[j]))
+		}
+		// without a known density a milliliter is weighed as a gram of water
+		if ingredientNutritions[i].GramsPerMl <= 0 {
+			ingredientNutritions[i].GramsPerMl = 1
+		}
+	}
+	if err := ru.recipeRepository.UpsertIngredientNutritions(ctx, ingredientNutritions); err != nil {
+		log.Errorf("[recipe_usecase.ImportIngredientNutritions] error importing ingredient nutritions, err: %v", err)
+		return err
+	}
+	recipeIds, err := ru.recipeRepository.GetRecipeIds(ctx)
+	if err != nil {
+		log.Errorf("[recipe_usecase.ImportIngredientNutritions] error getting recipe ids, err: %v", err)
+		return err
+	}
+	for _, recipeId := range recipeIds {
+		if err := ru.RefreshRecipeNutrition(ctx, recipeId); err != nil {
+			return err
+		}
+	}
+	log.Infof("[recipe_usecase.ImportIngredientNutritions] imported %d ingredient nutritions, refreshed nutrition of %d recipes", len(ingredientNutritions), len(recipeIds))
+	return nil
+}
+
+// RefreshRecipeNutrition computes the nutrition of one serving of the recipe and caches it
+func (ru *recipeUsecase) RefreshRecipeNutrition(ctx context.Context, recipeId int64) error {
+	recipe, err := ru.recipeRepository.GetRecipeById(ctx, recipeId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.RefreshRecipeNutrition] no row found for recipe_id: %d", recipeId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.RefreshRecipeNutrition] error getting recipe with recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	ingredientNutritions, err := ru.recipeRepository.GetIngredientNutritionsByRecipeId(ctx, recipeId)
+	if err != nil {
+		log.Errorf("[recipe_usecase.RefreshRecipeNutrition] error getting ingredient nutritions of recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	if err := ru.recipeRepository.UpsertRecipeNutrition(ctx, recipeId, computeRecipeNutrition(recipe, ingredientNutritions)); err != nil {
+		log.Errorf("[recipe_usecase.RefreshRecipeNutrition] error caching nutrition of recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	return nil
+}
+
 // Recipe Ratings
-func (ru *recipeUsecase) CreateRecipeRating(ctx context.Context, recipeId, userId int64, rating int) error {
-	if err := ru.recipeRepository.CreateRecipeRating(ctx, recipeId, userId, rating); err != nil {
+func (ru *recipeUsecase) CreateRecipeRating(ctx context.Context, recipeId, userId int64, createRecipeRatingDTO *domain.CreateRecipeRatingDTO) error {
+	if err := ru.recipeRepository.CreateRecipeRating(ctx, recipeId, userId, createRecipeRatingDTO.Rating); err != nil {
 		if err == sql.ErrNoRows {
-			log.Debugf("[recipe_usecase.CreateRecipeRating] error creating recipe rating with recipe_id: %d, err: %v", recipeId, err)
+			log.Debugf("[recipe_usecase.CreateRecipeRating] no recipe found for recipe_id: %d, err: %v", recipeId, err)
+			return sql.ErrNoRows
 		}
+		log.Errorf("[recipe_usecase.CreateRecipeRating] error creating recipe rating with recipe_id: %d, err: %v", recipeId, err)
 		return err
 	}
+	log.Debugf("[recipe_usecase.CreateRecipeRating] user_id: %d rated recipe_id: %d", userId, recipeId)
 	return nil
 }
-func (ru *recipeUsecase) GetRecipeRatingSummary(ctx context.Context, recipeId int64) (float64, int, error) {
+func (ru *recipeUsecase) UpdateRecipeRating(ctx context.Context, recipeId, userId int64, updateRecipeRatingDTO *domain.UpdateRecipeRatingDTO) error {
+	if err := ru.recipeRepository.UpdateRecipeRating(ctx, recipeId, userId, updateRecipeRatingDTO.Rating); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.UpdateRecipeRating] no rating found for recipe_id: %d, user_id: %d", recipeId, userId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.UpdateRecipeRating] error updating recipe rating with recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	return nil
+}
+func (ru *recipeUsecase) DeleteRecipeRating(ctx context.Context, recipeId, userId int64) error {
+	if err := ru.recipeRepository.DeleteRecipeRating(ctx, recipeId, userId); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[recipe_usecase.DeleteRecipeRating] no rating found for recipe_id: %d, user_id: %d", recipeId, userId)
+			return sql.ErrNoRows
+		}
+		log.Errorf("[recipe_usecase.DeleteRecipeRating] error deleting recipe rating with recipe_id: %d, err: %v", recipeId, err)
+		return err
+	}
+	return nil
+}
+func (ru *recipeUsecase) GetRecipeRatingSummary(ctx context.Context, recipeId, userId int64) (*entity.RecipeRatingSummary, error) {
+	// validate recipe existence, summary of an unknown recipe would be an empty summary instead of not found
+	if _, err := ru.GetRecipeById(ctx, recipeId, &domain.GetRecipeByIdQueryFilter{}); err != nil {
+		return nil, err
+	}
 	avg, ratingCount, err := ru.recipeRepository.GetRecipeRatingSummary(ctx, recipeId)
 	if err != nil {
-		log.Debugf("[recipe_usecase.GetRecipeRatingSummary] error getting recipe rating summary with recipe_id: %d, err: %v", recipeId, err)
-		return 0, 0, err
+		log.Errorf("[recipe_usecase.GetRecipeRatingSummary] error getting recipe rating summary with recipe_id: %d, err: %v", recipeId, err)
+		return nil, err
+	}
+	recipeRatingSummary := &entity.RecipeRatingSummary{
+		AverageRating: avg,
+		RatingCount:   ratingCount,
+	}
+	recipeRating, err := ru.recipeRepository.GetRecipeRatingByUserId(ctx, recipeId, userId)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("[recipe_usecase.GetRecipeRatingSummary] error getting rating of user_id: %d, err: %v", userId, err)
+		return nil, err
+	}
+	if recipeRating != nil {
+		recipeRatingSummary.UserRating = recipeRating.RecipeRating
+	}
+	return recipeRatingSummary, nil
+}
+
+// Search Synonyms
+func (ru *recipeUsecase) GetSearchSynonyms(ctx context.Context) ([]entity.SearchSynonym, error) {
+	searchSynonyms, err := ru.recipeRepository.GetSearchSynonyms(ctx)
+	if err != nil {
+		log.Errorf("[recipe_usecase.GetSearchSynonyms] error getting search synonyms, err: %v", err)
+		return nil, err
+	}
+	return searchSynonyms, nil
+}
+
+func (ru *recipeUsecase) CreateSearchSynonym(ctx context.Context, searchSynonymDTO *domain.SearchSynonymDTO) error {
+	searchSynonym, err := toSearchSynonym(searchSynonymDTO.Term, searchSynonymDTO.Replacement)
+	if err != nil {
+		log.Debugf("[recipe_usecase.CreateSearchSynonym] invalid search synonym: %+v", searchSynonymDTO)
+		return err
+	}
+	if err := ru.recipeRepository.CreateSearchSynonym(ctx, searchSynonym); err != nil {
+		log.Errorf("[recipe_usecase.CreateSearchSynonym] error creating search synonym with term: %s, err: %v", searchSynonym.Term, err)
+		return err
+	}
+	return nil
+}
+
+func (ru *recipeUsecase) UpdateSearchSynonym(ctx context.Context, searchSynonymId int64, searchSynonymDTO *domain.SearchSynonymDTO) error {
+	searchSynonym, err := toSearchSynonym(searchSynonymDTO.Term, searchSynonymDTO.Replacement)
+	if err != nil {
+		log.Debugf("[recipe_usecase.UpdateSearchSynonym] invalid search synonym: %+v", searchSynonymDTO)
+		return err
+	}
+	searchSynonym.SearchSynonymId = searchSynonymId
+	if err := ru.recipeRepository.UpdateSearchSynonym(ctx, searchSynonym); err != nil {
+		log.Errorf("[recipe_usecase.UpdateSearchSynonym] error updating search synonym with search_synonym_id: %d, err: %v", searchSynonymId, err)
+		return err
+	}
+	return nil
+}
+
+func (ru *recipeUsecase) DeleteSearchSynonym(ctx context.Context, searchSynonymId int64) error {
+	if err := ru.recipeRepository.DeleteSearchSynonym(ctx, searchSynonymId); err != nil {
+		log.Errorf("[recipe_usecase.DeleteSearchSynonym] error deleting search synonym with search_synonym_id: %d, err: %v", searchSynonymId, err)
+		return err
+	}
+	return nil
+}
+
+// ImportSearchSynonyms creates or replaces the synonyms by term, nothing is imported when any of them is invalid
+func (ru *recipeUsecase) ImportSearchSynonyms(ctx context.Context, searchSynonyms []entity.SearchSynonym) error {
+	for i := range searchSynonyms {
+		searchSynonym, err := toSearchSynonym(searchSynonyms[i].Term, searchSynonyms[i].Replacement)
+		if err != nil {
+			log.Errorf("[recipe_usecase.ImportSearchSynonyms] invalid search synonym: %s -> %s", searchSynonyms[i].Term, searchSynonyms[i].Replacement)
+			return err
+		}
+		searchSynonyms[i] = *searchSynonym
+	}
+	if err := ru.recipeRepository.UpsertSearchSynonyms(ctx, searchSynonyms); err != nil {
+		log.Errorf("[recipe_usecase.ImportSearchSynonyms] error importing search synonyms, err: %v", err)
+		return err
+	}
+	log.Infof("[recipe_usecase.ImportSearchSynonyms] imported %d search synonyms", len(searchSynonyms))
+	return nil
+}
+
+// toSearchSynonym normalizes a synonym the same way search queries are normalized so its term can be found in them
+func toSearchSynonym(term, replacement string) (*entity.SearchSynonym, error) {
+	term, replacement = search.Normalize(term), search.Normalize(replacement)
+	if term == "" || replacement == "" || term == replacement || len(strings.Fields(term)) > search.MaxSynonymWords {
+		return nil, domain.ErrBadRequest
 	}
-	return avg, ratingCount, nil
+	return &entity.SearchSynonym{Term: term, Replacement: replacement}, nil
 }
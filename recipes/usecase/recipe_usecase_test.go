@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+	mocks "github.com/victorsantoso/endeus/mocks/domain"
+	"github.com/victorsantoso/endeus/search"
+)
+
+func TestRecipeUsecase_UpdateRecipeCategory(t *testing.T) {
+	t.Run("test move category under another category", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("UpdateRecipeCategory", mock.Anything, &entity.RecipeCategory{CategoryId: 3, CategoryTag: "Jawa Timur", Slug: "jawa-timur", ParentCategoryId: 2}).Return(nil)
+		err := recipeUsecase.UpdateRecipeCategory(context.Background(), 3, &domain.UpdateRecipeCategoryDTO{CategoryTag: " Jawa Timur ", ParentCategoryId: 2})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test move category under itself", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		err := recipeUsecase.UpdateRecipeCategory(context.Background(), 3, &domain.UpdateRecipeCategoryDTO{CategoryTag: "Jawa Timur", ParentCategoryId: 3})
+		assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_DeleteRecipeCategory(t *testing.T) {
+	t.Run("test delete category and reassign its recipes", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("DeleteRecipeCategory", mock.Anything, int64(3), int64(2)).Return(nil)
+		err := recipeUsecase.DeleteRecipeCategory(context.Background(), 3, 2)
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test reassign recipes to the deleted category", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		err := recipeUsecase.DeleteRecipeCategory(context.Background(), 3, 3)
+		assert.ErrorIs(t, err, domain.ErrInvalidReassignCategory)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_ReorderRecipeCategories(t *testing.T) {
+	t.Run("test reorder with duplicated category", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		err := recipeUsecase.ReorderRecipeCategories(context.Background(), &domain.ReorderRecipeCategoriesDTO{CategoryIds: []int64{3, 1, 3}})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		mockRecipeRepository.AssertNotCalled(t, "ReorderRecipeCategories", mock.Anything, mock.Anything)
+	})
+
+	t.Run("test reorder categories", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("ReorderRecipeCategories", mock.Anything, []int64{3, 1, 2}).Return(nil)
+		err := recipeUsecase.ReorderRecipeCategories(context.Background(), &domain.ReorderRecipeCategoriesDTO{CategoryIds: []int64{3, 1, 2}})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_CreateRecipeRating(t *testing.T) {
+	t.Run("test rate existing recipe", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("CreateRecipeRating", mock.Anything, int64(1), int64(2), 5).Return(nil)
+		err := recipeUsecase.CreateRecipeRating(context.Background(), 1, 2, &domain.CreateRecipeRatingDTO{Rating: 5})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test rate unknown recipe", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("CreateRecipeRating", mock.Anything, int64(99), int64(2), 4).Return(sql.ErrNoRows) // foreign key violation is translated to sql.ErrNoRows
+		err := recipeUsecase.CreateRecipeRating(context.Background(), 99, 2, &domain.CreateRecipeRatingDTO{Rating: 4})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_GetRecipeRatingSummary(t *testing.T) {
+	publishedAt := time.Now().UTC()
+	t.Run("test summary with caller's rating", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipeById", mock.Anything, int64(1)).Return(&entity.Recipe{RecipeId: 1, PublishedAt: &publishedAt}, nil)
+		mockRecipeRepository.On("GetRecipeRatingSummary", mock.Anything, int64(1)).Return(4.5, 2, nil)
+		mockRecipeRepository.On("GetRecipeRatingByUserId", mock.Anything, int64(1), int64(2)).Return(&entity.RecipeRating{
+			RecipeId:     1,
+			UserId:       2,
+			RecipeRating: 4,
+			CreatedAt:    time.Now().UTC(),
+			UpdatedAt:    time.Now().UTC(),
+		}, nil)
+		recipeRatingSummary, err := recipeUsecase.GetRecipeRatingSummary(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.5, recipeRatingSummary.AverageRating)
+		assert.Equal(t, 2, recipeRatingSummary.RatingCount)
+		assert.Equal(t, 4, recipeRatingSummary.UserRating)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test summary without caller's rating", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipeById", mock.Anything, int64(1)).Return(&entity.Recipe{RecipeId: 1, PublishedAt: &publishedAt}, nil)
+		mockRecipeRepository.On("GetRecipeRatingSummary", mock.Anything, int64(1)).Return(float64(0), 0, nil)
+		mockRecipeRepository.On("GetRecipeRatingByUserId", mock.Anything, int64(1), int64(2)).Return(nil, sql.ErrNoRows)
+		recipeRatingSummary, err := recipeUsecase.GetRecipeRatingSummary(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, recipeRatingSummary.RatingCount)
+		assert.Empty(t, recipeRatingSummary.UserRating) // user has not rated the recipe yet
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test summary of unknown recipe", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipeById", mock.Anything, int64(99)).Return(nil, sql.ErrNoRows)
+		recipeRatingSummary, err := recipeUsecase.GetRecipeRatingSummary(context.Background(), 99, 2)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, recipeRatingSummary)
+		mockRecipeRepository.AssertNotCalled(t, "GetRecipeRatingSummary", mock.Anything, mock.Anything) // should stop on recipe validation
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test summary of a draft", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipeById", mock.Anything, int64(3)).Return(&entity.Recipe{RecipeId: 3}, nil)
+		recipeRatingSummary, err := recipeUsecase.GetRecipeRatingSummary(context.Background(), 3, 2)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, recipeRatingSummary)
+		mockRecipeRepository.AssertNotCalled(t, "GetRecipeRatingSummary", mock.Anything, mock.Anything) // drafts are not found
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_GetRecipeById(t *testing.T) {
+	t.Run("test draft is only found when drafts are included", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipeById", mock.Anything, int64(3)).Return(&entity.Recipe{RecipeId: 3, Servings: 2}, nil)
+		recipe, err := recipeUsecase.GetRecipeById(context.Background(), 3, &domain.GetRecipeByIdQueryFilter{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, recipe)
+		recipe, err = recipeUsecase.GetRecipeById(context.Background(), 3, &domain.GetRecipeByIdQueryFilter{IncludeDrafts: true})
+		assert.NoError(t, err)
+		assert.Nil(t, recipe.PublishedAt)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_ReorderRecipeSteps(t *testing.T) {
+	t.Run("test reorder with duplicated step", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		err := recipeUsecase.ReorderRecipeSteps(context.Background(), 1, &domain.ReorderRecipeStepsDTO{
+			RecipeStepIds: []int64{3, 1, 3},
+		})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		mockRecipeRepository.AssertNotCalled(t, "ReorderRecipeSteps", mock.Anything, mock.Anything, mock.Anything)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test reorder with steps of another recipe", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("ReorderRecipeSteps", mock.Anything, int64(1), []int64{2, 1, 9}).Return(domain.ErrBadRequest)
+		err := recipeUsecase.ReorderRecipeSteps(context.Background(), 1, &domain.ReorderRecipeStepsDTO{
+			RecipeStepIds: []int64{2, 1, 9},
+		})
+		assert.ErrorIs(t, err, domain.ErrBadRequest)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_UpdateRecipe(t *testing.T) {
+	t.Run("test update recipe numbers the given steps", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("UpdateRecipeById", mock.Anything, int64(1), &domain.UpdateRecipeByIdQueryFilter{
+			RecipeSteps: []entity.RecipeStep{
+				{Instruction: "Tumis bumbu halus hingga harum", DurationSeconds: 180, StepNumber: 1},
+				{Instruction: "Masukkan nasi, aduk rata", StepNumber: 2},
+			},
+		}).Return(nil)
+		err := recipeUsecase.UpdateRecipe(context.Background(), 1, &domain.UpdateRecipeDTO{
+			RecipeSteps: []domain.RecipeStepDTO{
+				{Instruction: " Tumis bumbu halus hingga harum ", DurationSeconds: 180},
+				{Instruction: "Masukkan nasi, aduk rata"},
+			},
+		})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test rename recipe derives slug from title", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("UpdateRecipeById", mock.Anything, int64(1), mock.MatchedBy(func(filter *domain.UpdateRecipeByIdQueryFilter) bool {
+			return filter.Title == "Crème Brûlée & Es Teh" && filter.Slug == "creme-brulee-dan-es-teh"
+		})).Return(nil)
+		err := recipeUsecase.UpdateRecipe(context.Background(), 1, &domain.UpdateRecipeDTO{Title: "Crème Brûlée & Es Teh"})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test rename recipe without latin letters", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("UpdateRecipeById", mock.Anything, int64(1), mock.MatchedBy(func(filter *domain.UpdateRecipeByIdQueryFilter) bool {
+			return filter.Slug == defaultRecipeSlug
+		})).Return(nil)
+		err := recipeUsecase.UpdateRecipe(context.Background(), 1, &domain.UpdateRecipeDTO{Title: "炒饭"})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test update recipe with unknown tag", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("UpdateRecipeById", mock.Anything, int64(1), &domain.UpdateRecipeByIdQueryFilter{
+			Tags: []entity.Tag{{TagId: 3}, {TagId: 99}},
+		}).Return(domain.ErrUnknownTag)
+		err := recipeUsecase.UpdateRecipe(context.Background(), 1, &domain.UpdateRecipeDTO{TagIds: []int64{3, 99}})
+		assert.ErrorIs(t, err, domain.ErrUnknownTag)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_GetRecipes(t *testing.T) {
+	t.Run("test fuzzy search expands synonyms and stems", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetSearchSynonyms", mock.Anything).Return([]entity.SearchSynonym{
+			{Term: "fried rice", Replacement: "nasi goreng"},
+			{Term: "chicken", Replacement: "ayam"},
+		}, nil)
+		mockRecipeRepository.On("GetRecipes", mock.Anything, mock.MatchedBy(func(getRecipesQueryFilter *domain.GetRecipesQueryFilter) bool {
+			return assert.Equal(t, search.Expansion{
+				Phrase:       "nasi goreng ayam",
+				Alternatives: []string{"fried rice chicken", "nasi goreng ayam"},
+			}, getRecipesQueryFilter.FuzzySearch) && assert.Equal(t, search.Expansion{
+				Phrase:       "gorengan",
+				Alternatives: []string{"gorengan", "goreng"},
+			}, getRecipesQueryFilter.FuzzyName)
+		})).Return(&entity.RecipePage{Recipes: []entity.Recipe{}}, nil)
+		_, err := recipeUsecase.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{
+			SearchQuery: "Fried Rice, chicken",
+			Name:        "gorengan",
+			Fuzzy:       true,
+		})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test search without fuzzy does not load synonyms", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("GetRecipes", mock.Anything, mock.Anything).Return(&entity.RecipePage{Recipes: []entity.Recipe{}}, nil)
+		_, err := recipeUsecase.GetRecipes(context.Background(), &domain.GetRecipesQueryFilter{SearchQuery: "nasgor"})
+		assert.NoError(t, err)
+		mockRecipeRepository.AssertNotCalled(t, "GetSearchSynonyms", mock.Anything)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
+
+func TestRecipeUsecase_CreateSearchSynonym(t *testing.T) {
+	t.Run("test create normalized search synonym", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		mockRecipeRepository.On("CreateSearchSynonym", mock.Anything, &entity.SearchSynonym{Term: "fried rice", Replacement: "nasi goreng"}).Return(nil)
+		err := recipeUsecase.CreateSearchSynonym(context.Background(), &domain.SearchSynonymDTO{Term: " Fried  Rice ", Replacement: "Nasi-Goreng"})
+		assert.NoError(t, err)
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+
+	t.Run("test create invalid search synonym", func(t *testing.T) {
+		mockRecipeRepository := new(mocks.RecipeRepository)
+		recipeUsecase := NewRecipeUsecase(mockRecipeRepository)
+		for _, searchSynonymDTO := range []*domain.SearchSynonymDTO{
+			{Term: "!!!", Replacement: "nasi goreng"},
+			{Term: "Nasi Goreng", Replacement: "nasi goreng"},
+			{Term: "nasi goreng ayam kampung pedas", Replacement: "nasi goreng"},
+		} {
+			err := recipeUsecase.CreateSearchSynonym(context.Background(), searchSynonymDTO)
+			assert.ErrorIs(t, err, domain.ErrBadRequest)
+		}
+		defer mockRecipeRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/units"
+)
+
+// fractionTolerance is how close the fractional part of a quantity must be to a kitchen fraction to be displayed as one
+const fractionTolerance = 0.02
+
+var kitchenFractions = []struct {
+	value   float64
+	display string
+}{
+	{1.0 / 8, "⅛"},
+	{1.0 / 4, "¼"},
+	{1.0 / 3, "⅓"},
+	{1.0 / 2, "½"},
+	{2.0 / 3, "⅔"},
+	{3.0 / 4, "¾"},
+}
+
+// presentRecipeQuantities rescales the ingredient quantities to servings, converts them into the measurement system
+// and fills their display quantity. Servings lower than 1 keeps the recipe servings.
+func presentRecipeQuantities(recipe *entity.Recipe, servings int, system units.System) {
+	scaleRecipe(recipe, servings)
+	for i := range recipe.RecipeIngredients {
+		recipeIngredient := &recipe.RecipeIngredients[i]
+		recipeIngredient.Quantity, recipeIngredient.Unit = units.Convert(recipeIngredient.Quantity, recipeIngredient.Unit, system)
+		recipeIngredient.QuantityDisplay = formatQuantity(recipeIngredient.Quantity)
+	}
+}
+
+// scaleRecipe rescales the ingredient quantities of the recipe to the given servings, servings lower than 1 keeps the recipe servings
+func scaleRecipe(recipe *entity.Recipe, servings int) {
+	if servings <= 0 || recipe.Servings <= 0 || servings == recipe.Servings {
+		return
+	}
+	factor := float64(servings) / float64(recipe.Servings)
+	for i := range recipe.RecipeIngredients {
+		// quantities are stored as NUMERIC(10, 3), keep the same precision
+		recipe.RecipeIngredients[i].Quantity = math.Round(recipe.RecipeIngredients[i].Quantity*factor*1000) / 1000
+	}
+	recipe.Servings = servings
+}
+
+// formatQuantity renders a quantity the way it is written in a recipe eg: 1.5 -> "1½", 0.333 -> "⅓", 83.33 -> "83".
+// Quantities of 10 or more are rounded to whole numbers, smaller quantities use a kitchen fraction when close enough
+// to one and fall back to at most 2 decimals. Unmeasured (0) quantities render as an empty string.
+func formatQuantity(quantity float64) string {
+	if quantity <= 0 {
+		return ""
+	}
+	if quantity >= 10 {
+		return strconv.FormatFloat(math.Round(quantity), 'f', -1, 64)
+	}
+	whole, fraction := math.Modf(quantity)
+	switch {
+	case fraction < fractionTolerance:
+		if whole > 0 {
+			return strconv.FormatFloat(whole, 'f', -1, 64)
+		}
+	case fraction > 1-fractionTolerance:
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	default:
+		for _, kitchenFraction := range kitchenFractions {
+			if math.Abs(fraction-kitchenFraction.value) < fractionTolerance {
+				if whole == 0 {
+					return kitchenFraction.display
+				}
+				return strconv.FormatFloat(whole, 'f', -1, 64) + kitchenFraction.display
+			}
+		}
+	}
+	return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/units"
+)
+
+func TestFormatQuantity(t *testing.T) {
+	testCases := map[float64]string{
+		0:      "",
+		0.5:    "½",
+		0.25:   "¼",
+		0.333:  "⅓",
+		0.667:  "⅔",
+		0.125:  "⅛",
+		1.5:    "1½",
+		2.75:   "2¾",
+		3:      "3",
+		1.99:   "2",
+		0.01:   "0.01",
+		0.4:    "0.4",
+		2.1:    "2.1",
+		83.333: "83",
+		250:    "250",
+	}
+	for quantity, display := range testCases {
+		assert.Equal(t, display, formatQuantity(quantity), "quantity: %v", quantity)
+	}
+}
+
+func TestPresentRecipeQuantities(t *testing.T) {
+	newRecipe := func() *entity.Recipe {
+		return &entity.Recipe{
+			Servings: 4,
+			RecipeIngredients: []entity.RecipeIngredient{
+				{Name: "pasta", Quantity: 200, Unit: "g"},
+				{Name: "telur", Quantity: 2, Unit: "butir"},
+				{Name: "minyak zaitun", Quantity: 1, Unit: "sdm"},
+				{Name: "garam", Note: "secukupnya"},
+			},
+		}
+	}
+
+	t.Run("test scale recipe down", func(t *testing.T) {
+		recipe := newRecipe()
+		presentRecipeQuantities(recipe, 2, units.Original)
+		assert.Equal(t, 2, recipe.Servings)
+		assert.Equal(t, float64(100), recipe.RecipeIngredients[0].Quantity)
+		assert.Equal(t, "1", recipe.RecipeIngredients[1].QuantityDisplay)
+		assert.Equal(t, 0.5, recipe.RecipeIngredients[2].Quantity)
+		assert.Equal(t, "½", recipe.RecipeIngredients[2].QuantityDisplay)
+		assert.Equal(t, float64(0), recipe.RecipeIngredients[3].Quantity) // unmeasured stays unmeasured
+		assert.Equal(t, "", recipe.RecipeIngredients[3].QuantityDisplay)
+	})
+
+	t.Run("test scale recipe up", func(t *testing.T) {
+		recipe := newRecipe()
+		presentRecipeQuantities(recipe, 6, units.Original)
+		assert.Equal(t, 6, recipe.Servings)
+		assert.Equal(t, float64(300), recipe.RecipeIngredients[0].Quantity)
+		assert.Equal(t, "3", recipe.RecipeIngredients[1].QuantityDisplay)
+		assert.Equal(t, "1½", recipe.RecipeIngredients[2].QuantityDisplay)
+	})
+
+	t.Run("test keep recipe servings", func(t *testing.T) {
+		recipe := newRecipe()
+		presentRecipeQuantities(recipe, 0, units.Original)
+		assert.Equal(t, 4, recipe.Servings)
+		assert.Equal(t, float64(200), recipe.RecipeIngredients[0].Quantity)
+		assert.Equal(t, "200", recipe.RecipeIngredients[0].QuantityDisplay)
+	})
+
+	t.Run("test scale recipe before converting units", func(t *testing.T) {
+		recipe := newRecipe()
+		presentRecipeQuantities(recipe, 8, units.Metric)
+		assert.Equal(t, float64(400), recipe.RecipeIngredients[0].Quantity)
+		assert.Equal(t, "g", recipe.RecipeIngredients[0].Unit)
+		assert.Equal(t, "butir", recipe.RecipeIngredients[1].Unit) // count units are not converted
+		assert.Equal(t, float64(30), recipe.RecipeIngredients[2].Quantity)
+		assert.Equal(t, "ml", recipe.RecipeIngredients[2].Unit)
+		assert.Equal(t, "30", recipe.RecipeIngredients[2].QuantityDisplay)
+	})
+}
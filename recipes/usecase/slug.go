@@ -0,0 +1,16 @@
+package usecase
+
+import "github.com/victorsantoso/endeus/helper"
+
+// default slugs of a title without any latin letter or digit eg: "炒饭", the repository de-duplicates them as "resep-2", "resep-3"
+const (
+	defaultRecipeSlug         = "resep"
+	defaultRecipeCategorySlug = "kategori"
+)
+
+func toSlug(title, defaultSlug string) string {
+	if slug := helper.Slugify(title); slug != "" {
+		return slug
+	}
+	return defaultSlug
+}
@@ -0,0 +1,82 @@
+package search
+
+import "strings"
+
+// minStemLength keeps short words like "ikan" or "jamu" from being cut into meaningless stems
+const minStemLength = 4
+
+// Stem removes the common Indonesian affixes of a lowercased word eg: "menggoreng" -> "goreng", "gorengannya" -> "goreng".
+// It's a light stemmer without a root word dictionary so it rather cuts too much, eg: "masakan" -> "masa", since stems
+// are searched by prefix ("masa" still finds "masak") and only alongside the original words
+func Stem(word string) string {
+	word = trimSuffixes(word, "lah", "kah", "tah", "pun")
+	word = trimSuffixes(word, "nya", "ku", "mu")
+	word = trimPrefix(word)
+	// -an needs a longer stem since many roots end with it eg: "santan"
+	if stem := strings.TrimSuffix(word, "kan"); stem != word && len(stem) >= minStemLength {
+		return stem
+	}
+	if stem := strings.TrimSuffix(word, "an"); stem != word && len(stem) >= minStemLength+1 {
+		return stem
+	}
+	return word
+}
+
+// StemAll stems every word of a normalized text
+func StemAll(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = Stem(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func trimSuffixes(word string, suffixes ...string) string {
+	for _, suffix := range suffixes {
+		if stem := strings.TrimSuffix(word, suffix); stem != word && len(stem) >= minStemLength {
+			return stem
+		}
+	}
+	return word
+}
+
+// prefixRule removes prefix from words continuing with one of next, then puts back restore eg: "meny" + "ayur" -> "sayur"
+type prefixRule struct {
+	prefix  string
+	next    string
+	restore string
+}
+
+// prefixRules are tried in order, the longer nasal forms of me- and pe- come first
+var prefixRules = []prefixRule{
+	{prefix: "meng", next: "aeioughk"},
+	{prefix: "meny", next: "aeiou", restore: "s"},
+	{prefix: "mem", next: "bfp"},
+	{prefix: "men", next: "cdjt"},
+	{prefix: "me", next: "lmnrwy"},
+	{prefix: "peng", next: "aeioughk"},
+	{prefix: "peny", next: "aeiou", restore: "s"},
+	{prefix: "pem", next: "bfp"},
+	{prefix: "pen", next: "cdjt"},
+	{prefix: "ber"},
+	{prefix: "ter"},
+	{prefix: "di"},
+	// ke- and se- are left alone, too many ingredients start with them eg: "kentang", "kelapa", "seledri"
+}
+
+// trimPrefix needs a longer stem than the suffixes since short words often only look prefixed eg: "terasi", "dingin"
+func trimPrefix(word string) string {
+	for _, rule := range prefixRules {
+		rest := strings.TrimPrefix(word, rule.prefix)
+		if rest == word || rest == "" {
+			continue
+		}
+		if rule.next != "" && !strings.ContainsRune(rule.next, rune(rest[0])) {
+			continue
+		}
+		if stem := rule.restore + rest; len(stem) > minStemLength {
+			return stem
+		}
+	}
+	return word
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	testCases := []struct {
+		word string
+		stem string
+	}{
+		{"menggoreng", "goreng"},
+		{"digoreng", "goreng"},
+		{"gorengannya", "goreng"},
+		{"memasak", "masak"},
+		{"merebus", "rebus"},
+		{"menyangrai", "sangrai"},
+		{"membakar", "bakar"},
+		{"berbumbu", "bumbu"},
+		{"panaskan", "panas"},
+		{"sayuran", "sayur"},
+		// roots that only look affixed are kept
+		{"ikan", "ikan"},
+		{"santan", "santan"},
+		{"terasi", "terasi"},
+		{"dingin", "dingin"},
+		{"kentang", "kentang"},
+		{"jamu", "jamu"},
+		{"beras", "beras"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.stem, Stem(testCase.word), testCase.word)
+	}
+}
+
+func TestStemAll(t *testing.T) {
+	assert.Equal(t, "ayam goreng bumbu", StemAll("ayam digoreng berbumbu"))
+}
@@ -0,0 +1,67 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxSynonymWords is the longest term looked up in the synonyms eg: "soto ayam lamongan"
+const MaxSynonymWords = 4
+
+// Synonyms maps a normalized term of one or more words to its replacement eg: "nasgor" -> "nasi goreng", "fried rice" -> "nasi goreng"
+type Synonyms map[string]string
+
+// Normalize lowercases a text and keeps only its words of letters and digits separated by a single space
+func Normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Replace replaces the terms of a normalized text found in the synonyms, longer terms are replaced first
+func (sy Synonyms) Replace(text string) string {
+	words := strings.Fields(text)
+	var replaced []string
+	for i := 0; i < len(words); {
+		j := i + MaxSynonymWords
+		if j > len(words) {
+			j = len(words)
+		}
+		for ; j > i; j-- {
+			if replacement, ok := sy[strings.Join(words[i:j], " ")]; ok {
+				replaced = append(replaced, replacement)
+				break
+			}
+		}
+		// no term starting at words[i]
+		if j == i {
+			replaced = append(replaced, words[i])
+			j = i + 1
+		}
+		i = j
+	}
+	return strings.Join(replaced, " ")
+}
+
+// Expansion is a search query rewritten to tolerate synonyms, affixes and typos
+type Expansion struct {
+	// Phrase is the normalized query with its synonyms replaced, matched against titles by trigram similarity
+	Phrase string
+	// Alternatives are the normalized query, Phrase and their stems without duplicates, any of them may match
+	Alternatives []string
+}
+
+// Expand normalizes a query then replaces its synonyms and stems it
+func Expand(query string, synonyms Synonyms) Expansion {
+	normalized := Normalize(query)
+	expansion := Expansion{Phrase: synonyms.Replace(normalized)}
+	seen := make(map[string]bool)
+	for _, alternative := range []string{normalized, expansion.Phrase, StemAll(normalized), StemAll(expansion.Phrase)} {
+		if alternative == "" || seen[alternative] {
+			continue
+		}
+		seen[alternative] = true
+		expansion.Alternatives = append(expansion.Alternatives, alternative)
+	}
+	return expansion
+}
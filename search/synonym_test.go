@@ -0,0 +1,38 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "soto ayam lamongan", Normalize("  Soto-Ayam, LAMONGAN! "))
+	assert.Equal(t, "", Normalize("' & !"))
+}
+
+func TestSynonyms_Replace(t *testing.T) {
+	synonyms := Synonyms{
+		"nasgor":        "nasi goreng",
+		"fried rice":    "nasi goreng",
+		"fried":         "goreng",
+		"chicken":       "ayam",
+		"fried chicken": "ayam goreng",
+	}
+	assert.Equal(t, "nasi goreng pedas", synonyms.Replace("nasgor pedas"))
+	assert.Equal(t, "nasi goreng ayam", synonyms.Replace("fried rice chicken"))
+	// the longest term wins
+	assert.Equal(t, "ayam goreng", synonyms.Replace("fried chicken"))
+	assert.Equal(t, "tempe goreng", synonyms.Replace("tempe fried"))
+	assert.Equal(t, "", synonyms.Replace(""))
+}
+
+func TestExpand(t *testing.T) {
+	expansion := Expand("Nasgor Gorengan", Synonyms{"nasgor": "nasi goreng"})
+	assert.Equal(t, "nasi goreng gorengan", expansion.Phrase)
+	assert.Equal(t, []string{"nasgor gorengan", "nasi goreng gorengan", "nasgor goreng", "nasi goreng goreng"}, expansion.Alternatives)
+
+	expansion = Expand("soto ayam", nil)
+	assert.Equal(t, "soto ayam", expansion.Phrase)
+	assert.Equal(t, []string{"soto ayam"}, expansion.Alternatives)
+}
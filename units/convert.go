@@ -0,0 +1,49 @@
+package units
+
+import "math"
+
+// Convert converts a quantity written in unit into the most readable unit of the system eg: 2 sdm -> 30 ml, 1500 g -> 1.5 kg.
+// Unmeasured quantities, approximate units (eg: siung), unregistered units (eg: butir, batang, lembar) and the Original system
+// are returned unchanged.
+func Convert(quantity float64, unit string, system System) (float64, string) {
+	if quantity <= 0 || system == Original {
+		return quantity, unit
+	}
+	from, ok := Lookup(unit)
+	if !ok || from.Approximate {
+		return quantity, unit
+	}
+	base := quantity * from.Base
+	to := targetUnit(base, from.Dimension, system)
+	// quantities are stored with 3 decimals, keep the same precision
+	return math.Round(base/to.Base*1000) / 1000, to.Name
+}
+
+// targetUnit picks the unit of the system a cook would use for the amount of base unit (ml or g)
+func targetUnit(base float64, dimension Dimension, system System) Unit {
+	if system == Imperial {
+		if dimension == Volume {
+			switch {
+			case base < aliases["tbsp"].Base:
+				return aliases["tsp"]
+			case base < aliases["cup"].Base/4:
+				return aliases["tbsp"]
+			}
+			return aliases["cup"]
+		}
+		if base < aliases["lb"].Base {
+			return aliases["oz"]
+		}
+		return aliases["lb"]
+	}
+	if dimension == Volume {
+		if base < 1000 {
+			return aliases["ml"]
+		}
+		return aliases["l"]
+	}
+	if base < 1000 {
+		return aliases["g"]
+	}
+	return aliases["kg"]
+}
@@ -0,0 +1,91 @@
+package units
+
+import (
+	"errors"
+	"strings"
+)
+
+// System is the measurement system ingredient quantities are presented in
+type System string
+
+const (
+	Original System = "original" // keep the unit written in the recipe
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+var ErrUnknownSystem = errors.New("units must be one of: original, metric, imperial")
+
+// ParseSystem parses the units query, empty means Original
+func ParseSystem(system string) (System, error) {
+	switch System(strings.ToLower(strings.TrimSpace(system))) {
+	case "", Original:
+		return Original, nil
+	case Metric:
+		return Metric, nil
+	case Imperial:
+		return Imperial, nil
+	}
+	return "", ErrUnknownSystem
+}
+
+// Dimension groups units that can be converted into each other
+type Dimension int
+
+const (
+	Volume Dimension = iota + 1 // base unit ml
+	Mass                        // base unit g
+)
+
+// Unit is a registered unit, Base is the amount of the dimension base unit (ml or g) in one unit
+type Unit struct {
+	Name      string
+	Aliases   []string
+	Base      float64
+	Dimension Dimension
+	// Approximate is set for local units without a fixed size eg: siung, Convert keeps them as written
+	// and Base is only the estimate used to weigh ingredients without a known weight per piece
+	Approximate bool
+}
+
+// registry contains the canonical units, metric and imperial units are also the conversion targets
+var registry = []Unit{
+	// Metric
+	{Name: "ml", Aliases: []string{"mililiter", "milliliter", "cc"}, Base: 1, Dimension: Volume},
+	{Name: "l", Aliases: []string{"liter", "litre", "ltr"}, Base: 1000, Dimension: Volume},
+	{Name: "mg", Aliases: []string{"miligram", "milligram"}, Base: 0.001, Dimension: Mass},
+	{Name: "g", Aliases: []string{"gr", "gram", "grams"}, Base: 1, Dimension: Mass},
+	{Name: "kg", Aliases: []string{"kilo", "kilogram"}, Base: 1000, Dimension: Mass},
+	// Imperial (US customary)
+	{Name: "tsp", Aliases: []string{"teaspoon", "teaspoons"}, Base: 4.92892, Dimension: Volume},
+	{Name: "tbsp", Aliases: []string{"tablespoon", "tablespoons"}, Base: 14.7868, Dimension: Volume},
+	{Name: "fl oz", Aliases: []string{"fluid ounce", "fluid ounces"}, Base: 29.5735, Dimension: Volume},
+	{Name: "cup", Aliases: []string{"cups", "cangkir"}, Base: 236.588, Dimension: Volume},
+	{Name: "pint", Aliases: []string{"pints", "pt"}, Base: 473.176, Dimension: Volume},
+	{Name: "oz", Aliases: []string{"ounce", "ounces"}, Base: 28.3495, Dimension: Mass},
+	{Name: "lb", Aliases: []string{"lbs", "pound", "pounds"}, Base: 453.592, Dimension: Mass},
+	// Indonesian
+	{Name: "sdm", Aliases: []string{"sendok makan"}, Base: 15, Dimension: Volume},
+	{Name: "sdt", Aliases: []string{"sendok teh"}, Base: 5, Dimension: Volume},
+	{Name: "gelas", Aliases: []string{"gls"}, Base: 250, Dimension: Volume},
+	{Name: "ons", Base: 100, Dimension: Mass},
+	{Name: "pon", Base: 500, Dimension: Mass},
+	{Name: "siung", Base: 5, Dimension: Mass, Approximate: true}, // a clove of garlic or shallot
+}
+
+var aliases = make(map[string]Unit)
+
+func init() {
+	for _, unit := range registry {
+		aliases[unit.Name] = unit
+		for _, alias := range unit.Aliases {
+			aliases[alias] = unit
+		}
+	}
+}
+
+// Lookup returns the registered unit of a unit name or alias, case insensitive
+func Lookup(name string) (Unit, bool) {
+	unit, ok := aliases[strings.ToLower(strings.TrimSpace(name))]
+	return unit, ok
+}
@@ -0,0 +1,59 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSystem(t *testing.T) {
+	system, err := ParseSystem("")
+	assert.NoError(t, err)
+	assert.Equal(t, Original, system)
+	system, err = ParseSystem("Metric")
+	assert.NoError(t, err)
+	assert.Equal(t, Metric, system)
+	_, err = ParseSystem("nautical")
+	assert.ErrorIs(t, err, ErrUnknownSystem)
+}
+
+func TestLookup(t *testing.T) {
+	unit, ok := Lookup(" Sendok Makan ")
+	assert.True(t, ok)
+	assert.Equal(t, "sdm", unit.Name)
+	_, ok = Lookup("butir")
+	assert.False(t, ok)
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		name             string
+		quantity         float64
+		unit             string
+		system           System
+		expectedQuantity float64
+		expectedUnit     string
+	}{
+		{"test sdm to metric", 2, "sdm", Metric, 30, "ml"},
+		{"test gelas to liter", 6, "gelas", Metric, 1.5, "l"},
+		{"test ons to gram", 2.5, "ons", Metric, 250, "g"},
+		{"test pon to kilogram", 3, "pon", Metric, 1.5, "kg"},
+		{"test approximate siung is kept", 4, "siung", Metric, 4, "siung"},
+		{"test imperial cup to metric", 1, "cup", Metric, 236.588, "ml"},
+		{"test sdt to imperial", 1, "sdt", Imperial, 1.014, "tsp"},
+		{"test sdm to imperial", 3, "sdm", Imperial, 3.043, "tbsp"},
+		{"test milliliter to cup", 500, "ml", Imperial, 2.113, "cup"},
+		{"test gram to ounce", 200, "gram", Imperial, 7.055, "oz"},
+		{"test kilogram to pound", 1, "kg", Imperial, 2.205, "lb"},
+		{"test count unit is kept", 2, "butir", Metric, 2, "butir"},
+		{"test unmeasured is kept", 0, "sdt", Metric, 0, "sdt"},
+		{"test original is kept", 2, "sdm", Original, 2, "sdm"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			quantity, unit := Convert(testCase.quantity, testCase.unit, testCase.system)
+			assert.Equal(t, testCase.expectedQuantity, quantity)
+			assert.Equal(t, testCase.expectedUnit, unit)
+		})
+	}
+}
@@ -2,17 +2,21 @@ package handler
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/helper"
 )
 
 type userHandler struct {
 	userUsecase domain.UserUsecase
 }
 
-func NewUserHandler(g *gin.Engine, userUsecase domain.UserUsecase) {
+func NewUserHandler(g *gin.Engine, authMiddleware gin.HandlerFunc, requirePermission func(permissions ...string) gin.HandlerFunc, userUsecase domain.UserUsecase) {
 	userHandler := &userHandler{
 		userUsecase: userUsecase,
 	}
@@ -20,6 +24,26 @@ func NewUserHandler(g *gin.Engine, userUsecase domain.UserUsecase) {
 	userGroup := g.Group("/api/v1")
 	userGroup.POST("/register", userHandler.Register)
 	userGroup.POST("/login", userHandler.Login)
+	userGroup.POST("/token/refresh", userHandler.RefreshToken)
+	userGroup.POST("/invitations/accept", userHandler.AcceptInvitation)
+	userGroup.POST("/password/forgot", userHandler.ForgotPassword)
+	userGroup.POST("/password/reset", userHandler.ResetPassword)
+	userGroup.POST("/verify-email", userHandler.VerifyEmail)
+
+	authGroup := g.Group("/api/v1", authMiddleware)
+	authGroup.POST("/logout", userHandler.Logout)
+	// Current user
+	authGroup.GET("/me", userHandler.GetMe)
+	authGroup.PUT("/me", userHandler.UpdateMe)
+	authGroup.PUT("/me/password", userHandler.UpdatePassword)
+	authGroup.PUT("/me/email", userHandler.UpdateEmail)
+	authGroup.DELETE("/me", userHandler.DeleteMe)
+	authGroup.POST("/verify-email/resend", userHandler.ResendEmailVerification)
+	// Admin
+	authGroup.PUT("/admin/users/:userId/role", requirePermission(domain.PermissionUserManage), userHandler.UpdateUserRole)
+	authGroup.POST("/admin/invitations", requirePermission(domain.PermissionUserManage), userHandler.CreateInvitation)
+	authGroup.GET("/admin/invitations", requirePermission(domain.PermissionUserManage), userHandler.GetInvitations)
+	authGroup.DELETE("/admin/invitations/:invitationId", requirePermission(domain.PermissionUserManage), userHandler.DeleteInvitation)
 }
 
 func (uh *userHandler) Register(c *gin.Context) {
@@ -33,16 +57,8 @@ func (uh *userHandler) Register(c *gin.Context) {
 		})
 		return
 	}
-	// handle role validation
-	if registerDTO.Role != domain.ADMIN && registerDTO.Role != domain.READER {
-		c.JSON(http.StatusBadRequest, &domain.RegisterResponse{
-			Message: domain.ErrBadRequest.Error(),
-			Code:    http.StatusBadRequest,
-		})
-		return
-	}
 	// process registration and return access token
-	accessToken, err := uh.userUsecase.Register(context.Background(), registerDTO)
+	authTokens, err := uh.userUsecase.Register(context.Background(), registerDTO)
 	if err != nil {
 		if err == domain.ErrDuplicateUser {
 			c.JSON(http.StatusConflict, &domain.RegisterResponse{
@@ -60,9 +76,11 @@ func (uh *userHandler) Register(c *gin.Context) {
 
 	// return access token upon successful registration process
 	c.JSON(http.StatusOK, &domain.RegisterResponse{
-		AccessToken: accessToken,
-		Message:     "successfully registered a new user",
-		Code:        http.StatusOK,
+		AccessToken:  authTokens.AccessToken,
+		RefreshToken: authTokens.RefreshToken,
+		ExpiresIn:    authTokens.ExpiresIn,
+		Message:      "successfully registered a new user",
+		Code:         http.StatusOK,
 	})
 }
 
@@ -77,7 +95,7 @@ func (uh *userHandler) Login(c *gin.Context) {
 		return
 	}
 	// validate user login process
-	accessToken, err := uh.userUsecase.Login(context.Background(), loginDTO)
+	authTokens, err := uh.userUsecase.Login(context.Background(), loginDTO)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, &domain.LoginResponse{
 			Message: domain.ErrInvalidCredential.Error(),
@@ -87,8 +105,600 @@ func (uh *userHandler) Login(c *gin.Context) {
 	}
 	// return access token upon successful login process
 	c.JSON(http.StatusOK, &domain.LoginResponse{
-		AccessToken: accessToken,
-		Message:     "successfully logged in.",
+		AccessToken:  authTokens.AccessToken,
+		RefreshToken: authTokens.RefreshToken,
+		ExpiresIn:    authTokens.ExpiresIn,
+		Message:      "successfully logged in.",
+		Code:         http.StatusOK,
+	})
+}
+
+func (uh *userHandler) RefreshToken(c *gin.Context) {
+	refreshTokenDTO := &domain.RefreshTokenDTO{}
+	// handle validation error
+	if err := c.ShouldBindJSON(refreshTokenDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.RefreshTokenResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	// rotate the refresh token and return new tokens
+	authTokens, err := uh.userUsecase.RefreshToken(context.Background(), refreshTokenDTO)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidRefreshToken, domain.ErrRefreshTokenReused:
+			c.JSON(http.StatusUnauthorized, &domain.RefreshTokenResponse{
+				Message: err.Error(),
+				Code:    http.StatusUnauthorized,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.RefreshTokenResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.RefreshTokenResponse{
+		AccessToken:  authTokens.AccessToken,
+		RefreshToken: authTokens.RefreshToken,
+		ExpiresIn:    authTokens.ExpiresIn,
+		Message:      "successfully refreshed token",
+		Code:         http.StatusOK,
+	})
+}
+
+// Logout revokes the access token of the request and the refresh token of the session
+func (uh *userHandler) Logout(c *gin.Context) {
+	key, _ := c.Get("claims")
+	claims, ok := key.(*helper.Claims)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.LogoutResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	logoutDTO := &domain.LogoutDTO{}
+	// handle validation error
+	if err := c.ShouldBindJSON(logoutDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.LogoutResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	userId, err := claims.UserId()
+	if err != nil {
+		c.JSON(http.StatusForbidden, &domain.LogoutResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if err := uh.userUsecase.Logout(context.Background(), userId, claims.ID, claims.ExpiresAt.Time, logoutDTO); err != nil {
+		c.JSON(http.StatusInternalServerError, &domain.LogoutResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.LogoutResponse{
+		Message: "successfully logged out",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) UpdateUserRole(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdateUserRoleResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil || userId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.UpdateUserRoleResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	updateUserRoleDTO := &domain.UpdateUserRoleDTO{}
+	if err := c.ShouldBindJSON(updateUserRoleDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateUserRoleResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.UpdateUserRole(context.Background(), user.UserId, int64(userId), updateUserRoleDTO); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateUserRoleResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		case domain.ErrCannotChangeOwnRole:
+			c.JSON(http.StatusBadRequest, &domain.UpdateUserRoleResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.UpdateUserRoleResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateUserRoleResponse{
+		Message: "successfully updated user role",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) CreateInvitation(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.CreateInvitationResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	createInvitationDTO := &domain.CreateInvitationDTO{}
+	if err := c.ShouldBindJSON(createInvitationDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.CreateInvitationResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	invitation, token, err := uh.userUsecase.CreateInvitation(context.Background(), user.UserId, createInvitationDTO)
+	if err != nil {
+		if err == domain.ErrDuplicateUser {
+			c.JSON(http.StatusConflict, &domain.CreateInvitationResponse{
+				Message: domain.ErrDuplicateUser.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.CreateInvitationResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.CreateInvitationResponse{
+		Invitation: invitation,
+		Token:      token,
+		Message:    "successfully created invitation",
+		Code:       http.StatusOK,
+	})
+}
+
+func (uh *userHandler) GetInvitations(c *gin.Context) {
+	invitations, err := uh.userUsecase.GetInvitations(context.Background())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, &domain.GetInvitationsResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetInvitationsResponse{
+		Invitations: invitations,
+		Message:     "successfully retrieved invitations",
 		Code:        http.StatusOK,
 	})
 }
+
+func (uh *userHandler) DeleteInvitation(c *gin.Context) {
+	invitationId, err := strconv.Atoi(c.Param("invitationId"))
+	if err != nil || invitationId <= 0 {
+		c.JSON(http.StatusBadRequest, &domain.DeleteInvitationResponse{
+			Message: domain.ErrInvalidId.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.DeleteInvitation(context.Background(), int64(invitationId)); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.DeleteInvitationResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.DeleteInvitationResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteInvitationResponse{
+		Message: "successfully deleted invitation",
+		Code:    http.StatusOK,
+	})
+}
+
+// AcceptInvitation registers the invited email and returns the tokens like a registration
+func (uh *userHandler) AcceptInvitation(c *gin.Context) {
+	acceptInvitationDTO := &domain.AcceptInvitationDTO{}
+	if err := c.ShouldBindJSON(acceptInvitationDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.AcceptInvitationResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	authTokens, err := uh.userUsecase.AcceptInvitation(context.Background(), acceptInvitationDTO)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidInvitation:
+			c.JSON(http.StatusBadRequest, &domain.AcceptInvitationResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		case domain.ErrDuplicateUser:
+			c.JSON(http.StatusConflict, &domain.AcceptInvitationResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.AcceptInvitationResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.AcceptInvitationResponse{
+		AccessToken:  authTokens.AccessToken,
+		RefreshToken: authTokens.RefreshToken,
+		ExpiresIn:    authTokens.ExpiresIn,
+		Message:      "successfully accepted invitation",
+		Code:         http.StatusOK,
+	})
+}
+
+func (uh *userHandler) GetMe(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.GetMeResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	me, err := uh.userUsecase.GetMe(context.Background(), user.UserId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.GetMeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.GetMeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.GetMeResponse{
+		User:    me,
+		Message: "successfully retrieved user",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) UpdateMe(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdateMeResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	updateMeDTO := &domain.UpdateMeDTO{}
+	if err := c.ShouldBindJSON(updateMeDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateMeResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	me, err := uh.userUsecase.UpdateMe(context.Background(), user.UserId, updateMeDTO)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, &domain.UpdateMeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.UpdateMeResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateMeResponse{
+		User:    me,
+		Message: "successfully updated user",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) UpdatePassword(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdatePasswordResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	updatePasswordDTO := &domain.UpdatePasswordDTO{}
+	if err := c.ShouldBindJSON(updatePasswordDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdatePasswordResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	authTokens, err := uh.userUsecase.UpdatePassword(context.Background(), user.UserId, updatePasswordDTO)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidCredential:
+			c.JSON(http.StatusBadRequest, &domain.UpdatePasswordResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdatePasswordResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.UpdatePasswordResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdatePasswordResponse{
+		AccessToken:  authTokens.AccessToken,
+		RefreshToken: authTokens.RefreshToken,
+		ExpiresIn:    authTokens.ExpiresIn,
+		Message:      "successfully updated password",
+		Code:         http.StatusOK,
+	})
+}
+
+func (uh *userHandler) UpdateEmail(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.UpdateEmailResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	updateEmailDTO := &domain.UpdateEmailDTO{}
+	if err := c.ShouldBindJSON(updateEmailDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.UpdateEmailResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.UpdateEmail(context.Background(), user.UserId, updateEmailDTO); err != nil {
+		switch err {
+		case domain.ErrInvalidCredential:
+			c.JSON(http.StatusBadRequest, &domain.UpdateEmailResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		case domain.ErrDuplicateUser:
+			c.JSON(http.StatusConflict, &domain.UpdateEmailResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.UpdateEmailResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.UpdateEmailResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.UpdateEmailResponse{
+		Message: "successfully updated email",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) DeleteMe(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.DeleteMeResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	deleteMeDTO := &domain.DeleteMeDTO{}
+	if err := c.ShouldBindJSON(deleteMeDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.DeleteMeResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.DeleteMe(context.Background(), user.UserId, deleteMeDTO); err != nil {
+		switch err {
+		case domain.ErrInvalidCredential:
+			c.JSON(http.StatusBadRequest, &domain.DeleteMeResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.DeleteMeResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.DeleteMeResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.DeleteMeResponse{
+		Message: "successfully deleted user",
+		Code:    http.StatusOK,
+	})
+}
+
+// ForgotPassword responds the same whether the email is registered or not
+func (uh *userHandler) ForgotPassword(c *gin.Context) {
+	forgotPasswordDTO := &domain.ForgotPasswordDTO{}
+	if err := c.ShouldBindJSON(forgotPasswordDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.ForgotPasswordResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.ForgotPassword(context.Background(), forgotPasswordDTO); err != nil {
+		c.JSON(http.StatusInternalServerError, &domain.ForgotPasswordResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ForgotPasswordResponse{
+		Message: "if the email is registered, a password reset link has been sent to it",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) ResetPassword(c *gin.Context) {
+	resetPasswordDTO := &domain.ResetPasswordDTO{}
+	if err := c.ShouldBindJSON(resetPasswordDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.ResetPasswordResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.ResetPassword(context.Background(), resetPasswordDTO); err != nil {
+		if err == domain.ErrInvalidPasswordResetToken {
+			c.JSON(http.StatusBadRequest, &domain.ResetPasswordResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.ResetPasswordResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResetPasswordResponse{
+		Message: "successfully reset password, please login with the new password",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) VerifyEmail(c *gin.Context) {
+	verifyEmailDTO := &domain.VerifyEmailDTO{}
+	if err := c.ShouldBindJSON(verifyEmailDTO); err != nil {
+		c.JSON(http.StatusBadRequest, &domain.VerifyEmailResponse{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if err := uh.userUsecase.VerifyEmail(context.Background(), verifyEmailDTO); err != nil {
+		if err == domain.ErrInvalidEmailVerificationToken {
+			c.JSON(http.StatusBadRequest, &domain.VerifyEmailResponse{
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, &domain.VerifyEmailResponse{
+			Message: domain.ErrInternalServerError.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, &domain.VerifyEmailResponse{
+		Message: "successfully verified email",
+		Code:    http.StatusOK,
+	})
+}
+
+func (uh *userHandler) ResendEmailVerification(c *gin.Context) {
+	key, _ := c.Get("user")
+	user, ok := key.(*entity.User)
+	if !ok {
+		c.JSON(http.StatusForbidden, &domain.ResendEmailVerificationResponse{
+			Message: domain.ErrForbidenAccess.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if err := uh.userUsecase.ResendEmailVerification(context.Background(), user.UserId); err != nil {
+		switch err {
+		case domain.ErrEmailAlreadyVerified:
+			c.JSON(http.StatusConflict, &domain.ResendEmailVerificationResponse{
+				Message: err.Error(),
+				Code:    http.StatusConflict,
+			})
+		case domain.ErrEmailVerificationThrottled:
+			c.JSON(http.StatusTooManyRequests, &domain.ResendEmailVerificationResponse{
+				Message: err.Error(),
+				Code:    http.StatusTooManyRequests,
+			})
+		case sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, &domain.ResendEmailVerificationResponse{
+				Message: domain.ErrNotFound.Error(),
+				Code:    http.StatusNotFound,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, &domain.ResendEmailVerificationResponse{
+				Message: domain.ErrInternalServerError.Error(),
+				Code:    http.StatusInternalServerError,
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, &domain.ResendEmailVerificationResponse{
+		Message: "successfully sent verification email",
+		Code:    http.StatusOK,
+	})
+}
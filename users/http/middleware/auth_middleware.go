@@ -3,10 +3,10 @@ package middleware
 import (
 	"context"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/helper"
 )
@@ -16,55 +16,48 @@ func AuthMiddleware(userRepository domain.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// validate authorization
 		authorization := c.GetHeader("Authorization")
-		if len(authorization) == 0 {
+		if !strings.HasPrefix(authorization, "Bearer ") {
 			handleForbiddenAccess(c)
 			return
 		}
-		// validate bearer token existence
+		// validate bearer token signature, issuer, audience and expiry
 		token := authorization[len("Bearer "):]
-		jwtObj, err := helper.VerifyJwt(token)
-		var registeredClaims *jwt.RegisteredClaims
-		if jwtObj == nil {
+		claims, err := helper.VerifyJwt(token)
+		if err != nil {
 			handleForbiddenAccess(c)
 			return
 		}
-		// validate jwtObj with type assertions
-		switch t := jwtObj.Claims.(type) {
-		case *jwt.RegisteredClaims:
-			registeredClaims = t
-			if !jwtObj.Valid {
-				handleForbiddenAccess(c)
-				return
-			}
-		// set default status bad request
-		default:
+		// validate id
+		id, err := claims.UserId()
+		if err != nil {
 			handleForbiddenAccess(c)
 			return
 		}
-		// validate err and signature
-		if err != nil || err == jwt.ErrSignatureInvalid {
-			handleForbiddenAccess(c)
-			return
-		}
-		// validate id and role
-		id, err := strconv.Atoi(registeredClaims.ID)
-		if err != nil {
+		// validate the access token was not revoked by a logout
+		revoked, err := userRepository.IsAccessTokenRevoked(context.Background(), claims.ID)
+		if revoked || err != nil {
 			handleForbiddenAccess(c)
 			return
 		}
 		// validate user's existence
-		validateUser, err := userRepository.FindById(context.Background(), int64(id))
+		validateUser, err := userRepository.FindById(context.Background(), id)
 		if validateUser == nil || err != nil {
 			handleForbiddenAccess(c)
 			return
 		}
 		// validate user's role
-		if validateUser.Role != registeredClaims.Subject {
+		if validateUser.Role != claims.Role {
+			handleForbiddenAccess(c)
+			return
+		}
+		// validate the token was issued after the last password change or reset, iat only has a precision of seconds
+		if claims.IssuedAt == nil || claims.IssuedAt.Time.Before(validateUser.TokensValidAfter.Truncate(time.Second)) {
 			handleForbiddenAccess(c)
 			return
 		}
 		// set context with validated data
 		c.Set("user", validateUser)
+		c.Set("claims", claims)
 	}
 }
 
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/apex/log"
+	"github.com/gin-gonic/gin"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+// RequirePermission returns the middleware factory used at route registration, eg: requirePermission(domain.PermissionRecipeCreate).
+// It runs after AuthMiddleware and forbids the route unless the role of the user is granted every permission in the role_permissions table.
+func RequirePermission(userRepository domain.UserRepository) func(permissions ...string) gin.HandlerFunc {
+	return func(permissions ...string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			// validate the user set by the auth middleware
+			key, _ := c.Get("user")
+			user, ok := key.(*entity.User)
+			if !ok {
+				handleForbiddenAccess(c)
+				return
+			}
+			// validate the role is granted every permission
+			rolePermissions, err := userRepository.GetRolePermissions(context.Background(), user.Role)
+			if err != nil {
+				log.Errorf("[permission_middleware.RequirePermission] error getting permissions of role: %s, err: %v", user.Role, err)
+				handleForbiddenAccess(c)
+				return
+			}
+			granted := make(map[string]bool, len(rolePermissions))
+			for _, permission := range rolePermissions {
+				granted[permission] = true
+			}
+			for _, permission := range permissions {
+				if !granted[permission] {
+					handleForbiddenAccess(c)
+					return
+				}
+			}
+		}
+	}
+}
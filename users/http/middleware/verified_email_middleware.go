@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorsantoso/endeus/domain"
+	"github.com/victorsantoso/endeus/entity"
+)
+
+// RequireVerifiedEmail forbids the users who did not verify their email when enabled by application.require_verified_email.
+// It runs after AuthMiddleware, a disabled middleware lets every user through.
+func RequireVerifiedEmail(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			return
+		}
+		// validate the user set by the auth middleware
+		key, _ := c.Get("user")
+		user, ok := key.(*entity.User)
+		if !ok {
+			handleForbiddenAccess(c)
+			return
+		}
+		// validate the email of the user is verified
+		if user.EmailVerifiedAt == nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, &AuthMiddlewareResponse{
+				Message: domain.ErrEmailNotVerified.Error(),
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/lib/pq"
@@ -23,65 +24,635 @@ func NewUserRepository(dbConn *sql.DB) domain.UserRepository {
 const (
 	// query with variables handling to escape sql injections
 	CreateUserQuery = `
-		INSERT INTO users(role, email, password, name, profile_image, created_at, updated_at)
-		VALUES($1, $2, $3, $4, $5, now()::timestamptz, now()::timestamptz)
+		INSERT INTO users(role, email, password, name, profile_image, email_verified_at, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, now()::timestamptz, now()::timestamptz)
 		RETURNING role, user_id;
 	`
 	FindByEmailQuery = `
-		SELECT user_id, role, email, password, name, profile_image, created_at, updated_at FROM users WHERE email = $1;
+		SELECT user_id, role, email, password, name, profile_image, created_at, updated_at, email_verified_at, COALESCE(tokens_valid_after, created_at) FROM users WHERE email = $1;
 	`
 	FindByIdQuery = `
-		SELECT user_id, role, email, password, name, profile_image, created_at, updated_at FROM users WHERE user_id = $1;
+		SELECT user_id, role, email, password, name, profile_image, created_at, updated_at, email_verified_at, COALESCE(tokens_valid_after, created_at) FROM users WHERE user_id = $1;
+	`
+	// Refresh tokens, a token is found by its hash and locked so only one refresh can rotate it
+	CreateRefreshTokenQuery = `
+		INSERT INTO refresh_tokens(user_id, family_id, token_hash, expires_at, created_at)
+		VALUES($1, $2, $3, $4, now()::timestamptz)
+		RETURNING refresh_token_id;
+	`
+	GetRefreshTokenForUpdateQuery = `
+		SELECT refresh_token_id, user_id, family_id, expires_at, revoked_at IS NOT NULL
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE;
+	`
+	RevokeRefreshTokenQuery = `
+		UPDATE refresh_tokens SET revoked_at = now()::timestamptz WHERE refresh_token_id = $1;
+	`
+	RevokeRefreshTokenFamilyQuery = `
+		UPDATE refresh_tokens SET revoked_at = now()::timestamptz WHERE family_id = $1 AND revoked_at IS NULL;
+	`
+	// RevokeUserRefreshTokenFamilyQuery revokes the family of the token $1 only when it belongs to the user $2
+	RevokeUserRefreshTokenFamilyQuery = `
+		UPDATE refresh_tokens SET revoked_at = now()::timestamptz
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1 AND user_id = $2) AND revoked_at IS NULL;
+	`
+	// Revoked access tokens, a jti is only kept until its access token expires
+	RevokeAccessTokenQuery = `
+		INSERT INTO revoked_access_tokens(jti, expires_at) VALUES($1, $2) ON CONFLICT (jti) DO NOTHING;
+	`
+	DeleteExpiredRevokedAccessTokensQuery = `
+		DELETE FROM revoked_access_tokens WHERE expires_at < now();
+	`
+	IsAccessTokenRevokedQuery = `
+		SELECT EXISTS(SELECT 1 FROM revoked_access_tokens WHERE jti = $1);
+	`
+	UpdateUserRoleQuery = `
+		UPDATE users SET role = $2, updated_at = now()::timestamptz WHERE user_id = $1;
+	`
+	// Invitations, an invitation is pending until it is accepted or expires
+	DeletePendingInvitationsByEmailQuery = `
+		DELETE FROM invitations WHERE email = $1 AND accepted_at IS NULL;
+	`
+	CreateInvitationQuery = `
+		INSERT INTO invitations(email, role, token_hash, invited_by, expires_at, created_at)
+		VALUES($1, $2, $3, NULLIF($4, 0), $5, now()::timestamptz)
+		RETURNING invitation_id, created_at;
+	`
+	GetInvitationsQuery = `
+		SELECT invitation_id, email, role, COALESCE(invited_by, 0), expires_at, created_at
+		FROM invitations
+		WHERE accepted_at IS NULL AND expires_at > now()
+		ORDER BY created_at DESC, invitation_id DESC;
+	`
+	DeleteInvitationQuery = `
+		DELETE FROM invitations WHERE invitation_id = $1 AND accepted_at IS NULL;
+	`
+	GetPendingInvitationForUpdateQuery = `
+		SELECT invitation_id, email, role
+		FROM invitations
+		WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > now()
+		FOR UPDATE;
+	`
+	AcceptInvitationQuery = `
+		UPDATE invitations SET accepted_at = now()::timestamptz, accepted_user_id = $2 WHERE invitation_id = $1;
+	`
+	GetRolePermissionsQuery = `
+		SELECT permission FROM role_permissions WHERE role = $1 ORDER BY permission;
+	`
+	// Current user, a deleted user takes along its refresh tokens, ratings and discussions
+	UpdateUserProfileQuery = `
+		UPDATE users SET name = $2, profile_image = $3, updated_at = now()::timestamptz WHERE user_id = $1;
+	`
+	// UpdateUserPasswordQuery also rejects the access tokens issued before the change
+	UpdateUserPasswordQuery = `
+		UPDATE users SET password = $2, tokens_valid_after = now()::timestamptz, updated_at = now()::timestamptz WHERE user_id = $1;
+	`
+	RevokeUserRefreshTokensQuery = `
+		UPDATE refresh_tokens SET revoked_at = now()::timestamptz WHERE user_id = $1 AND revoked_at IS NULL;
+	`
+	// UpdateUserEmailQuery keeps the verification of an unchanged email, a new email has to be verified again
+	UpdateUserEmailQuery = `
+		UPDATE users SET email = $2, email_verified_at = CASE WHEN email = $2 THEN email_verified_at END, updated_at = now()::timestamptz
+		WHERE user_id = $1;
+	`
+	DeleteUserQuery = `
+		DELETE FROM users WHERE user_id = $1;
+	`
+	// Password reset tokens, a user only has its latest unused token
+	DeleteUnusedPasswordResetTokensQuery = `
+		DELETE FROM password_reset_tokens WHERE user_id = $1 AND used_at IS NULL;
+	`
+	CreatePasswordResetTokenQuery = `
+		INSERT INTO password_reset_tokens(user_id, token_hash, expires_at, created_at)
+		VALUES($1, $2, $3, now()::timestamptz)
+		RETURNING password_reset_token_id;
+	`
+	GetLatestPasswordResetTokenQuery = `
+		SELECT password_reset_token_id, user_id, expires_at, created_at
+		FROM password_reset_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC, password_reset_token_id DESC
+		LIMIT 1;
+	`
+	GetPasswordResetTokenForUpdateQuery = `
+		SELECT password_reset_token_id, user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		FOR UPDATE;
+	`
+	UsePasswordResetTokenQuery = `
+		UPDATE password_reset_tokens SET used_at = now()::timestamptz WHERE password_reset_token_id = $1;
+	`
+	// Email verification tokens, a token only verifies the user while its email is unchanged
+	DeleteUnusedEmailVerificationTokensQuery = `
+		DELETE FROM email_verification_tokens WHERE user_id = $1 AND used_at IS NULL;
+	`
+	CreateEmailVerificationTokenQuery = `
+		INSERT INTO email_verification_tokens(user_id, email, token_hash, expires_at, created_at)
+		VALUES($1, $2, $3, $4, now()::timestamptz)
+		RETURNING email_verification_token_id, created_at;
+	`
+	GetLatestEmailVerificationTokenQuery = `
+		SELECT email_verification_token_id, user_id, email, expires_at, created_at
+		FROM email_verification_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC, email_verification_token_id DESC
+		LIMIT 1;
+	`
+	GetEmailVerificationTokenForUpdateQuery = `
+		SELECT t.email_verification_token_id, t.user_id
+		FROM email_verification_tokens t
+		JOIN users u ON u.user_id = t.user_id AND u.email = t.email
+		WHERE t.token_hash = $1 AND t.used_at IS NULL AND t.expires_at > now()
+		FOR UPDATE OF t;
+	`
+	UseEmailVerificationTokenQuery = `
+		UPDATE email_verification_tokens SET used_at = now()::timestamptz WHERE email_verification_token_id = $1;
+	`
+	VerifyUserEmailQuery = `
+		UPDATE users SET email_verified_at = now()::timestamptz, updated_at = now()::timestamptz
+		WHERE user_id = $1 AND email_verified_at IS NULL;
 	`
 )
 
 func (ur *userRepository) Create(ctx context.Context, user *entity.User) (string, int64, error) {
-    var userId int64
-    var role string
-    // begin transaction
-    tx, err := ur.dbConn.Begin()
-    if err != nil {
-        return "", 0, err // Return the error immediately
-    }
-    defer func() {
-        if err != nil {
-            tx.Rollback()
-            return
-        }
-        tx.Commit()
-    }()
-    if err := tx.QueryRowContext(ctx, CreateUserQuery, user.Role, user.Email, user.Password, user.Name, user.ProfileImage).Scan(&role, &userId); err != nil {
-        if pqError, ok := err.(*pq.Error); ok {
-            if pqError.Code == "23505" { // unique violation code on email
-                return role, userId, domain.ErrDuplicateUser
-            }
-        }
-        return role, userId, domain.ErrInvalidRole
-    }
-    log.Debugf("[user_repository] user with user_id: %d, role: %s created", userId, role)
-    return role, userId, nil // Return nil error here
+	var userId int64
+	var role string
+	// begin transaction
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return "", 0, err // Return the error immediately
+	}
+	if err := tx.QueryRowContext(ctx, CreateUserQuery, user.Role, user.Email, user.Password, user.Name, user.ProfileImage, user.EmailVerifiedAt).Scan(&role, &userId); err != nil {
+		tx.Rollback()
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation code on email
+				return "", 0, domain.ErrDuplicateUser
+			}
+		}
+		return "", 0, domain.ErrInvalidRole
+	}
+	if err := tx.Commit(); err != nil {
+		return "", 0, err
+	}
+	log.Debugf("[user_repository] user with user_id: %d, role: %s created", userId, role)
+	return role, userId, nil
 }
 
+// FindByEmail returns sql.ErrNoRows when no user is registered with the email
 func (ur *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-    var user entity.User
-    row := ur.dbConn.QueryRowContext(ctx, FindByEmailQuery, email)
-    if err := row.Scan(&user.UserId, &user.Role, &user.Email, &user.Password, &user.Name, &user.ProfileImage, &user.CreatedAt, &user.UpdatedAt); err != nil {
-        if err == sql.ErrNoRows {
-            return nil, nil // Return nil, nil if no rows found
-        }
-        return nil, err
-    }
-    return &user, nil
+	var user entity.User
+	row := ur.dbConn.QueryRowContext(ctx, FindByEmailQuery, email)
+	if err := row.Scan(&user.UserId, &user.Role, &user.Email, &user.Password, &user.Name, &user.ProfileImage, &user.CreatedAt, &user.UpdatedAt, &user.EmailVerifiedAt, &user.TokensValidAfter); err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
+// FindById returns sql.ErrNoRows when the user does not exist
 func (ur *userRepository) FindById(ctx context.Context, userId int64) (*entity.User, error) {
-    var user entity.User
-    row := ur.dbConn.QueryRowContext(ctx, FindByIdQuery, userId)
-    if err := row.Scan(&user.UserId, &user.Role, &user.Email, &user.Password, &user.Name, &user.ProfileImage, &user.CreatedAt, &user.UpdatedAt); err != nil {
-        if err == sql.ErrNoRows {
-            return nil, nil // Return nil, nil if no rows found
-        }
-        return nil, err
-    }
-    return &user, nil
-}
\ No newline at end of file
+	var user entity.User
+	row := ur.dbConn.QueryRowContext(ctx, FindByIdQuery, userId)
+	if err := row.Scan(&user.UserId, &user.Role, &user.Email, &user.Password, &user.Name, &user.ProfileImage, &user.CreatedAt, &user.UpdatedAt, &user.EmailVerifiedAt, &user.TokensValidAfter); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (ur *userRepository) CreateRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	return ur.dbConn.QueryRowContext(ctx, CreateRefreshTokenQuery,
+		refreshToken.UserId,
+		refreshToken.FamilyId,
+		refreshToken.TokenHash,
+		refreshToken.ExpiresAt,
+	).Scan(&refreshToken.RefreshTokenId)
+}
+
+// RotateRefreshToken revokes the token of tokenHash and stores nextRefreshToken in its family for the same user.
+// A token that was already revoked is being reused, eg: stolen and refreshed by someone else, so its whole family is revoked.
+func (ur *userRepository) RotateRefreshToken(ctx context.Context, tokenHash string, nextRefreshToken *entity.RefreshToken) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	var refreshTokenId int64
+	var expiresAt time.Time
+	var revoked bool
+	err = tx.QueryRowContext(ctx, GetRefreshTokenForUpdateQuery, tokenHash).
+		Scan(&refreshTokenId, &nextRefreshToken.UserId, &nextRefreshToken.FamilyId, &expiresAt, &revoked)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return domain.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	if revoked {
+		if _, err := tx.ExecContext(ctx, RevokeRefreshTokenFamilyQuery, nextRefreshToken.FamilyId); err != nil {
+			tx.Rollback()
+			return err
+		}
+		// the family stays revoked even though the refresh fails
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Debugf("[user_repository] refresh token family of user_id: %d revoked on reuse", nextRefreshToken.UserId)
+		return domain.ErrRefreshTokenReused
+	}
+	if !expiresAt.After(time.Now()) {
+		tx.Rollback()
+		return domain.ErrInvalidRefreshToken
+	}
+	if _, err := tx.ExecContext(ctx, RevokeRefreshTokenQuery, refreshTokenId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.QueryRowContext(ctx, CreateRefreshTokenQuery,
+		nextRefreshToken.UserId,
+		nextRefreshToken.FamilyId,
+		nextRefreshToken.TokenHash,
+		nextRefreshToken.ExpiresAt,
+	).Scan(&nextRefreshToken.RefreshTokenId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// RevokeRefreshTokenFamily revokes every token issued since the login of the token, an unknown token is ignored
+func (ur *userRepository) RevokeRefreshTokenFamily(ctx context.Context, userId int64, tokenHash string) error {
+	_, err := ur.dbConn.ExecContext(ctx, RevokeUserRefreshTokenFamilyQuery, tokenHash, userId)
+	return err
+}
+
+// RevokeAccessToken denies the jti until expiresAt, the expired jti are purged on the way
+func (ur *userRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, DeleteExpiredRevokedAccessTokensQuery); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, RevokeAccessTokenQuery, jti, expiresAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (ur *userRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	if err := ur.dbConn.QueryRowContext(ctx, IsAccessTokenRevokedQuery, jti).Scan(&revoked); err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
+
+// UpdateUserRole returns sql.ErrNoRows when the user does not exist
+func (ur *userRepository) UpdateUserRole(ctx context.Context, userId int64, role string) error {
+	result, err := ur.dbConn.ExecContext(ctx, UpdateUserRoleQuery, userId, role)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CreateInvitation replaces the pending invitations of the email so only the latest token can be accepted
+func (ur *userRepository) CreateInvitation(ctx context.Context, invitation *entity.Invitation) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, DeletePendingInvitationsByEmailQuery, invitation.Email); err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.QueryRowContext(ctx, CreateInvitationQuery,
+		invitation.Email,
+		invitation.Role,
+		invitation.TokenHash,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+	).Scan(&invitation.InvitationId, &invitation.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetInvitations returns the pending invitations, newest first
+func (ur *userRepository) GetInvitations(ctx context.Context) ([]entity.Invitation, error) {
+	rows, err := ur.dbConn.QueryContext(ctx, GetInvitationsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	invitations := []entity.Invitation{}
+	for rows.Next() {
+		var invitation entity.Invitation
+		if err := rows.Scan(&invitation.InvitationId, &invitation.Email, &invitation.Role, &invitation.InvitedBy, &invitation.ExpiresAt, &invitation.CreatedAt); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// DeleteInvitation revokes a pending invitation, returns sql.ErrNoRows when there is no pending invitation with the id
+func (ur *userRepository) DeleteInvitation(ctx context.Context, invitationId int64) error {
+	result, err := ur.dbConn.ExecContext(ctx, DeleteInvitationQuery, invitationId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AcceptInvitation creates the user with the email and role of the pending invitation of tokenHash,
+// the email, role and user_id are set into user
+func (ur *userRepository) AcceptInvitation(ctx context.Context, tokenHash string, user *entity.User) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	var invitationId int64
+	if err := tx.QueryRowContext(ctx, GetPendingInvitationForUpdateQuery, tokenHash).Scan(&invitationId, &user.Email, &user.Role); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return domain.ErrInvalidInvitation
+		}
+		return err
+	}
+	var role string
+	if err := tx.QueryRowContext(ctx, CreateUserQuery, user.Role, user.Email, user.Password, user.Name, user.ProfileImage, user.EmailVerifiedAt).Scan(&role, &user.UserId); err != nil {
+		tx.Rollback()
+		if pqError, ok := err.(*pq.Error); ok {
+			if pqError.Code == "23505" { // unique violation code on email, registered since the invitation
+				return domain.ErrDuplicateUser
+			}
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, AcceptInvitationQuery, invitationId, user.UserId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetRolePermissions returns the permissions granted to the role, a role without permissions returns an empty slice
+func (ur *userRepository) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	rows, err := ur.dbConn.QueryContext(ctx, GetRolePermissionsQuery, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	permissions := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// UpdateUserProfile returns sql.ErrNoRows when the user does not exist
+func (ur *userRepository) UpdateUserProfile(ctx context.Context, userId int64, name string, profileImage string) error {
+	result, err := ur.dbConn.ExecContext(ctx, UpdateUserProfileQuery, userId, name, profileImage)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateUserPassword stores the new password hash and revokes every refresh token of the user
+func (ur *userRepository) UpdateUserPassword(ctx context.Context, userId int64, password string) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, UpdateUserPasswordQuery, userId, password)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+	if _, err := tx.ExecContext(ctx, RevokeUserRefreshTokensQuery, userId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateUserEmail returns domain.ErrDuplicateUser when another user is registered with the email
+func (ur *userRepository) UpdateUserEmail(ctx context.Context, userId int64, email string) error {
+	result, err := ur.dbConn.ExecContext(ctx, UpdateUserEmailQuery, userId, email)
+	if err != nil {
+		if pqError, ok := err.(*pq.Error); ok && pqError.Code == "23505" {
+			return domain.ErrDuplicateUser
+		}
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteUser returns sql.ErrNoRows when the user does not exist
+func (ur *userRepository) DeleteUser(ctx context.Context, userId int64) error {
+	result, err := ur.dbConn.ExecContext(ctx, DeleteUserQuery, userId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CreatePasswordResetToken replaces the unused reset tokens of the user so only the latest mail can reset the password
+func (ur *userRepository) CreatePasswordResetToken(ctx context.Context, passwordResetToken *entity.PasswordResetToken) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, DeleteUnusedPasswordResetTokensQuery, passwordResetToken.UserId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.QueryRowContext(ctx, CreatePasswordResetTokenQuery,
+		passwordResetToken.UserId,
+		passwordResetToken.TokenHash,
+		passwordResetToken.ExpiresAt,
+	).Scan(&passwordResetToken.PasswordResetTokenId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ResetPassword uses the reset token of tokenHash to store the new password hash and returns the user id.
+// Every session of the user ends, its refresh tokens are revoked and its access tokens are rejected.
+// Returns domain.ErrInvalidPasswordResetToken when the token is unknown, expired or already used.
+func (ur *userRepository) ResetPassword(ctx context.Context, tokenHash string, password string) (int64, error) {
+	var passwordResetTokenId, userId int64
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.QueryRowContext(ctx, GetPasswordResetTokenForUpdateQuery, tokenHash).Scan(&passwordResetTokenId, &userId); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrInvalidPasswordResetToken
+		}
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, UsePasswordResetTokenQuery, passwordResetTokenId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, UpdateUserPasswordQuery, userId, password); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, RevokeUserRefreshTokensQuery, userId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return userId, nil
+}
+
+// CreateEmailVerificationToken replaces the unused verification tokens of the user so only the latest mail can verify it
+func (ur *userRepository) CreateEmailVerificationToken(ctx context.Context, emailVerificationToken *entity.EmailVerificationToken) error {
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, DeleteUnusedEmailVerificationTokensQuery, emailVerificationToken.UserId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.QueryRowContext(ctx, CreateEmailVerificationTokenQuery,
+		emailVerificationToken.UserId,
+		emailVerificationToken.Email,
+		emailVerificationToken.TokenHash,
+		emailVerificationToken.ExpiresAt,
+	).Scan(&emailVerificationToken.EmailVerificationTokenId, &emailVerificationToken.CreatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetLatestPasswordResetToken returns sql.ErrNoRows when no password reset mail was sent to the user
+func (ur *userRepository) GetLatestPasswordResetToken(ctx context.Context, userId int64) (*entity.PasswordResetToken, error) {
+	var passwordResetToken entity.PasswordResetToken
+	row := ur.dbConn.QueryRowContext(ctx, GetLatestPasswordResetTokenQuery, userId)
+	if err := row.Scan(
+		&passwordResetToken.PasswordResetTokenId,
+		&passwordResetToken.UserId,
+		&passwordResetToken.ExpiresAt,
+		&passwordResetToken.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &passwordResetToken, nil
+}
+
+// GetLatestEmailVerificationToken returns sql.ErrNoRows when no verification mail was sent to the user
+func (ur *userRepository) GetLatestEmailVerificationToken(ctx context.Context, userId int64) (*entity.EmailVerificationToken, error) {
+	var emailVerificationToken entity.EmailVerificationToken
+	row := ur.dbConn.QueryRowContext(ctx, GetLatestEmailVerificationTokenQuery, userId)
+	if err := row.Scan(
+		&emailVerificationToken.EmailVerificationTokenId,
+		&emailVerificationToken.UserId,
+		&emailVerificationToken.Email,
+		&emailVerificationToken.ExpiresAt,
+		&emailVerificationToken.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &emailVerificationToken, nil
+}
+
+// VerifyEmail uses the verification token of tokenHash to verify the email of its user and returns the user id.
+// Returns domain.ErrInvalidEmailVerificationToken when the token is unknown, expired, already used or issued for a former email.
+func (ur *userRepository) VerifyEmail(ctx context.Context, tokenHash string) (int64, error) {
+	var emailVerificationTokenId, userId int64
+	tx, err := ur.dbConn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.QueryRowContext(ctx, GetEmailVerificationTokenForUpdateQuery, tokenHash).Scan(&emailVerificationTokenId, &userId); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrInvalidEmailVerificationToken
+		}
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, UseEmailVerificationTokenQuery, emailVerificationTokenId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, VerifyUserEmailQuery, userId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return userId, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"regexp"
 	"testing"
 	"time"
@@ -159,7 +160,7 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 				email: "testtest@gmail.com",
 			},
 			testFunction: func(t *testing.T, tt args) {
-				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at", "email_verified_at", "tokens_valid_after"})
 				mock.ExpectQuery(regexp.QuoteMeta(FindByEmailQuery)).WillReturnRows(rows)
 				user, err := userRepository.FindByEmail(context.Background(), tt.email)
 				assert.Error(t, err)
@@ -186,8 +187,8 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 					CreatedAt: time.Now().UTC(),
 					UpdatedAt: time.Now().UTC(),
 				}
-				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at"})
-				rows.AddRow(testUser.UserId, testUser.Role, testUser.Email, hashedPassword, testUser.Name, testUser.ProfileImage, testUser.CreatedAt, testUser.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at", "email_verified_at", "tokens_valid_after"})
+				rows.AddRow(testUser.UserId, testUser.Role, testUser.Email, hashedPassword, testUser.Name, testUser.ProfileImage, testUser.CreatedAt, testUser.UpdatedAt, nil, testUser.CreatedAt)
 				mock.ExpectQuery(regexp.QuoteMeta(FindByEmailQuery)).WillReturnRows(rows) // expect query will return rows
 				user, err := userRepository.FindByEmail(context.Background(), tt.email)
 				assert.NoError(t, err)
@@ -222,7 +223,7 @@ func TestUserRepository_FindById(t *testing.T) {
 				userId: 1,
 			},
 			testFunction: func(t *testing.T, tt args) {
-				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at", "email_verified_at", "tokens_valid_after"})
 				mock.ExpectQuery(regexp.QuoteMeta(FindByIdQuery)).WillReturnRows(rows)
 				user, err := userRepository.FindById(context.Background(), tt.userId)
 				assert.Error(t, err)
@@ -249,8 +250,8 @@ func TestUserRepository_FindById(t *testing.T) {
 					CreatedAt: time.Now().UTC(),
 					UpdatedAt: time.Now().UTC(),
 				}
-				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at"})
-				rows.AddRow(testUser.UserId, testUser.Role, testUser.Email, hashedPassword, testUser.Name, testUser.ProfileImage, testUser.CreatedAt, testUser.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"user_id", "role", "email", "password", "name", "profile_image", "created_at", "updated_at", "email_verified_at", "tokens_valid_after"})
+				rows.AddRow(testUser.UserId, testUser.Role, testUser.Email, hashedPassword, testUser.Name, testUser.ProfileImage, testUser.CreatedAt, testUser.UpdatedAt, nil, testUser.CreatedAt)
 				mock.ExpectQuery(regexp.QuoteMeta(FindByIdQuery)).WillReturnRows(rows) // expect query will return rows
 				user, err := userRepository.FindById(context.Background(), tt.userId)
 				assert.NoError(t, err)
@@ -265,3 +266,235 @@ func TestUserRepository_FindById(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_RotateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+	columns := []string{"refresh_token_id", "user_id", "family_id", "expires_at", "revoked"}
+
+	t.Run("test rotate refresh token", func(t *testing.T) {
+		nextExpiresAt := time.Now().Add(time.Hour)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRefreshTokenForUpdateQuery)).
+			WithArgs("current-hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 1, "family", time.Now().Add(time.Hour), false))
+		mock.ExpectExec(regexp.QuoteMeta(RevokeRefreshTokenQuery)).
+			WithArgs(int64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateRefreshTokenQuery)).
+			WithArgs(int64(1), "family", "next-hash", nextExpiresAt).
+			WillReturnRows(sqlmock.NewRows([]string{"refresh_token_id"}).AddRow(5))
+		mock.ExpectCommit()
+		nextRefreshToken := &entity.RefreshToken{TokenHash: "next-hash", ExpiresAt: nextExpiresAt}
+		err := userRepository.RotateRefreshToken(context.Background(), "current-hash", nextRefreshToken)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), nextRefreshToken.RefreshTokenId)
+		assert.Equal(t, int64(1), nextRefreshToken.UserId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test reuse of a rotated refresh token revokes its family", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRefreshTokenForUpdateQuery)).
+			WithArgs("current-hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 1, "family", time.Now().Add(time.Hour), true))
+		mock.ExpectExec(regexp.QuoteMeta(RevokeRefreshTokenFamilyQuery)).
+			WithArgs("family").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := userRepository.RotateRefreshToken(context.Background(), "current-hash", &entity.RefreshToken{TokenHash: "next-hash"})
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rotate expired refresh token", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRefreshTokenForUpdateQuery)).
+			WithArgs("current-hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 1, "family", time.Now().Add(-time.Minute), false))
+		mock.ExpectRollback()
+		err := userRepository.RotateRefreshToken(context.Background(), "current-hash", &entity.RefreshToken{TokenHash: "next-hash"})
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test rotate unknown refresh token", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetRefreshTokenForUpdateQuery)).
+			WithArgs("unknown-hash").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+		err := userRepository.RotateRefreshToken(context.Background(), "unknown-hash", &entity.RefreshToken{TokenHash: "next-hash"})
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_AcceptInvitation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+
+	t.Run("test accept invitation creates the user with the invited role", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetPendingInvitationForUpdateQuery)).
+			WithArgs("invitation-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"invitation_id", "email", "role"}).AddRow(3, "chef@gmail.com", domain.ADMIN))
+		mock.ExpectQuery(regexp.QuoteMeta(CreateUserQuery)).
+			WithArgs(domain.ADMIN, "chef@gmail.com", "hashed-password", "Chef Juna", "", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"role", "user_id"}).AddRow(domain.ADMIN, 2))
+		mock.ExpectExec(regexp.QuoteMeta(AcceptInvitationQuery)).
+			WithArgs(int64(3), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		user := &entity.User{Password: "hashed-password", Name: "Chef Juna"}
+		err := userRepository.AcceptInvitation(context.Background(), "invitation-hash", user)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), user.UserId)
+		assert.Equal(t, domain.ADMIN, user.Role)
+		assert.Equal(t, "chef@gmail.com", user.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test accept expired or accepted invitation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetPendingInvitationForUpdateQuery)).
+			WithArgs("invitation-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"invitation_id", "email", "role"}))
+		mock.ExpectRollback()
+		err := userRepository.AcceptInvitation(context.Background(), "invitation-hash", &entity.User{Password: "hashed-password", Name: "Chef Juna"})
+		assert.ErrorIs(t, err, domain.ErrInvalidInvitation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_GetRolePermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+
+	t.Run("test get permissions of a role", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetRolePermissionsQuery)).
+			WithArgs(domain.CONTRIBUTOR).
+			WillReturnRows(sqlmock.NewRows([]string{"permission"}).AddRow(domain.PermissionRecipeCreate).AddRow(domain.PermissionRecipeUpdate))
+		permissions, err := userRepository.GetRolePermissions(context.Background(), domain.CONTRIBUTOR)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{domain.PermissionRecipeCreate, domain.PermissionRecipeUpdate}, permissions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test get permissions of a role without permissions", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetRolePermissionsQuery)).
+			WithArgs(domain.READER).
+			WillReturnRows(sqlmock.NewRows([]string{"permission"}))
+		permissions, err := userRepository.GetRolePermissions(context.Background(), domain.READER)
+		assert.NoError(t, err)
+		assert.Empty(t, permissions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_UpdateUserPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+
+	t.Run("test update password revokes every refresh token of the user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(UpdateUserPasswordQuery)).
+			WithArgs(int64(1), "hashed-password").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(RevokeUserRefreshTokensQuery)).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		err := userRepository.UpdateUserPassword(context.Background(), 1, "hashed-password")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test update password of unknown user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(UpdateUserPasswordQuery)).
+			WithArgs(int64(9), "hashed-password").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		err := userRepository.UpdateUserPassword(context.Background(), 9, "hashed-password")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+
+	t.Run("test reset password ends every session of the user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetPasswordResetTokenForUpdateQuery)).
+			WithArgs("reset-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"password_reset_token_id", "user_id"}).AddRow(4, 1))
+		mock.ExpectExec(regexp.QuoteMeta(UsePasswordResetTokenQuery)).
+			WithArgs(int64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(UpdateUserPasswordQuery)).
+			WithArgs(int64(1), "hashed-password").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(RevokeUserRefreshTokensQuery)).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+		userId, err := userRepository.ResetPassword(context.Background(), "reset-hash", "hashed-password")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), userId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test reset password with expired or used token", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetPasswordResetTokenForUpdateQuery)).
+			WithArgs("reset-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"password_reset_token_id", "user_id"}))
+		mock.ExpectRollback()
+		_, err := userRepository.ResetPassword(context.Background(), "reset-hash", "hashed-password")
+		assert.ErrorIs(t, err, domain.ErrInvalidPasswordResetToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserRepository_VerifyEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	userRepository := NewUserRepository(db)
+
+	t.Run("test verify email", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetEmailVerificationTokenForUpdateQuery)).
+			WithArgs("verification-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"email_verification_token_id", "user_id"}).AddRow(5, 1))
+		mock.ExpectExec(regexp.QuoteMeta(UseEmailVerificationTokenQuery)).
+			WithArgs(int64(5)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(VerifyUserEmailQuery)).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		userId, err := userRepository.VerifyEmail(context.Background(), "verification-hash")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), userId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("test verify email with a token of a former email", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(GetEmailVerificationTokenForUpdateQuery)).
+			WithArgs("verification-hash").
+			WillReturnRows(sqlmock.NewRows([]string{"email_verification_token_id", "user_id"}))
+		mock.ExpectRollback()
+		_, err := userRepository.VerifyEmail(context.Background(), "verification-hash")
+		assert.ErrorIs(t, err, domain.ErrInvalidEmailVerificationToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,42 +2,68 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/entity"
 	"github.com/victorsantoso/endeus/helper"
+	"github.com/victorsantoso/endeus/internal"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// invitationExpiration is how long an invitation can be accepted
+	invitationExpiration = 7 * 24 * time.Hour
+	// passwordResetExpiration is how long the link of a password reset mail can be used
+	passwordResetExpiration = time.Hour
+	// passwordResetResendInterval throttles the password reset mails of a user
+	passwordResetResendInterval = time.Minute
+	// emailVerificationExpiration is how long the link of a verification mail can be used
+	emailVerificationExpiration = 24 * time.Hour
+	// emailVerificationResendInterval throttles the verification mails of a user
+	emailVerificationResendInterval = time.Minute
+)
+
+var mailerConfig = internal.ConfigureMailer()
+
 type userUsecase struct {
 	userRepository domain.UserRepository
+	mailer         domain.Mailer
 }
 
-func NewUserUsecase(userRepository domain.UserRepository) domain.UserUsecase {
+func NewUserUsecase(userRepository domain.UserRepository, mailer domain.Mailer) domain.UserUsecase {
 	return &userUsecase{
 		userRepository: userRepository,
+		mailer:         mailer,
 	}
 }
 
-func (uu *userUsecase) Register(ctx context.Context, registerDTO *domain.RegisterDTO) (string, error) {
+func (uu *userUsecase) Register(ctx context.Context, registerDTO *domain.RegisterDTO) (*domain.AuthTokens, error) {
 	// validation for register user
-	validateUser, _ := uu.userRepository.FindByEmail(ctx, registerDTO.Email)
+	validateUser, err := uu.userRepository.FindByEmail(ctx, registerDTO.Email)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("[user_usecase.Register] error finding user with email: %s, err: %v", registerDTO.Email, err)
+		return nil, err
+	}
 	if validateUser != nil {
-		log.Debugf("[user_usecase.Register] user already registered with: %+v", validateUser)
-		return "", domain.ErrDuplicateUser
+		log.Debugf("[user_usecase.Register] user already registered with user_id: %d", validateUser.UserId)
+		return nil, domain.ErrDuplicateUser
 	}
 
 	// hash password for security purposes
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registerDTO.Password), bcrypt.DefaultCost)
 	if err != nil {
 		log.Errorf("[user_usecase.Register] error generating password hash, err: %v", err)
-		return "", err
+		return nil, err
 	}
 
-	// create a new user
+	// create a new user, admins are only created by an invitation or the create-admin command
 	role, userId, err := uu.userRepository.Create(ctx, &entity.User{
-		Role:         registerDTO.Role,
+		Role:         domain.READER,
 		Email:        registerDTO.Email,
 		Password:     string(hashedPassword),
 		Name:         registerDTO.Name,
@@ -51,36 +77,474 @@ func (uu *userUsecase) Register(ctx context.Context, registerDTO *domain.Registe
 			log.Debugf("[user_usecase.Register] duplicate entry, err: %v", err)
 		}
 		log.Errorf("[user_usecase.Register] error creating a new user, err: %v", err)
-		return "", err
+		return nil, err
+	}
+	// a failed verification mail does not fail the registration, the user can ask for another one
+	if err := uu.sendEmailVerification(ctx, userId, registerDTO.Email, registerDTO.Name); err != nil {
+		log.Errorf("[user_usecase.Register] error sending verification mail to user_id: %d, err: %v", userId, err)
 	}
 
-	// generate access token
-	accessToken, err := helper.GenerateJWT(role, userId)
+	// generate access and refresh tokens
+	authTokens, err := uu.login(ctx, role, userId)
+	if err != nil {
+		log.Errorf("[user_usecase.Register] error generating tokens, err: %v", err)
+		return nil, err
+	}
+	return authTokens, nil
+}
+
+// CreateAdmin creates an admin without logging in, used to bootstrap the first admin
+func (uu *userUsecase) CreateAdmin(ctx context.Context, createAdminDTO *domain.CreateAdminDTO) (int64, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(createAdminDTO.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Errorf("[user_usecase.CreateAdmin] error generating password hash, err: %v", err)
+		return 0, err
+	}
+	// the email of an admin created from the command line is trusted
+	emailVerifiedAt := time.Now()
+	_, userId, err := uu.userRepository.Create(ctx, &entity.User{
+		Role:            domain.ADMIN,
+		Email:           createAdminDTO.Email,
+		Password:        string(hashedPassword),
+		Name:            createAdminDTO.Name,
+		EmailVerifiedAt: &emailVerifiedAt,
+	})
+	if err != nil {
+		log.Errorf("[user_usecase.CreateAdmin] error creating admin with email: %s, err: %v", createAdminDTO.Email, err)
+		return 0, err
+	}
+	return userId, nil
+}
+
+// UpdateUserRole changes the role of another user, the access tokens of the user are rejected from now on since their role differs
+func (uu *userUsecase) UpdateUserRole(ctx context.Context, adminId int64, userId int64, updateUserRoleDTO *domain.UpdateUserRoleDTO) error {
+	// an admin demoting itself could leave no admin at all
+	if adminId == userId {
+		return domain.ErrCannotChangeOwnRole
+	}
+	if err := uu.userRepository.UpdateUserRole(ctx, userId, updateUserRoleDTO.Role); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[user_usecase.UpdateUserRole] user_id: %d not found", userId)
+			return err
+		}
+		log.Errorf("[user_usecase.UpdateUserRole] error updating role of user_id: %d, err: %v", userId, err)
+		return err
+	}
+	return nil
+}
+
+// CreateInvitation invites an email that is not registered yet, the token is returned once to be sent to the invitee
+func (uu *userUsecase) CreateInvitation(ctx context.Context, adminId int64, createInvitationDTO *domain.CreateInvitationDTO) (*entity.Invitation, string, error) {
+	validateUser, err := uu.userRepository.FindByEmail(ctx, createInvitationDTO.Email)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("[user_usecase.CreateInvitation] error finding user with email: %s, err: %v", createInvitationDTO.Email, err)
+		return nil, "", err
+	}
+	// a registered user gets its role changed instead
+	if validateUser != nil {
+		return nil, "", domain.ErrDuplicateUser
+	}
+	token, err := helper.GenerateToken()
+	if err != nil {
+		log.Errorf("[user_usecase.CreateInvitation] error generating token, err: %v", err)
+		return nil, "", err
+	}
+	role := createInvitationDTO.Role
+	if role == "" {
+		role = domain.ADMIN
+	}
+	invitation := &entity.Invitation{
+		Email:     createInvitationDTO.Email,
+		Role:      role,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(invitationExpiration).UTC(),
+		InvitedBy: adminId,
+	}
+	if err := uu.userRepository.CreateInvitation(ctx, invitation); err != nil {
+		log.Errorf("[user_usecase.CreateInvitation] error creating invitation for email: %s, err: %v", createInvitationDTO.Email, err)
+		return nil, "", err
+	}
+	return invitation, token, nil
+}
+
+func (uu *userUsecase) GetInvitations(ctx context.Context) ([]entity.Invitation, error) {
+	invitations, err := uu.userRepository.GetInvitations(ctx)
+	if err != nil {
+		log.Errorf("[user_usecase.GetInvitations] error getting invitations, err: %v", err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (uu *userUsecase) DeleteInvitation(ctx context.Context, invitationId int64) error {
+	if err := uu.userRepository.DeleteInvitation(ctx, invitationId); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[user_usecase.DeleteInvitation] no pending invitation_id: %d", invitationId)
+			return err
+		}
+		log.Errorf("[user_usecase.DeleteInvitation] error deleting invitation_id: %d, err: %v", invitationId, err)
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation registers the invited email with the role of the invitation and logs the new user in
+func (uu *userUsecase) AcceptInvitation(ctx context.Context, acceptInvitationDTO *domain.AcceptInvitationDTO) (*domain.AuthTokens, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(acceptInvitationDTO.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Errorf("[user_usecase.AcceptInvitation] error generating password hash, err: %v", err)
+		return nil, err
+	}
+	// the invited email is vouched for by the admin who invited it
+	emailVerifiedAt := time.Now()
+	user := &entity.User{
+		Password:        string(hashedPassword),
+		Name:            acceptInvitationDTO.Name,
+		ProfileImage:    acceptInvitationDTO.ProfileImage,
+		EmailVerifiedAt: &emailVerifiedAt,
+	}
+	if err := uu.userRepository.AcceptInvitation(ctx, helper.HashToken(acceptInvitationDTO.Token), user); err != nil {
+		if err == domain.ErrInvalidInvitation || err == domain.ErrDuplicateUser {
+			log.Debugf("[user_usecase.AcceptInvitation] invitation rejected, err: %v", err)
+			return nil, err
+		}
+		log.Errorf("[user_usecase.AcceptInvitation] error accepting invitation, err: %v", err)
+		return nil, err
+	}
+	authTokens, err := uu.login(ctx, user.Role, user.UserId)
 	if err != nil {
-		log.Errorf("[user_usecase.Register] error generating access token, err: %v", err)
-		return "", err // Return the error here instead of nil
+		log.Errorf("[user_usecase.AcceptInvitation] error generating tokens, err: %v", err)
+		return nil, err
 	}
-	return accessToken, nil
+	return authTokens, nil
 }
 
-func (uu *userUsecase) Login(ctx context.Context, loginDTO *domain.LoginDTO) (string, error) {
+func (uu *userUsecase) Login(ctx context.Context, loginDTO *domain.LoginDTO) (*domain.AuthTokens, error) {
 	// validate user existence
 	user, err := uu.userRepository.FindByEmail(ctx, loginDTO.Email)
-	if user == nil || err != nil {
+	if err != nil {
 		log.Debugf("[user_usecase.Login] failed to find user with email: %s, err: %v", loginDTO.Email, err)
-		return "", err // Return the error here
+		return nil, err
 	}
 	// validate user password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginDTO.Password))
 	if err != nil {
 		log.Debugf("[user_usecase.Login] failed on compare hash and password process, err: %v", err)
-		return "", err
+		return nil, err
 	}
-	// generate jwt if the credential is valid
-	accessToken, err := helper.GenerateJWT(user.Role, user.UserId)
+	// generate jwt and refresh token if the credential is valid
+	authTokens, err := uu.login(ctx, user.Role, user.UserId)
 	if err != nil {
-		log.Errorf("[user_usecase.Login] failed on generating jwt process: %v", err)
-		return "", err // Return the error here instead of nil
+		log.Errorf("[user_usecase.Login] failed on generating tokens process: %v", err)
+		return nil, err
+	}
+	return authTokens, nil
+}
+
+// login issues an access token and the first refresh token of a new family
+func (uu *userUsecase) login(ctx context.Context, role string, userId int64) (*domain.AuthTokens, error) {
+	familyId, err := helper.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := helper.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := uu.userRepository.CreateRefreshToken(ctx, &entity.RefreshToken{
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: helper.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(helper.RefreshTokenExpiration()),
+	}); err != nil {
+		return nil, err
+	}
+	return newAuthTokens(role, userId, refreshToken)
+}
+
+func newAuthTokens(role string, userId int64, refreshToken string) (*domain.AuthTokens, error) {
+	accessToken, err := helper.GenerateJWT(role, userId)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(helper.AccessTokenExpiration().Seconds()),
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token, the presented one cannot be used again
+func (uu *userUsecase) RefreshToken(ctx context.Context, refreshTokenDTO *domain.RefreshTokenDTO) (*domain.AuthTokens, error) {
+	refreshToken, err := helper.GenerateToken()
+	if err != nil {
+		log.Errorf("[user_usecase.RefreshToken] error generating refresh token, err: %v", err)
+		return nil, err
+	}
+	nextRefreshToken := &entity.RefreshToken{
+		TokenHash: helper.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(helper.RefreshTokenExpiration()),
+	}
+	if err := uu.userRepository.RotateRefreshToken(ctx, helper.HashToken(refreshTokenDTO.RefreshToken), nextRefreshToken); err != nil {
+		if err == domain.ErrInvalidRefreshToken || err == domain.ErrRefreshTokenReused {
+			log.Debugf("[user_usecase.RefreshToken] refresh token rejected, err: %v", err)
+			return nil, err
+		}
+		log.Errorf("[user_usecase.RefreshToken] error rotating refresh token, err: %v", err)
+		return nil, err
+	}
+	// the role is read again so a role change applies from the next refresh
+	user, err := uu.userRepository.FindById(ctx, nextRefreshToken.UserId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		log.Errorf("[user_usecase.RefreshToken] error finding user_id: %d, err: %v", nextRefreshToken.UserId, err)
+		return nil, err
+	}
+	authTokens, err := newAuthTokens(user.Role, user.UserId, refreshToken)
+	if err != nil {
+		log.Errorf("[user_usecase.RefreshToken] error generating access token, err: %v", err)
+		return nil, err
+	}
+	return authTokens, nil
+}
+
+// Logout revokes the access token of jti until it expires and the refresh token family of the session
+func (uu *userUsecase) Logout(ctx context.Context, userId int64, jti string, accessTokenExpiresAt time.Time, logoutDTO *domain.LogoutDTO) error {
+	if err := uu.userRepository.RevokeAccessToken(ctx, jti, accessTokenExpiresAt); err != nil {
+		log.Errorf("[user_usecase.Logout] error revoking access token of user_id: %d, err: %v", userId, err)
+		return err
+	}
+	if err := uu.userRepository.RevokeRefreshTokenFamily(ctx, userId, helper.HashToken(logoutDTO.RefreshToken)); err != nil {
+		log.Errorf("[user_usecase.Logout] error revoking refresh tokens of user_id: %d, err: %v", userId, err)
+		return err
 	}
-	return accessToken, nil
+	return nil
+}
+
+func (uu *userUsecase) GetMe(ctx context.Context, userId int64) (*entity.User, error) {
+	user, err := uu.userRepository.FindById(ctx, userId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[user_usecase.GetMe] no row found for user_id: %d", userId)
+			return nil, sql.ErrNoRows
+		}
+		log.Errorf("[user_usecase.GetMe] error finding user_id: %d, err: %v", userId, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+func (uu *userUsecase) UpdateMe(ctx context.Context, userId int64, updateMeDTO *domain.UpdateMeDTO) (*entity.User, error) {
+	if err := uu.userRepository.UpdateUserProfile(ctx, userId, updateMeDTO.Name, updateMeDTO.ProfileImage); err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[user_usecase.UpdateMe] no row found for user_id: %d", userId)
+			return nil, sql.ErrNoRows
+		}
+		log.Errorf("[user_usecase.UpdateMe] error updating profile of user_id: %d, err: %v", userId, err)
+		return nil, err
+	}
+	return uu.GetMe(ctx, userId)
+}
+
+// UpdatePassword logs out every session of the user and returns the tokens of a new session
+func (uu *userUsecase) UpdatePassword(ctx context.Context, userId int64, updatePasswordDTO *domain.UpdatePasswordDTO) (*domain.AuthTokens, error) {
+	user, err := uu.verifyPassword(ctx, userId, updatePasswordDTO.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(updatePasswordDTO.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Errorf("[user_usecase.UpdatePassword] error generating password hash, err: %v", err)
+		return nil, err
+	}
+	if err := uu.userRepository.UpdateUserPassword(ctx, userId, string(hashedPassword)); err != nil {
+		log.Errorf("[user_usecase.UpdatePassword] error updating password of user_id: %d, err: %v", userId, err)
+		return nil, err
+	}
+	authTokens, err := uu.login(ctx, user.Role, user.UserId)
+	if err != nil {
+		log.Errorf("[user_usecase.UpdatePassword] error generating tokens, err: %v", err)
+		return nil, err
+	}
+	return authTokens, nil
+}
+
+// UpdateEmail changes the email of the user, a new email is unverified until the user opens the link mailed to it
+func (uu *userUsecase) UpdateEmail(ctx context.Context, userId int64, updateEmailDTO *domain.UpdateEmailDTO) error {
+	user, err := uu.verifyPassword(ctx, userId, updateEmailDTO.CurrentPassword)
+	if err != nil {
+		return err
+	}
+	if err := uu.userRepository.UpdateUserEmail(ctx, userId, updateEmailDTO.Email); err != nil {
+		if err == domain.ErrDuplicateUser {
+			log.Debugf("[user_usecase.UpdateEmail] email: %s is already registered", updateEmailDTO.Email)
+			return err
+		}
+		log.Errorf("[user_usecase.UpdateEmail] error updating email of user_id: %d, err: %v", userId, err)
+		return err
+	}
+	if user.Email == updateEmailDTO.Email {
+		return nil
+	}
+	// the email is already changed, a failed verification mail can be sent again by the user
+	if err := uu.sendEmailVerification(ctx, userId, updateEmailDTO.Email, user.Name); err != nil {
+		log.Errorf("[user_usecase.UpdateEmail] error sending verification mail to user_id: %d, err: %v", userId, err)
+	}
+	return nil
+}
+
+// DeleteMe deletes the user along with its sessions, ratings and discussions
+func (uu *userUsecase) DeleteMe(ctx context.Context, userId int64, deleteMeDTO *domain.DeleteMeDTO) error {
+	if _, err := uu.verifyPassword(ctx, userId, deleteMeDTO.CurrentPassword); err != nil {
+		return err
+	}
+	if err := uu.userRepository.DeleteUser(ctx, userId); err != nil {
+		log.Errorf("[user_usecase.DeleteMe] error deleting user_id: %d, err: %v", userId, err)
+		return err
+	}
+	return nil
+}
+
+// verifyPassword re-verifies the current user before changing its credentials, a wrong password returns domain.ErrInvalidCredential
+func (uu *userUsecase) verifyPassword(ctx context.Context, userId int64, password string) (*entity.User, error) {
+	user, err := uu.GetMe(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		log.Debugf("[user_usecase.verifyPassword] wrong password for user_id: %d", userId)
+		return nil, domain.ErrInvalidCredential
+	}
+	return user, nil
+}
+
+// ForgotPassword mails a password reset link when a user is registered with the email, at most once per passwordResetResendInterval.
+// An unknown email, a throttled request or a failed mail is not an error so the response does not reveal which emails are registered.
+func (uu *userUsecase) ForgotPassword(ctx context.Context, forgotPasswordDTO *domain.ForgotPasswordDTO) error {
+	user, err := uu.userRepository.FindByEmail(ctx, forgotPasswordDTO.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debugf("[user_usecase.ForgotPassword] no user registered with email: %s", forgotPasswordDTO.Email)
+			return nil
+		}
+		log.Errorf("[user_usecase.ForgotPassword] error finding user with email: %s, err: %v", forgotPasswordDTO.Email, err)
+		return err
+	}
+	latestPasswordResetToken, err := uu.userRepository.GetLatestPasswordResetToken(ctx, user.UserId)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("[user_usecase.ForgotPassword] error getting latest password reset of user_id: %d, err: %v", user.UserId, err)
+		return err
+	}
+	if latestPasswordResetToken != nil && time.Since(latestPasswordResetToken.CreatedAt) < passwordResetResendInterval {
+		log.Debugf("[user_usecase.ForgotPassword] password reset of user_id: %d was sent at %v", user.UserId, latestPasswordResetToken.CreatedAt)
+		return nil
+	}
+	token, err := helper.GenerateToken()
+	if err != nil {
+		log.Errorf("[user_usecase.ForgotPassword] error generating token, err: %v", err)
+		return err
+	}
+	if err := uu.userRepository.CreatePasswordResetToken(ctx, &entity.PasswordResetToken{
+		UserId:    user.UserId,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetExpiration),
+	}); err != nil {
+		log.Errorf("[user_usecase.ForgotPassword] error creating password reset token of user_id: %d, err: %v", user.UserId, err)
+		return err
+	}
+	// the user can ask for another link once the interval passed
+	if err := uu.mailer.Send(ctx, &domain.Mail{
+		To:      user.Email,
+		Subject: "Reset your Endeus password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below within %d minutes to choose a new password:\n%s?token=%s\n\nIf you did not ask for a password reset, you can ignore this email.\n",
+			user.Name, int(passwordResetExpiration.Minutes()), mailerConfig.PasswordResetUrl, url.QueryEscape(token)),
+	}); err != nil {
+		log.Errorf("[user_usecase.ForgotPassword] error sending password reset mail to user_id: %d, err: %v", user.UserId, err)
+	}
+	return nil
+}
+
+// ResetPassword sets the new password with a token of ForgotPassword, every session of the user ends
+func (uu *userUsecase) ResetPassword(ctx context.Context, resetPasswordDTO *domain.ResetPasswordDTO) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(resetPasswordDTO.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Errorf("[user_usecase.ResetPassword] error generating password hash, err: %v", err)
+		return err
+	}
+	userId, err := uu.userRepository.ResetPassword(ctx, helper.HashToken(resetPasswordDTO.Token), string(hashedPassword))
+	if err != nil {
+		if err == domain.ErrInvalidPasswordResetToken {
+			log.Debugf("[user_usecase.ResetPassword] password reset token rejected")
+			return err
+		}
+		log.Errorf("[user_usecase.ResetPassword] error resetting password, err: %v", err)
+		return err
+	}
+	log.Debugf("[user_usecase.ResetPassword] password of user_id: %d was reset", userId)
+	return nil
+}
+
+// VerifyEmail verifies the email of the user with a token of a verification mail
+func (uu *userUsecase) VerifyEmail(ctx context.Context, verifyEmailDTO *domain.VerifyEmailDTO) error {
+	userId, err := uu.userRepository.VerifyEmail(ctx, helper.HashToken(verifyEmailDTO.Token))
+	if err != nil {
+		if err == domain.ErrInvalidEmailVerificationToken {
+			log.Debugf("[user_usecase.VerifyEmail] email verification token rejected")
+			return err
+		}
+		log.Errorf("[user_usecase.VerifyEmail] error verifying email, err: %v", err)
+		return err
+	}
+	log.Debugf("[user_usecase.VerifyEmail] email of user_id: %d verified", userId)
+	return nil
+}
+
+// ResendEmailVerification mails another verification link, at most once per emailVerificationResendInterval
+func (uu *userUsecase) ResendEmailVerification(ctx context.Context, userId int64) error {
+	user, err := uu.GetMe(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		log.Debugf("[user_usecase.ResendEmailVerification] email of user_id: %d is already verified", userId)
+		return domain.ErrEmailAlreadyVerified
+	}
+	latestEmailVerificationToken, err := uu.userRepository.GetLatestEmailVerificationToken(ctx, userId)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("[user_usecase.ResendEmailVerification] error getting latest verification of user_id: %d, err: %v", userId, err)
+		return err
+	}
+	if latestEmailVerificationToken != nil && time.Since(latestEmailVerificationToken.CreatedAt) < emailVerificationResendInterval {
+		log.Debugf("[user_usecase.ResendEmailVerification] verification of user_id: %d was sent at %v", userId, latestEmailVerificationToken.CreatedAt)
+		return domain.ErrEmailVerificationThrottled
+	}
+	if err := uu.sendEmailVerification(ctx, userId, user.Email, user.Name); err != nil {
+		log.Errorf("[user_usecase.ResendEmailVerification] error sending verification mail to user_id: %d, err: %v", userId, err)
+		return err
+	}
+	return nil
+}
+
+// sendEmailVerification mails a verification link for email, the previous links of the user stop working
+func (uu *userUsecase) sendEmailVerification(ctx context.Context, userId int64, email string, name string) error {
+	token, err := helper.GenerateToken()
+	if err != nil {
+		return err
+	}
+	if err := uu.userRepository.CreateEmailVerificationToken(ctx, &entity.EmailVerificationToken{
+		UserId:    userId,
+		Email:     email,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(emailVerificationExpiration),
+	}); err != nil {
+		return err
+	}
+	return uu.mailer.Send(ctx, &domain.Mail{
+		To:      email,
+		Subject: "Verify your Endeus email",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below within %d hours to verify your email:\n%s?token=%s\n\nIf you did not register to Endeus, you can ignore this email.\n",
+			name, int(emailVerificationExpiration.Hours()), mailerConfig.EmailVerificationUrl, url.QueryEscape(token)),
+	})
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/victorsantoso/endeus/domain"
 	"github.com/victorsantoso/endeus/entity"
+	"github.com/victorsantoso/endeus/helper"
 	mocks "github.com/victorsantoso/endeus/mocks/domain"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -18,29 +20,41 @@ func TestUserUsecase_Register(t *testing.T) {
 
 	t.Run("test register success", func(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
-		userUsecase := NewUserUsecase(mockUserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
 		registerDTO := &domain.RegisterDTO{
-			Role:         domain.ADMIN,
 			Email:        "testtest@gmail.com",
 			Password:     "Test*999",
 			Name:         "Test User",
 			ProfileImage: "Google Cloud Storage Sample Path",
 		}
 		mockUserRepository.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
-		mockUserRepository.On("Create", mock.Anything, mock.Anything).Return(registerDTO.Role, int64(1), nil)
-		accessToken, err := userUsecase.Register(context.Background(), registerDTO)
+		mockUserRepository.On("Create", mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Role == domain.READER && user.EmailVerifiedAt == nil // self registration never creates an admin nor a verified user
+		})).Return(domain.READER, int64(1), nil)
+		mockUserRepository.On("CreateEmailVerificationToken", mock.Anything, mock.MatchedBy(func(emailVerificationToken *entity.EmailVerificationToken) bool {
+			return emailVerificationToken.UserId == 1 && emailVerificationToken.Email == registerDTO.Email && len(emailVerificationToken.TokenHash) == 64
+		})).Return(nil)
+		mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Mail) bool {
+			return m.To == registerDTO.Email && strings.Contains(m.Body, "?token=")
+		})).Return(nil)
+		mockUserRepository.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(refreshToken *entity.RefreshToken) bool {
+			return refreshToken.UserId == 1 && refreshToken.FamilyId != "" && len(refreshToken.TokenHash) == 64
+		})).Return(nil)
+		authTokens, err := userUsecase.Register(context.Background(), registerDTO)
 		assert.NoError(t, err)
-		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, authTokens.AccessToken)
+		assert.NotEmpty(t, authTokens.RefreshToken)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mock.Anything, mock.Anything)
 		mockUserRepository.AssertCalled(t, "Create", mock.Anything, mock.Anything)
 		defer mockUserRepository.AssertExpectations(t)
+		defer mockMailer.AssertExpectations(t)
 	})
 
 	t.Run("test register failed", func(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
-		userUsecase := NewUserUsecase(mockUserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
 		registerDTO := &domain.RegisterDTO{
-			Role:         "random", // register with invalid role
 			Email:        "testtest@gmail.com",
 			Password:     "Test*999",
 			Name:         "Test User",
@@ -48,9 +62,9 @@ func TestUserUsecase_Register(t *testing.T) {
 		}
 		mockUserRepository.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 		mockUserRepository.On("Create", mock.Anything, mock.Anything).Return("", int64(0), domain.ErrInvalidRole)
-		accessToken, err := userUsecase.Register(context.Background(), registerDTO)
+		authTokens, err := userUsecase.Register(context.Background(), registerDTO)
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
+		assert.Nil(t, authTokens)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mock.Anything, mock.Anything)
 		mockUserRepository.AssertCalled(t, "Create", mock.Anything, mock.Anything)
 		defer mockUserRepository.AssertExpectations(t)
@@ -58,9 +72,8 @@ func TestUserUsecase_Register(t *testing.T) {
 
 	t.Run("test register existing user", func(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
-		userUsecase := NewUserUsecase(mockUserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
 		registerDTO := &domain.RegisterDTO{
-			Role:         domain.ADMIN,
 			Email:        "testtest@gmail.com",
 			Password:     "Test*999",
 			Name:         "Test User",
@@ -68,7 +81,7 @@ func TestUserUsecase_Register(t *testing.T) {
 		}
 		mockUserRepository.On("FindByEmail", mock.Anything, mock.Anything).Return(&entity.User{
 			UserId:       1,
-			Role:         registerDTO.Role,
+			Role:         domain.READER,
 			Email:        registerDTO.Email,
 			Password:     registerDTO.Password,
 			Name:         registerDTO.Name,
@@ -76,9 +89,9 @@ func TestUserUsecase_Register(t *testing.T) {
 			CreatedAt:    time.Now().UTC(),
 			UpdatedAt:    time.Now().UTC(),
 		}, nil)
-		accessToken, err := userUsecase.Register(context.Background(), registerDTO)
+		authTokens, err := userUsecase.Register(context.Background(), registerDTO)
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
+		assert.Nil(t, authTokens)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mock.Anything, mock.Anything) // will call find by email and find existing user
 		mockUserRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)   // will not be continued to Create, because existing user found
 		defer mockUserRepository.AssertExpectations(t)
@@ -88,21 +101,21 @@ func TestUserUsecase_Register(t *testing.T) {
 func TestUserUsecase_Login(t *testing.T) {
 	t.Run("test login wrong credential", func(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
-		userUsecase := NewUserUsecase(mockUserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
 		loginDTO := &domain.LoginDTO{
 			Email:    "testtest@gmail.com",
 			Password: "Test*999",
 		}
 		mockUserRepository.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
-		accessToken, err := userUsecase.Login(context.Background(), loginDTO)
+		authTokens, err := userUsecase.Login(context.Background(), loginDTO)
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
+		assert.Nil(t, authTokens)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mock.Anything, mock.Anything)
 		defer mockUserRepository.AssertExpectations(t)
 	})
 	t.Run("test login correct credential", func(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
-		userUsecase := NewUserUsecase(mockUserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
 		loginDTO := &domain.LoginDTO{
 			Email:    "testtest@gmail.com",
 			Password: "Test*999",
@@ -119,10 +132,362 @@ func TestUserUsecase_Login(t *testing.T) {
 			CreatedAt:    time.Now().UTC(),
 			UpdatedAt:    time.Now().UTC(),
 		}, nil)
-		accessToken, err := userUsecase.Login(context.Background(), loginDTO)
+		mockUserRepository.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+		authTokens, err := userUsecase.Login(context.Background(), loginDTO)
 		assert.NoError(t, err)
-		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, authTokens.AccessToken)
+		assert.NotEmpty(t, authTokens.RefreshToken)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mock.Anything, mock.Anything)
 		defer mockUserRepository.AssertExpectations(t)
 	})
 }
+
+func TestUserUsecase_RefreshToken(t *testing.T) {
+	t.Run("test refresh rotates the refresh token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("RotateRefreshToken", mock.Anything, helper.HashToken("refresh-token"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*entity.RefreshToken).UserId = 1
+			}).
+			Return(nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(&entity.User{UserId: 1, Role: domain.READER}, nil)
+		authTokens, err := userUsecase.RefreshToken(context.Background(), &domain.RefreshTokenDTO{RefreshToken: "refresh-token"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, authTokens.AccessToken)
+		assert.NotEqual(t, "refresh-token", authTokens.RefreshToken)
+		claims, err := helper.VerifyJwt(authTokens.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.READER, claims.Role)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("test refresh with a reused refresh token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("RotateRefreshToken", mock.Anything, helper.HashToken("refresh-token"), mock.Anything).Return(domain.ErrRefreshTokenReused)
+		authTokens, err := userUsecase.RefreshToken(context.Background(), &domain.RefreshTokenDTO{RefreshToken: "refresh-token"})
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+		assert.Nil(t, authTokens)
+		mockUserRepository.AssertNotCalled(t, "FindById", mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	t.Run("test logout revokes access token and refresh token family", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		expiresAt := time.Now().Add(10 * time.Minute)
+		mockUserRepository.On("RevokeAccessToken", mock.Anything, "jti", expiresAt).Return(nil)
+		mockUserRepository.On("RevokeRefreshTokenFamily", mock.Anything, int64(1), helper.HashToken("refresh-token")).Return(nil)
+		err := userUsecase.Logout(context.Background(), 1, "jti", expiresAt, &domain.LogoutDTO{RefreshToken: "refresh-token"})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_UpdateUserRole(t *testing.T) {
+	t.Run("test promote reader to admin", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("UpdateUserRole", mock.Anything, int64(2), domain.ADMIN).Return(nil)
+		err := userUsecase.UpdateUserRole(context.Background(), 1, 2, &domain.UpdateUserRoleDTO{Role: domain.ADMIN})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("test admin changes own role", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		err := userUsecase.UpdateUserRole(context.Background(), 1, 1, &domain.UpdateUserRoleDTO{Role: domain.READER})
+		assert.ErrorIs(t, err, domain.ErrCannotChangeOwnRole)
+		mockUserRepository.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_CreateInvitation(t *testing.T) {
+	t.Run("test invite admin", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindByEmail", mock.Anything, "chef@gmail.com").Return(nil, sql.ErrNoRows)
+		mockUserRepository.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(invitation *entity.Invitation) bool {
+			return invitation.Email == "chef@gmail.com" && invitation.Role == domain.ADMIN && invitation.InvitedBy == 1
+		})).Return(nil)
+		invitation, token, err := userUsecase.CreateInvitation(context.Background(), 1, &domain.CreateInvitationDTO{Email: "chef@gmail.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, helper.HashToken(token), invitation.TokenHash) // only the hash of the token is stored
+		assert.WithinDuration(t, time.Now().Add(invitationExpiration), invitation.ExpiresAt, time.Minute)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("test invite registered email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindByEmail", mock.Anything, "chef@gmail.com").Return(&entity.User{UserId: 2, Email: "chef@gmail.com"}, nil)
+		_, _, err := userUsecase.CreateInvitation(context.Background(), 1, &domain.CreateInvitationDTO{Email: "chef@gmail.com"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateUser)
+		mockUserRepository.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_AcceptInvitation(t *testing.T) {
+	t.Run("test accept invitation logs the admin in", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("AcceptInvitation", mock.Anything, helper.HashToken("invitation-token"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				user := args.Get(2).(*entity.User)
+				user.UserId, user.Role, user.Email = 2, domain.ADMIN, "chef@gmail.com"
+			}).
+			Return(nil)
+		mockUserRepository.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+		authTokens, err := userUsecase.AcceptInvitation(context.Background(), &domain.AcceptInvitationDTO{
+			Token:    "invitation-token",
+			Password: "Test*999",
+			Name:     "Chef Juna",
+		})
+		assert.NoError(t, err)
+		claims, err := helper.VerifyJwt(authTokens.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ADMIN, claims.Role)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("test accept invalid invitation", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("AcceptInvitation", mock.Anything, helper.HashToken("invitation-token"), mock.Anything).Return(domain.ErrInvalidInvitation)
+		authTokens, err := userUsecase.AcceptInvitation(context.Background(), &domain.AcceptInvitationDTO{
+			Token:    "invitation-token",
+			Password: "Test*999",
+			Name:     "Chef Juna",
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidInvitation)
+		assert.Nil(t, authTokens)
+		mockUserRepository.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_UpdatePassword(t *testing.T) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("Test*999"), bcrypt.DefaultCost)
+	assert.NoError(t, err)
+	user := &entity.User{UserId: 1, Role: domain.READER, Email: "testtest@gmail.com", Password: string(hashedPassword)}
+
+	t.Run("test update password with wrong current password", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		authTokens, err := userUsecase.UpdatePassword(context.Background(), 1, &domain.UpdatePasswordDTO{CurrentPassword: "Wrong*999", NewPassword: "Test*1000"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCredential)
+		assert.Nil(t, authTokens)
+		mockUserRepository.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test update password issues a new session", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		mockUserRepository.On("UpdateUserPassword", mock.Anything, int64(1), mock.MatchedBy(func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(password), []byte("Test*1000")) == nil
+		})).Return(nil)
+		mockUserRepository.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+		authTokens, err := userUsecase.UpdatePassword(context.Background(), 1, &domain.UpdatePasswordDTO{CurrentPassword: "Test*999", NewPassword: "Test*1000"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, authTokens.AccessToken)
+		assert.NotEmpty(t, authTokens.RefreshToken)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_UpdateEmail(t *testing.T) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("Test*999"), bcrypt.DefaultCost)
+	assert.NoError(t, err)
+	user := &entity.User{UserId: 1, Role: domain.READER, Email: "testtest@gmail.com", Password: string(hashedPassword)}
+
+	t.Run("test update email to a registered email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		mockUserRepository.On("UpdateUserEmail", mock.Anything, int64(1), "taken@gmail.com").Return(domain.ErrDuplicateUser)
+		err := userUsecase.UpdateEmail(context.Background(), 1, &domain.UpdateEmailDTO{Email: "taken@gmail.com", CurrentPassword: "Test*999"})
+		assert.ErrorIs(t, err, domain.ErrDuplicateUser)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test update email with wrong current password", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		err := userUsecase.UpdateEmail(context.Background(), 1, &domain.UpdateEmailDTO{Email: "new@gmail.com", CurrentPassword: "Wrong*999"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCredential)
+		mockUserRepository.AssertNotCalled(t, "UpdateUserEmail", mock.Anything, mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test update email mails a verification to the new email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		mockUserRepository.On("UpdateUserEmail", mock.Anything, int64(1), "new@gmail.com").Return(nil)
+		mockUserRepository.On("CreateEmailVerificationToken", mock.Anything, mock.MatchedBy(func(emailVerificationToken *entity.EmailVerificationToken) bool {
+			return emailVerificationToken.UserId == 1 && emailVerificationToken.Email == "new@gmail.com"
+		})).Return(nil)
+		mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Mail) bool {
+			return m.To == "new@gmail.com"
+		})).Return(nil)
+		err := userUsecase.UpdateEmail(context.Background(), 1, &domain.UpdateEmailDTO{Email: "new@gmail.com", CurrentPassword: "Test*999"})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+		defer mockMailer.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_DeleteMe(t *testing.T) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("Test*999"), bcrypt.DefaultCost)
+	assert.NoError(t, err)
+	user := &entity.User{UserId: 1, Role: domain.READER, Email: "testtest@gmail.com", Password: string(hashedPassword)}
+
+	t.Run("test delete me", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(user, nil)
+		mockUserRepository.On("DeleteUser", mock.Anything, int64(1)).Return(nil)
+		err := userUsecase.DeleteMe(context.Background(), 1, &domain.DeleteMeDTO{CurrentPassword: "Test*999"})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_ForgotPassword(t *testing.T) {
+	t.Run("test forgot password of unknown email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindByEmail", mock.Anything, "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+		err := userUsecase.ForgotPassword(context.Background(), &domain.ForgotPasswordDTO{Email: "unknown@gmail.com"})
+		assert.NoError(t, err)
+		mockUserRepository.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test forgot password mails the token stored by its hash", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindByEmail", mock.Anything, "testtest@gmail.com").Return(&entity.User{UserId: 1, Email: "testtest@gmail.com", Name: "Test User"}, nil)
+		mockUserRepository.On("GetLatestPasswordResetToken", mock.Anything, int64(1)).Return(nil, sql.ErrNoRows)
+		var passwordResetToken *entity.PasswordResetToken
+		mockUserRepository.On("CreatePasswordResetToken", mock.Anything, mock.MatchedBy(func(token *entity.PasswordResetToken) bool {
+			passwordResetToken = token
+			return token.UserId == 1 && token.ExpiresAt.After(time.Now())
+		})).Return(nil)
+		var mail *domain.Mail
+		mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m *domain.Mail) bool {
+			mail = m
+			return m.To == "testtest@gmail.com"
+		})).Return(nil)
+		err := userUsecase.ForgotPassword(context.Background(), &domain.ForgotPasswordDTO{Email: "testtest@gmail.com"})
+		assert.NoError(t, err)
+		_, token, found := strings.Cut(mail.Body, "?token=")
+		assert.True(t, found)
+		token, _, _ = strings.Cut(token, "\n")
+		assert.Equal(t, helper.HashToken(token), passwordResetToken.TokenHash)
+		defer mockUserRepository.AssertExpectations(t)
+		defer mockMailer.AssertExpectations(t)
+	})
+	t.Run("test forgot password right after the previous mail", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindByEmail", mock.Anything, "testtest@gmail.com").Return(&entity.User{UserId: 1, Email: "testtest@gmail.com", Name: "Test User"}, nil)
+		mockUserRepository.On("GetLatestPasswordResetToken", mock.Anything, int64(1)).Return(&entity.PasswordResetToken{
+			UserId:    1,
+			CreatedAt: time.Now().Add(-10 * time.Second),
+		}, nil)
+		err := userUsecase.ForgotPassword(context.Background(), &domain.ForgotPasswordDTO{Email: "testtest@gmail.com"})
+		assert.NoError(t, err)
+		mockUserRepository.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test forgot password with failing mailer", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindByEmail", mock.Anything, "testtest@gmail.com").Return(&entity.User{UserId: 1, Email: "testtest@gmail.com", Name: "Test User"}, nil)
+		mockUserRepository.On("GetLatestPasswordResetToken", mock.Anything, int64(1)).Return(&entity.PasswordResetToken{
+			UserId:    1,
+			CreatedAt: time.Now().Add(-2 * time.Minute),
+		}, nil)
+		mockUserRepository.On("CreatePasswordResetToken", mock.Anything, mock.Anything).Return(nil)
+		mockMailer.On("Send", mock.Anything, mock.Anything).Return(assert.AnError)
+		err := userUsecase.ForgotPassword(context.Background(), &domain.ForgotPasswordDTO{Email: "testtest@gmail.com"})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+		defer mockMailer.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	t.Run("test reset password with used token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("ResetPassword", mock.Anything, helper.HashToken("used-token"), mock.Anything).Return(int64(0), domain.ErrInvalidPasswordResetToken)
+		err := userUsecase.ResetPassword(context.Background(), &domain.ResetPasswordDTO{Token: "used-token", NewPassword: "Test*1000"})
+		assert.ErrorIs(t, err, domain.ErrInvalidPasswordResetToken)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test reset password stores the hash of the new password", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("ResetPassword", mock.Anything, helper.HashToken("reset-token"), mock.MatchedBy(func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(password), []byte("Test*1000")) == nil
+		})).Return(int64(1), nil)
+		err := userUsecase.ResetPassword(context.Background(), &domain.ResetPasswordDTO{Token: "reset-token", NewPassword: "Test*1000"})
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_ResendEmailVerification(t *testing.T) {
+	t.Run("test resend to a verified email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		emailVerifiedAt := time.Now()
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(&entity.User{UserId: 1, Email: "testtest@gmail.com", EmailVerifiedAt: &emailVerifiedAt}, nil)
+		err := userUsecase.ResendEmailVerification(context.Background(), 1)
+		assert.ErrorIs(t, err, domain.ErrEmailAlreadyVerified)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test resend right after the previous mail", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		userUsecase := NewUserUsecase(mockUserRepository, nil)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(&entity.User{UserId: 1, Email: "testtest@gmail.com"}, nil)
+		mockUserRepository.On("GetLatestEmailVerificationToken", mock.Anything, int64(1)).Return(&entity.EmailVerificationToken{
+			UserId:    1,
+			Email:     "testtest@gmail.com",
+			CreatedAt: time.Now().Add(-10 * time.Second),
+		}, nil)
+		err := userUsecase.ResendEmailVerification(context.Background(), 1)
+		assert.ErrorIs(t, err, domain.ErrEmailVerificationThrottled)
+		mockUserRepository.AssertNotCalled(t, "CreateEmailVerificationToken", mock.Anything, mock.Anything)
+		defer mockUserRepository.AssertExpectations(t)
+	})
+	t.Run("test resend after the interval", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockMailer := new(mocks.Mailer)
+		userUsecase := NewUserUsecase(mockUserRepository, mockMailer)
+		mockUserRepository.On("FindById", mock.Anything, int64(1)).Return(&entity.User{UserId: 1, Email: "testtest@gmail.com"}, nil)
+		mockUserRepository.On("GetLatestEmailVerificationToken", mock.Anything, int64(1)).Return(&entity.EmailVerificationToken{
+			UserId:    1,
+			Email:     "testtest@gmail.com",
+			CreatedAt: time.Now().Add(-2 * time.Minute),
+		}, nil)
+		mockUserRepository.On("CreateEmailVerificationToken", mock.Anything, mock.Anything).Return(nil)
+		mockMailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		err := userUsecase.ResendEmailVerification(context.Background(), 1)
+		assert.NoError(t, err)
+		defer mockUserRepository.AssertExpectations(t)
+		defer mockMailer.AssertExpectations(t)
+	})
+}